@@ -0,0 +1,250 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/services"
+)
+
+// roleUser is the role of a stored user authenticated with their own password.
+// Unlike the admin roles it is not in the permissions matrix: a user may only create
+// comments in their own name and change their own comments.
+const roleUser = "user"
+
+// tokenTTL is how long a token issued by POST /api/token stays valid.
+const tokenTTL = 24 * time.Hour
+
+// principal is the authenticated caller of a request.
+type principal struct {
+	// UserId is the ID of the stored user
+	UserId int
+
+	// Username is the username the caller authenticated with
+	Username string
+
+	// Role is services.RoleAdmin, services.RoleModerator or roleUser
+	Role string
+
+	// password is the stored password hash the caller authenticated against, so a
+	// token stops working when the password is changed, see current
+	password string
+}
+
+// token is a bearer token issued by POST /api/token.
+type token struct {
+	principal principal
+	expires   time.Time
+}
+
+// tokenResponse is the body of the POST /api/token response.
+type tokenResponse struct {
+	Token     string    `json:"token"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// errUnauthorized is returned by authenticate when the credentials are missing or wrong.
+var errUnauthorized = errors.New("autentikasi diperlukan")
+
+// errMustChangePassword is returned by authenticate for an account that still has to
+// change its temporary password, which can only be done in the application.
+var errMustChangePassword = errors.New("akses ditolak: password harus diganti melalui aplikasi terlebih dahulu")
+
+// authenticate identifies the caller of a request from the Authorization header.
+//
+// Two schemes are accepted:
+//   - Basic: the username and password of a stored user. A user with an admin role gets
+//     that role, see model.User.Role, every other user gets roleUser. An account that
+//     must change its password is refused with errMustChangePassword.
+//   - Bearer: a token issued by POST /api/token that has not expired and whose user has
+//     not changed since, see current
+//
+// The password is checked before the storage lock is taken, so the slow bcrypt
+// comparison does not hold up the other requests. The storage lock is held when
+// authenticate returns without an error; the caller releases it.
+//
+// Parameters:
+//   - r: The request
+//
+// Returns:
+//   - principal: The authenticated caller
+//   - error: errUnauthorized when the header is missing or the credentials are wrong,
+//     errMustChangePassword when the password has to be changed first
+func (s *server) authenticate(r *http.Request) (principal, error) {
+	header := r.Header.Get("Authorization")
+
+	if value, found := strings.CutPrefix(header, "Bearer "); found {
+		s.mu.Lock()
+
+		t, ok := s.tokens[value]
+		if !ok || time.Now().After(t.expires) || !s.current(t.principal) {
+			delete(s.tokens, value)
+			s.mu.Unlock()
+			return principal{}, errUnauthorized
+		}
+
+		return t.principal, nil
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return principal{}, errUnauthorized
+	}
+
+	var user model.User
+	s.mu.Lock()
+	err := s.userService.FindUserByUsername(username, &user)
+	s.mu.Unlock()
+	if err != nil || !helper.CheckPasswordHash(password, user.Password) {
+		return principal{}, errUnauthorized
+	}
+
+	if user.MustChangePassword {
+		return principal{}, errMustChangePassword
+	}
+
+	caller := principal{UserId: user.Id, Username: user.Username, Role: roleOf(user), password: user.Password}
+
+	// The user may have changed while the password was checked without the lock
+	s.mu.Lock()
+	if !s.current(caller) {
+		s.mu.Unlock()
+		return principal{}, errUnauthorized
+	}
+
+	return caller, nil
+}
+
+// current reports whether the user of a principal still exists unchanged: it is not
+// deleted or pending deletion and still has the role and the password the principal
+// was authenticated with. The caller must hold the storage lock.
+//
+// Parameters:
+//   - caller: The principal to check
+//
+// Returns:
+//   - bool: true if the principal may still act for the user
+func (s *server) current(caller principal) bool {
+	valid := false
+	s.userService.ForEachUser(func(user model.User) bool {
+		if user.Id != caller.UserId {
+			return true
+		}
+
+		valid = user.DeletedAt.IsZero() && roleOf(user) == caller.Role && user.Password == caller.password
+		return false
+	})
+
+	return valid
+}
+
+// roleOf returns the role a user acts with in the API.
+//
+// Parameters:
+//   - user: The stored user
+//
+// Returns:
+//   - string: The admin role of the user, or roleUser for a regular user
+func roleOf(user model.User) string {
+	if services.IsAdminRole(user.Role) {
+		return user.Role
+	}
+
+	return roleUser
+}
+
+// authorized wraps a mutating handler so it only runs for an authenticated caller,
+// while holding the storage lock, see authenticate. A request without valid credentials
+// is answered with 401 Unauthorized and a WWW-Authenticate challenge, an account that
+// must change its password with 403 Forbidden.
+//
+// Parameters:
+//   - handler: The handler, receiving the authenticated caller
+//
+// Returns:
+//   - http.HandlerFunc: The wrapped handler
+func (s *server) authorized(handler func(w http.ResponseWriter, r *http.Request, caller principal)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		caller, err := s.authenticate(r)
+		if errors.Is(err, errMustChangePassword) {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="tugas-besar"`)
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		defer s.mu.Unlock()
+
+		handler(w, r, caller)
+	}
+}
+
+// canManage reports whether the caller may change a comment owned by a user.
+// The admin roles need the manage_comments permission, a user may only change
+// their own comments.
+//
+// Parameters:
+//   - caller: The authenticated caller
+//   - ownerId: The ID of the owner of the comment
+//
+// Returns:
+//   - error: An error explaining why access is denied, nil if it is allowed
+func (s *server) canManage(caller principal, ownerId int) error {
+	if caller.Role == roleUser {
+		if caller.UserId != ownerId {
+			return fmt.Errorf("akses ditolak: komentar milik user lain")
+		}
+
+		return nil
+	}
+
+	s.permissions.SetRole(caller.Role)
+	defer s.permissions.SetRole("")
+
+	return s.permissions.Check(services.PermissionManageComments)
+}
+
+// recordAudit records a change made through the API in the audit log with the caller as
+// the actor, when the caller has an admin role, like the changes made in the admin menu.
+// Users changing their own comments are not recorded, as in the user menu.
+//
+// Parameters:
+//   - caller: The authenticated caller
+//   - action: The change, e.g. "edit_comment" or "delete_comment"
+//   - targetId: The ID of the comment that was changed
+//   - before: The comment before the change, nil for a create
+//   - after: The comment after the change, nil for a delete
+func (s *server) recordAudit(caller principal, action string, targetId int, before any, after any) {
+	if caller.Role == roleUser {
+		return
+	}
+
+	s.audit.RecordBy(model.User{Id: caller.UserId, Username: caller.Username, Role: caller.Role}, action, targetId, before, after)
+}
+
+// issueToken answers POST /api/token with a bearer token for the caller, valid for
+// tokenTTL, so the password does not have to be sent with every request.
+func (s *server) issueToken(w http.ResponseWriter, r *http.Request, caller principal) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	value := hex.EncodeToString(secret)
+	expires := time.Now().Add(tokenTTL)
+	s.tokens[value] = token{principal: caller, expires: expires}
+
+	writeJSON(w, http.StatusCreated, tokenResponse{Token: value, Role: caller.Role, ExpiresAt: expires})
+}
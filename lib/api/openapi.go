@@ -0,0 +1,267 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/services"
+)
+
+// docsPage is the Swagger UI page served on /api/docs. It loads Swagger UI from a CDN
+// and points it at /api/openapi.json.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// dashboardPage is the dashboard served on /dashboard. It charts /api/stats with
+// Chart.js, loaded from a CDN, and refreshes every minute.
+//
+//go:embed dashboard.html
+var dashboardPage []byte
+
+// openAPI builds the OpenAPI 3 document of the routes registered in Handler.
+// The paths are described here, the schemas are generated from the Go types the
+// handlers encode and decode, so the fields cannot drift from the code.
+//
+// Returns:
+//   - map[string]any: The document, ready to be encoded as JSON
+func openAPI() map[string]any {
+	ref := func(name string) map[string]any {
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+
+	list := func(name string) map[string]any {
+		return map[string]any{"type": "array", "items": ref(name)}
+	}
+
+	response := func(description string, schema map[string]any) map[string]any {
+		if schema == nil {
+			return map[string]any{"description": description}
+		}
+
+		return map[string]any{
+			"description": description,
+			"content":     map[string]any{"application/json": map[string]any{"schema": schema}},
+		}
+	}
+
+	query := func(name string, description string, schema map[string]any) map[string]any {
+		return map[string]any{"name": name, "in": "query", "description": description, "schema": schema}
+	}
+
+	body := func(name string) map[string]any {
+		return map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": ref(name)}},
+		}
+	}
+
+	integer := map[string]any{"type": "integer"}
+	text := map[string]any{"type": "string"}
+	idParam := map[string]any{"name": "id", "in": "path", "required": true, "schema": integer}
+	secured := []any{map[string]any{"basicAuth": []any{}}, map[string]any{"bearerAuth": []any{}}}
+	page := []any{
+		query("offset", "Jumlah data yang dilewati", integer),
+		query("limit", "Jumlah data maksimum", integer),
+	}
+
+	failures := map[string]any{
+		"400": response("Permintaan tidak valid", ref("Error")),
+		"429": response("Terlalu banyak permintaan, lihat header Retry-After", ref("Error")),
+	}
+	with := func(responses map[string]any) map[string]any {
+		for code, value := range failures {
+			responses[code] = value
+		}
+
+		return responses
+	}
+	mutating := func(responses map[string]any) map[string]any {
+		responses["401"] = response("Autentikasi diperlukan", ref("Error"))
+		responses["403"] = response("Akses ditolak", ref("Error"))
+
+		return with(responses)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Tugas Besar API",
+			"version":     helper.Version,
+			"description": "REST API atas user dan komentar aplikasi analisis sentimen.",
+		},
+		"paths": map[string]any{
+			"/api/users": map[string]any{
+				"get": map[string]any{
+					"summary":    "Daftar user tanpa password",
+					"parameters": page,
+					"responses":  with(map[string]any{"200": response("Daftar user", list("User"))}),
+				},
+			},
+			"/api/comments": map[string]any{
+				"get": map[string]any{
+					"summary": "Daftar komentar proyek aktif",
+					"parameters": append([]any{
+						query("kategori", "Positif, Netral atau Negatif", text),
+						query("status", "Baru, Ditinjau atau Selesai", text),
+						query("q", "Teks yang dicari, tanpa membedakan huruf besar", text),
+						query("sort", "id, created_at, komentar atau kategori", text),
+						query("order", "asc atau desc", text),
+					}, page...),
+					"responses": with(map[string]any{"200": response("Daftar komentar", list("Comment"))}),
+				},
+				"post": map[string]any{
+					"summary":     "Tambah komentar",
+					"description": "User menambah komentar atas namanya sendiri; user_id lain membutuhkan izin manage_comments.",
+					"security":    secured,
+					"requestBody": body("CommentRequest"),
+					"responses":   mutating(map[string]any{"201": response("Komentar yang dibuat", ref("Comment"))}),
+				},
+			},
+			"/api/comments/{id}": map[string]any{
+				"get": map[string]any{
+					"summary":    "Satu komentar",
+					"parameters": []any{idParam},
+					"responses": with(map[string]any{
+						"200": response("Komentar", ref("Comment")),
+						"404": response("Komentar tidak ditemukan", ref("Error")),
+					}),
+				},
+				"put": map[string]any{
+					"summary":     "Ubah komentar",
+					"description": "Body harus membawa version yang terakhir dibaca.",
+					"security":    secured,
+					"parameters":  []any{idParam},
+					"requestBody": body("CommentRequest"),
+					"responses": mutating(map[string]any{
+						"200": response("Komentar yang diubah", ref("Comment")),
+						"404": response("Komentar tidak ditemukan", ref("Error")),
+						"409": response("Version sudah tidak berlaku", ref("Error")),
+					}),
+				},
+				"delete": map[string]any{
+					"summary":    "Hapus komentar",
+					"security":   secured,
+					"parameters": []any{idParam},
+					"responses": mutating(map[string]any{
+						"204": response("Komentar dihapus", nil),
+						"404": response("Komentar tidak ditemukan", ref("Error")),
+					}),
+				},
+			},
+			"/api/stats": map[string]any{
+				"get": map[string]any{
+					"summary": "Statistik komentar untuk dashboard",
+					"parameters": []any{
+						query("days", "Jumlah hari deret per hari, bawaan 30", integer),
+						query("words", "Jumlah kata teratas, bawaan 10", integer),
+					},
+					"responses": with(map[string]any{"200": response("Statistik", ref("Statistics"))}),
+				},
+			},
+			"/api/token": map[string]any{
+				"post": map[string]any{
+					"summary":   "Tukar basic auth dengan bearer token",
+					"security":  []any{map[string]any{"basicAuth": []any{}}},
+					"responses": mutating(map[string]any{"201": response("Token baru", ref("Token"))}),
+				},
+			},
+		},
+		"components": map[string]any{
+			"schemas": map[string]any{
+				"User":           schemaOf(reflect.TypeOf(userResponse{})),
+				"Comment":        schemaOf(reflect.TypeOf(model.Comment{})),
+				"CommentRequest": schemaOf(reflect.TypeOf(commentRequest{})),
+				"Token":          schemaOf(reflect.TypeOf(tokenResponse{})),
+				"Statistics":     schemaOf(reflect.TypeOf(services.Statistics{})),
+				"Error": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"error": text},
+				},
+			},
+			"securitySchemes": map[string]any{
+				"basicAuth":  map[string]any{"type": "http", "scheme": "basic"},
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// schemaOf generates the JSON schema of a Go type from its json struct tags.
+// Fields without omitempty are listed as required.
+//
+// Parameters:
+//   - t: The type to describe
+//
+// Returns:
+//   - map[string]any: The schema
+func schemaOf(t reflect.Type) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOf(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return map[string]any{"type": "integer"}
+	case reflect.Float64, reflect.Float32:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		var required []string
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			properties[name] = schemaOf(field.Type)
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, name)
+			}
+		}
+
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+
+		return schema
+	}
+
+	return map[string]any{"type": "string"}
+}
+
+// openAPISpec answers GET /api/openapi.json with the OpenAPI document.
+func (s *server) openAPISpec(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, openAPI())
+}
+
+// docs answers GET /api/docs with the Swagger UI page.
+func (s *server) docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsPage)
+}
+
+// dashboard answers GET /dashboard with the dashboard page.
+func (s *server) dashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(dashboardPage)
+}
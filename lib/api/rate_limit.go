@@ -0,0 +1,184 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tugas-besar/lib/helper"
+)
+
+// bucket is the token bucket of one client.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter limits the requests per client with a token bucket: every client may send
+// burst requests at once, refilled at perMinute requests per minute.
+type rateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	burst     int
+	buckets   map[string]*bucket
+}
+
+// newRateLimiter creates a rate limiter.
+//
+// Parameters:
+//   - perMinute: The sustained number of requests per minute, 0 disables the limiter
+//   - burst: The number of requests a client may send at once, at least 1
+//
+// Returns:
+//   - *rateLimiter: The new rate limiter
+func newRateLimiter(perMinute int, burst int) *rateLimiter {
+	return &rateLimiter{
+		perMinute: perMinute,
+		burst:     max(burst, 1),
+		buckets:   make(map[string]*bucket),
+	}
+}
+
+// allow takes one request from the bucket of a client.
+//
+// Parameters:
+//   - key: The client, e.g. its IP address or token
+//
+// Returns:
+//   - bool: true if the request may be served
+//   - time.Duration: How long to wait for the next request when it may not
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	if l.perMinute <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	rate := float64(l.perMinute) / 60
+
+	b, ok := l.buckets[key]
+	if !ok {
+		l.sweep(now, rate)
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+
+	b.tokens--
+
+	return true, 0
+}
+
+// sweep drops the buckets that have refilled completely, so clients that went away do
+// not keep memory. It only runs once many clients are tracked. The caller must hold mu.
+//
+// Parameters:
+//   - now: The current time
+//   - rate: The refill rate in requests per second
+func (l *rateLimiter) sweep(now time.Time, rate float64) {
+	if len(l.buckets) < 1024 {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*rate >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// limitFromEnv reads a limit setting.
+//
+// Parameters:
+//   - key: The environment variable
+//   - fallback: The value used when the variable is missing or invalid
+//
+// Returns:
+//   - int: The limit, never negative
+func limitFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(helper.GetEnv(key, strconv.Itoa(fallback)))
+	if err != nil || value < 0 {
+		return fallback
+	}
+
+	return value
+}
+
+// rateLimited wraps the API so every client is rate limited. Requests with a bearer
+// token issued by POST /api/token are limited per token with API_TOKEN_RATE_LIMIT
+// (default 300 per minute), every other request, including unknown tokens, per IP
+// address with API_RATE_LIMIT (default 60 per minute). Both
+// allow bursts of API_RATE_BURST requests (default 20) and 0 disables a limit.
+// A limited request is answered with 429 Too Many Requests and a Retry-After header.
+//
+// Parameters:
+//   - next: The handler to protect
+//
+// Returns:
+//   - http.Handler: The wrapped handler
+func (s *server) rateLimited(next http.Handler) http.Handler {
+	burst := limitFromEnv("API_RATE_BURST", 20)
+	perIP := newRateLimiter(limitFromEnv("API_RATE_LIMIT", 60), burst)
+	perToken := newRateLimiter(limitFromEnv("API_TOKEN_RATE_LIMIT", 300), burst)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limiter, key := perIP, clientIP(r)
+		if value, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found && s.issued(value) {
+			limiter, key = perToken, value
+		}
+
+		ok, wait := limiter.allow(key)
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, fmt.Errorf("terlalu banyak permintaan, coba lagi dalam %d detik", int(math.Ceil(wait.Seconds()))))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// issued reports whether a bearer token was issued by POST /api/token and has not expired.
+//
+// Parameters:
+//   - value: The bearer token
+//
+// Returns:
+//   - bool: true for a valid token
+func (s *server) issued(value string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[value]
+
+	return ok && time.Now().Before(t.expires)
+}
+
+// clientIP returns the IP address of the client of a request, without the port.
+//
+// Parameters:
+//   - r: The request
+//
+// Returns:
+//   - string: The IP address, or the raw remote address when it has no port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
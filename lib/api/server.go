@@ -0,0 +1,502 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+	"tugas-besar/lib/services"
+)
+
+// Server defines the interface for the REST API over the users and comments.
+// It exposes the same storage as the interactive menus, so every change made through
+// the API is journaled like any other change.
+type Server interface {
+	// Handler returns the HTTP handler with all API routes.
+	Handler() http.Handler
+
+	// Run listens on the given port until the process receives SIGINT or SIGTERM,
+	// then shuts down gracefully.
+	Run(port int) error
+}
+
+// server implements the Server interface using net/http.
+// The storage arrays are not safe for concurrent use, so every request holds mu.
+type server struct {
+	userService services.UserService
+	commentRepo repository.CommentRepository
+	permissions services.PermissionService
+	reports     services.ReportService
+	audit       services.AuditService
+	mu          sync.Mutex
+
+	// tokens holds the bearer tokens issued by POST /api/token, guarded by mu
+	tokens map[string]token
+}
+
+// userResponse is a user as returned by the API, without the password.
+type userResponse struct {
+	Id        int       `json:"id"`
+	Uuid      string    `json:"uuid,omitempty"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// commentRequest is the body of the create and update comment requests.
+type commentRequest struct {
+	UserId   int    `json:"user_id,omitempty"`
+	Komentar string `json:"komentar"`
+	Kategori string `json:"kategori"`
+	Version  int    `json:"version,omitempty"`
+}
+
+// NewServer creates and returns a new Server implementation.
+//
+// Parameters:
+//   - userService: The UserService implementation used to read users
+//   - commentRepo: The CommentRepository implementation used to read and change comments
+//   - permissions: The PermissionService implementation that decides what the admin roles may change
+//   - reports: The ReportService implementation used to aggregate the statistics
+//   - audit: The AuditService implementation the changes of the admin roles are recorded with
+//
+// Returns:
+//   - Server: A new instance of the server implementation
+func NewServer(userService services.UserService, commentRepo repository.CommentRepository, permissions services.PermissionService, reports services.ReportService, audit services.AuditService) Server {
+	return &server{
+		userService: userService,
+		commentRepo: commentRepo,
+		permissions: permissions,
+		reports:     reports,
+		audit:       audit,
+		tokens:      make(map[string]token),
+	}
+}
+
+// Handler returns the HTTP handler with all API routes:
+//
+//	GET    /api/users           List users, paged by ?offset= and ?limit=
+//	GET    /api/comments        List comments, filtered by ?kategori= and ?q=, ordered by ?sort= and ?order=,
+//	                            paged by ?offset= and ?limit=
+//	GET    /api/comments/{id}   Get one comment
+//	POST   /api/comments        Create a comment
+//	PUT    /api/comments/{id}   Update a comment, the body must carry the current version
+//	DELETE /api/comments/{id}   Delete a comment
+//	GET    /api/stats           Totals, counts per category, a per-day series of ?days= days and
+//	                            the ?words= most used words, for dashboards
+//	POST   /api/token           Issue a bearer token for the caller
+//	GET    /api/openapi.json    The OpenAPI document of these routes
+//	GET    /api/docs            Swagger UI for the OpenAPI document
+//	GET    /dashboard           Charts of /api/stats for viewing the sentiment in a browser
+//
+// The POST, PUT and DELETE routes need basic or bearer auth, see authenticate. Without
+// credentials they answer 401 Unauthorized; a caller that may not change the comment
+// gets 403 Forbidden. The changes made by an admin role are recorded in the audit log,
+// see recordAudit. Every route is rate limited per IP address or token, see rateLimited.
+//
+// Returns:
+//   - http.Handler: The rate limited router with every route serialized on the storage lock,
+//     the authenticated routes once the credentials are checked
+func (s *server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/users", s.locked(s.listUsers))
+	mux.HandleFunc("GET /api/comments", s.locked(s.listComments))
+	mux.HandleFunc("GET /api/comments/{id}", s.locked(s.getComment))
+	mux.HandleFunc("POST /api/comments", s.authorized(s.createComment))
+	mux.HandleFunc("PUT /api/comments/{id}", s.authorized(s.updateComment))
+	mux.HandleFunc("DELETE /api/comments/{id}", s.authorized(s.deleteComment))
+	mux.HandleFunc("GET /api/stats", s.locked(s.stats))
+	mux.HandleFunc("POST /api/token", s.authorized(s.issueToken))
+	mux.HandleFunc("GET /api/openapi.json", s.openAPISpec)
+	mux.HandleFunc("GET /api/docs", s.docs)
+	mux.HandleFunc("GET /dashboard", s.dashboard)
+
+	return s.rateLimited(mux)
+}
+
+// Run listens on the given port until the process receives SIGINT or SIGTERM.
+// On a signal the server stops accepting connections and waits up to ten seconds
+// for the requests in progress to finish.
+//
+// Parameters:
+//   - port: The TCP port to listen on
+//
+// Returns:
+//   - error: An error if the server cannot listen or does not shut down cleanly
+func (s *server) Run(port int) error {
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: s.Handler(),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	failed := make(chan error, 1)
+	go func() {
+		err := srv.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			failed <- err
+		}
+		close(failed)
+	}()
+
+	fmt.Printf("Server berjalan di http://localhost:%d\n", port)
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Menghentikan server...")
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return srv.Shutdown(shutdown)
+}
+
+// locked wraps a handler so it runs while holding the storage lock.
+//
+// Parameters:
+//   - handler: The handler to serialize
+//
+// Returns:
+//   - http.HandlerFunc: The wrapped handler
+func (s *server) locked(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		handler(w, r)
+	}
+}
+
+// listUsers writes the users without the password. The offset and limit query
+// parameters select one page, by default every user is written. Users pending deletion
+// are left out.
+func (s *server) listUsers(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := pageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var users [255]model.User
+	n, err := s.userService.GetUsers(offset, limit, &users)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := make([]userResponse, 0, n)
+	for i := 0; i < n; i++ {
+		if !users[i].DeletedAt.IsZero() {
+			continue
+		}
+
+		result = append(result, userResponse{users[i].Id, users[i].Uuid, users[i].Username, users[i].CreatedAt})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// listComments writes the comments, optionally filtered by the kategori, status (Baru,
+// Ditinjau or Selesai) and q (case-insensitive text search) query parameters and ordered by the sort (id,
+// created_at, komentar or kategori) and order (asc or desc) query parameters.
+// The offset and limit query parameters select one page of the matching comments,
+// by default every match is written.
+func (s *server) listComments(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := pageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	order := repository.Asc
+	switch r.URL.Query().Get("order") {
+	case "", "asc":
+	case "desc":
+		order = repository.Desc
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("order harus asc atau desc"))
+		return
+	}
+
+	var comments [255]model.Comment
+	n, err := s.commentRepo.Comments().
+		WhereKategori(services.NormalizeKategori(r.URL.Query().Get("kategori"))).
+		WhereStatus(r.URL.Query().Get("status")).
+		Contains(r.URL.Query().Get("q")).
+		OrderBy(r.URL.Query().Get("sort"), order).
+		Offset(offset).
+		Limit(limit).
+		Find(&comments)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	list := make([]model.Comment, n)
+	for i := range list {
+		list[i] = public(comments[i])
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// public returns a comment without the internal admin note, which is never served by the API.
+func public(comment model.Comment) model.Comment {
+	comment.Catatan = ""
+	return comment
+}
+
+// stats writes the statistics of the comments for dashboards. The days query parameter
+// sets the length of the per-day series (default 30, at most 366) and the words query
+// parameter the number of top words (default 10, at most 100).
+func (s *server) stats(w http.ResponseWriter, r *http.Request) {
+	days, err := intParam(r, "days", 30, 366)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	words, err := intParam(r, "words", 10, 100)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.reports.Statistics(days, words)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// intParam reads a positive integer query parameter.
+//
+// Parameters:
+//   - r: The request
+//   - name: The name of the query parameter
+//   - fallback: The value used when the parameter is missing
+//   - limit: The highest allowed value
+//
+// Returns:
+//   - int: The value of the parameter
+//   - error: An error if the value is not a number between 1 and limit
+func intParam(r *http.Request, name string, fallback int, limit int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > limit {
+		return 0, fmt.Errorf("%s harus angka antara 1 dan %d", name, limit)
+	}
+
+	return n, nil
+}
+
+// pageParams reads the offset and limit query parameters of a list request.
+// The offset defaults to 0 and the limit to the size of the storage array.
+func pageParams(r *http.Request) (int, int, error) {
+	offset, limit := 0, 255
+
+	if value := r.URL.Query().Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("offset tidak valid")
+		}
+		offset = n
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("limit tidak valid")
+		}
+		limit = n
+	}
+
+	return offset, limit, nil
+}
+
+// getComment writes the comment with the ID in the path.
+func (s *server) getComment(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("id tidak valid"))
+		return
+	}
+
+	var comment model.Comment
+	err = s.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, public(comment))
+}
+
+// createComment stores a new comment from the request body and writes it back with its ID.
+// A user creates comments in their own name; the user_id of the body defaults to the
+// caller and naming another user needs the manage_comments permission.
+func (s *server) createComment(w http.ResponseWriter, r *http.Request, caller principal) {
+	var body commentRequest
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("body tidak valid: %v", err))
+		return
+	}
+
+	kategori := services.NormalizeKategori(body.Kategori)
+	if strings.TrimSpace(body.Komentar) == "" || kategori == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("komentar dan kategori (Positif, Netral, Negatif) wajib diisi"))
+		return
+	}
+
+	if caller.Role == roleUser && body.UserId == 0 {
+		body.UserId = caller.UserId
+	}
+
+	err = s.canManage(caller, body.UserId)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	comment := model.Comment{Komentar: body.Komentar, Kategori: kategori}
+	err = s.commentRepo.Create(&comment, body.UserId)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.commentRepo.FindCommentById(comment.Id, &comment)
+	s.recordAudit(caller, "create_comment", comment.Id, nil, comment)
+	writeJSON(w, http.StatusCreated, public(comment))
+}
+
+// updateComment changes the text and/or category of the comment with the ID in the path.
+// The body must carry the version the client read, a stale version answers 409 Conflict.
+// Only the owner or an admin role with the manage_comments permission may update it.
+func (s *server) updateComment(w http.ResponseWriter, r *http.Request, caller principal) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("id tidak valid"))
+		return
+	}
+
+	var body commentRequest
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("body tidak valid: %v", err))
+		return
+	}
+
+	kategori := services.NormalizeKategori(body.Kategori)
+	if body.Kategori != "" && kategori == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("kategori harus Positif, Netral atau Negatif"))
+		return
+	}
+
+	var comment model.Comment
+	err = s.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	err = s.canManage(caller, comment.UserId)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.commentRepo.EditComment(id, model.Comment{Komentar: body.Komentar, Kategori: kategori, Version: body.Version})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	before := comment
+	s.commentRepo.FindCommentById(id, &comment)
+	s.recordAudit(caller, "edit_comment", id, before, comment)
+	writeJSON(w, http.StatusOK, public(comment))
+}
+
+// deleteComment removes the comment with the ID in the path.
+// Only the owner or an admin role with the manage_comments permission may delete it.
+func (s *server) deleteComment(w http.ResponseWriter, r *http.Request, caller principal) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("id tidak valid"))
+		return
+	}
+
+	var comment model.Comment
+	err = s.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	err = s.canManage(caller, comment.UserId)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	err = s.commentRepo.DeleteComment(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	s.recordAudit(caller, "delete_comment", id, comment, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes a value as a JSON response.
+//
+// Parameters:
+//   - w: The response writer
+//   - status: The HTTP status code
+//   - value: The value to encode
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an error as a JSON response of the form {"error": "..."}.
+//
+// Parameters:
+//   - w: The response writer
+//   - status: The HTTP status code
+//   - err: The error to report
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
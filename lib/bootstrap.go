@@ -1,14 +1,27 @@
 package lib
 
 import (
+	"errors"
+	"runtime/debug"
+
+	"github.com/fatih/color"
+
 	"tugas-besar/lib/config"
+	"tugas-besar/lib/helper"
 	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+	"tugas-besar/lib/services"
 )
 
 // Bootstrap initializes the application by loading environment configurations.
-// It calls config.GetEnvConfig() to load environment variables from the .env file.
+// It calls config.GetEnvConfig() to load environment variables from the .env file,
+// config.GetMenuConfig() to load the menu labels and hidden items
+// and replays the operation journal so data from previous runs is recovered. A journal
+// that does not match its checksum is not loaded until the user restores a backup or
+// accepts it, see services.ProfileService.Recover; the application exits when that fails.
 // After initializing configurations, it enters an infinite loop to keep the
-// application running. This function is called from the main function to start
+// application running. Every pass through the main menu recovers from panics,
+// see mainMenu. This function is called from the main function to start
 // the application processes.
 //
 // The function does not accept any parameters and does not return any values.
@@ -18,50 +31,211 @@ func Bootstrap() {
 
 	// Configuration
 	config.GetEnvConfig()
+	config.GetMenuConfig()
+
+	// Esc and :menu jump back to the main menu from any prompt
+	helper.InstallShortcuts()
 
 	// Dependency Injection
 	container := config.DependencyConfig()
 
-	for {
-		container.MainController.MainMenu(&result)
+	// Crash recovery, replaying the journal of the startup profile. A journal that fails
+	// its checksum is not loaded until it is restored from a backup or accepted; without
+	// it nothing else may run, so the application exits when the recovery fails
+	err := container.ProfileService.Switch(helper.GetEnv("PROFILE", services.DefaultProfile))
+	if errors.Is(err, repository.ErrChecksumMismatch) {
+		err = container.ProfileService.Recover(err)
+		if errors.Is(err, helper.ErrBack) {
+			return
+		}
+		if err != nil {
+			color.Red(err.Error())
+			helper.Pause()
+			return
+		}
+	}
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
 
-		if result == "Exit" {
-			break
+	// Settings
+	container.SettingsService.Apply()
+
+	// Retention policy
+	err = container.RetentionService.Expire()
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+
+	// Deleted users past their grace period
+	_, err = container.UserService.PurgeDeletedUsers()
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+
+	// Background jobs
+	container.ReportService.StartSchedule()
+	container.SettingsService.StartBackup()
+	container.CommentService.StartPublisher()
+	container.UpdateService.Start()
+	helper.StartIdleLock()
+
+	container.AdminController.SetUserMenu(func(user model.User) {
+		var result string
+		userMenu(container, &result, &user)
+	})
+
+	for mainMenu(container, &result, &user) {
+	}
+}
+
+// mainMenu shows the main menu once and runs the selected flow.
+//
+// A panic anywhere in the flow is recovered: the in-memory state is dumped to a crash
+// file with helper.DumpCrash, a message is shown, the user is logged out and the
+// application returns to the main menu instead of exiting.
+//
+// Parameters:
+//   - container: The wired dependencies
+//   - result: Pointer to the selected menu option
+//   - user: Pointer to the logged-in user
+//
+// Returns:
+//   - bool: false when Exit was chosen, true to show the main menu again
+func mainMenu(container *config.AppContainer, result *string, user *model.User) (running bool) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		*user = model.User{}
+		container.ProjectController.Reset()
+		helper.EndSession()
+
+		color.Red("Terjadi kesalahan tak terduga: %v", recovered)
+
+		path, err := helper.DumpCrash(recovered, debug.Stack())
+		if err != nil {
+			color.Red("Data tidak dapat disimpan ke file crash: %v", err)
+		} else {
+			color.Yellow("Data tersimpan di %s. Lampirkan file ini saat melaporkan bug.", path)
 		}
 
-		switch result {
-		case "Login":
-			container.AuthController.Login(&user)
-			if user.Username != "" {
-				for {
-					err := container.UserController.UserPage(&result)
-					if err != nil {
-						break
-					}
-
-					if result == "Exit" {
-						user.Username = ""
-						user.Password = ""
-						break
-					}
-
-					switch result {
-					case "Tambah Komentar":
-						container.CommentController.CommentInputPage(user)
-					case "Lihat Komentar":
-						container.CommentController.CommentView()
-					case "Edit Komentar":
-						container.CommentController.EditComment(user)
-					case "Delete Komentar":
-						container.CommentController.DeleteComment(user)
-					}
-				}
+		helper.Pause()
+
+		running = true
+	}()
+
+	container.MainController.MainMenu(result)
+
+	if *result == "Exit" {
+		return false
+	}
+
+	defer helper.TraceTime("main > " + *result)()
+
+	switch *result {
+	case "Login":
+		container.AuthController.Login(user)
+		if user.MustChangePassword && !container.UserController.ChangePassword(user) {
+			*user = model.User{}
+		}
+
+		if services.IsAdminRole(user.Role) {
+			// Accounts with an admin role go straight to the admin menu
+			container.AdminController.AdminMenuAs(*user)
+			*user = model.User{}
+		}
+
+		if user.Username != "" {
+			helper.LockSession(func() (bool, error) { return container.UserController.Unlock(*user) })
+			container.ProjectController.PilihProyek(false)
+			userMenu(container, result, user)
+			container.ProjectController.Reset()
+			helper.EndSession()
+		}
+	case "Register":
+		container.AuthController.Register()
+	case "Cari":
+		container.SearchController.Cari()
+	case "Profil":
+		container.ProfileController.ProfileMenu()
+	case "Bantuan":
+		container.MainController.Bantuan()
+	case "Tentang":
+		container.MainController.Tentang()
+	case "Lihat sebagai Tamu":
+		for {
+			container.GuestController.GuestMenu(result)
+
+			if *result == "Exit" {
+				break
+			}
+
+			done := helper.TraceTime("tamu > " + *result)
+			switch *result {
+			case "Lihat Komentar":
+				container.CommentController.CommentView()
+			case "Statistik":
+				container.GuestController.Statistik()
+			case "Mode Kiosk":
+				container.GuestController.Kiosk()
 			}
-		case "Register":
-			container.AuthController.Register()
-		case "Admin":
-			container.AdminController.AdminMenu()
+			done()
 		}
+	case "Admin":
+		container.AdminController.AdminMenu()
 	}
 
+	return true
+}
+
+// userMenu shows the user menu for a logged-in user and runs the selected flows until
+// the user exits, which logs the user out. It is also run by the admin to impersonate
+// a user, see controllers.AdminController.Impersonasi.
+//
+// Parameters:
+//   - container: The wired dependencies
+//   - result: Pointer to the selected menu option
+//   - user: Pointer to the logged-in user
+func userMenu(container *config.AppContainer, result *string, user *model.User) {
+	for {
+		err := container.UserController.UserPage(*user, result)
+		if err != nil {
+			break
+		}
+
+		if *result == "Exit" {
+			user.Username = ""
+			user.Password = ""
+			break
+		}
+
+		done := helper.TraceTime("user > " + *result)
+		switch *result {
+		case "Tambah Komentar":
+			container.CommentController.CommentInputPage(*user)
+		case "Input Cepat":
+			container.CommentController.RapidEntry(*user)
+		case "Lihat Komentar":
+			container.CommentController.CommentView()
+		case "Balas Komentar":
+			container.CommentController.BalasKomentar(*user)
+		case "Edit Komentar":
+			container.CommentController.EditComment(*user)
+		case "Delete Komentar":
+			container.CommentController.DeleteComment(*user)
+		case "Komentar Terjadwal":
+			container.CommentController.ScheduledComment(*user)
+		case "Favorit":
+			container.CommentController.Favorit(*user)
+		case "Label Kedua":
+			container.CommentController.LabelKedua(*user)
+		}
+		done()
+	}
 }
@@ -0,0 +1,344 @@
+package lib
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+
+	"tugas-besar/lib/config"
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/services"
+)
+
+// Command runs a non-interactive subcommand given on the command line, so the
+// classifier can be used from scripts and for quick demos without the menus.
+//
+// Supported subcommands:
+//   - classify [--hf] "teks komentar": Prints the predicted category and the score,
+//     separated by a tab. With --hf the configured HuggingFace model is used instead
+//     of the lexicon analyzer
+//   - stats [--json]: Prints the total users, total comments, comments per category and
+//     comment lengths of the startup profile, as plain text or as a JSON object
+//   - export --format csv|json|xlsx --out file [--notes] [--anonymize]: Exports the users and
+//     comments of the startup profile through the same code path as the Export Data report
+//   - import --file data.csv [--source twitter] [--merge] [--auto-label]: Imports a CSV or
+//     JSON file into the startup profile through the same code path as Import Komentar
+//   - version: Prints the version, commit and build date of the binary
+//   - serve [--port 8080]: Starts the REST API on the data of the startup profile until
+//     the process is interrupted
+//
+// Parameters:
+//   - args: The command-line arguments after the program name
+//
+// Returns:
+//   - int: The exit code, 0 on success, 1 when classification fails and 2 on wrong usage
+func Command(args []string) int {
+	// A missing .env file is not an error here, scripts should only see the result
+	godotenv.Load()
+
+	switch args[0] {
+	case "classify":
+		return classify(args[1:])
+	case "stats":
+		return stats(args[1:])
+	case "export":
+		return export(args[1:])
+	case "import":
+		return importFile(args[1:])
+	case "serve":
+		return serve(args[1:])
+	case "version":
+		fmt.Println(helper.BuildInfo())
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "perintah tidak dikenal: %s\n", args[0])
+	fmt.Fprintln(os.Stderr, "penggunaan: app classify [--hf] \"teks komentar\"")
+	fmt.Fprintln(os.Stderr, "          app stats [--json]")
+	fmt.Fprintln(os.Stderr, "          app export --format csv|json|xlsx --out file [--notes] [--anonymize]")
+	fmt.Fprintln(os.Stderr, "          app import --file data.csv [--source twitter] [--merge] [--auto-label]")
+	fmt.Fprintln(os.Stderr, "          app serve [--port 8080]")
+	fmt.Fprintln(os.Stderr, "          app version")
+
+	return 2
+}
+
+// classify prints the predicted category and score of a text.
+//
+// Parameters:
+//   - args: The words of the text, optionally preceded by --hf
+//
+// Returns:
+//   - int: The exit code, 0 on success, 1 when classification fails and 2 on wrong usage
+func classify(args []string) int {
+	remote := len(args) > 0 && args[0] == "--hf"
+	if remote {
+		args = args[1:]
+	}
+
+	text := strings.TrimSpace(strings.Join(args, " "))
+	if text == "" {
+		fmt.Fprintln(os.Stderr, "penggunaan: app classify [--hf] \"teks komentar\"")
+		return 2
+	}
+
+	if remote {
+		kategori, score, err := services.NewInferenceService().Classify(text)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		fmt.Printf("%s\t%.4f\n", kategori, score)
+		return 0
+	}
+
+	kategori, score := services.NewSentimentService().Analyze(text)
+	fmt.Printf("%s\t%d\n", kategori, score)
+
+	return 0
+}
+
+// summary is the output of the stats subcommand.
+type summary struct {
+	TotalUsers    int                 `json:"total_users"`
+	TotalComments int                 `json:"total_comments"`
+	PerCategory   map[string]int      `json:"per_category"`
+	Length        []model.LengthStats `json:"length"`
+}
+
+// stats prints a summary of the data of the startup profile (PROFILE).
+// The journal is replayed to load the data, exactly as on a normal start.
+//
+// Parameters:
+//   - args: Empty, or --json to print the summary as a JSON object
+//
+// Returns:
+//   - int: The exit code, 0 on success, 1 when the journal cannot be replayed and 2 on wrong usage
+func stats(args []string) int {
+	asJSON := len(args) == 1 && args[0] == "--json"
+	if len(args) > 0 && !asJSON {
+		fmt.Fprintln(os.Stderr, "penggunaan: app stats [--json]")
+		return 2
+	}
+
+	container, err := load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	statistics, err := container.ReportService.Statistics(0, 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	result := summary{
+		TotalUsers:    global.UserCount,
+		TotalComments: global.CommentCount,
+		PerCategory:   map[string]int{"Positif": 0, "Netral": 0, "Negatif": 0},
+		Length:        statistics.Length,
+	}
+	for i := 0; i < global.CommentCount; i++ {
+		result.PerCategory[global.Comments[i].Kategori]++
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(result)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		return 0
+	}
+
+	fmt.Printf("User:     %d\n", result.TotalUsers)
+	fmt.Printf("Komentar: %d\n", result.TotalComments)
+	for _, kategori := range []string{"Positif", "Netral", "Negatif"} {
+		fmt.Printf("%-9s %d\n", kategori+":", result.PerCategory[kategori])
+	}
+
+	fmt.Println()
+	fmt.Printf("%-9s %6s %9s %6s %6s %6s\n", "Panjang", "Jumlah", "Rata-rata", "Median", "Min", "Maks")
+	for _, length := range result.Length {
+		fmt.Printf("%-9s %6d %9.1f %6.1f %6d %6d\n", length.Kategori, length.Count, length.Average, length.Median, length.Shortest, length.Longest)
+	}
+
+	return 0
+}
+
+// export writes the users and comments of the startup profile to a file.
+//
+// Parameters:
+//   - args: The flags --format (csv, json or xlsx, default csv), --out (required) and
+//     --notes to include the admin notes of the comments and --anonymize to replace the
+//     users by pseudonyms
+//
+// Returns:
+//   - int: The exit code, 0 on success, 1 when the export fails and 2 on wrong usage
+func export(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "csv", "csv, json atau xlsx")
+	out := flags.String("out", "", "nama file hasil export")
+	notes := flags.Bool("notes", false, "sertakan catatan admin")
+	anonymize := flags.Bool("anonymize", false, "ganti user dengan pseudonim")
+
+	err := flags.Parse(args)
+	if err != nil || *out == "" {
+		fmt.Fprintln(os.Stderr, "penggunaan: app export --format csv|json|xlsx --out file [--notes] [--anonymize]")
+		return 2
+	}
+
+	container, err := load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	paths, err := container.ReportService.Export(*format, *out, *notes, *anonymize)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+
+	return 0
+}
+
+// importFile imports a CSV or JSON file and prints a plain-text summary.
+// Like the interactive import, the whole file is rolled back when a row cannot be stored,
+// and the stored changes are recorded in the audit log under the account that ran it.
+//
+// Parameters:
+//   - args: The flags --file (required), --source (default: the file name without
+//     extension), --merge and --auto-label
+//
+// Returns:
+//   - int: The exit code, 0 on success, 1 when the import fails and 2 on wrong usage
+func importFile(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := flags.String("file", "", "file .csv atau .json")
+	source := flags.String("source", "", "sumber data")
+	merge := flags.Bool("merge", false, "gabungkan duplikat alih-alih melewatinya")
+	autoLabel := flags.Bool("auto-label", false, "label otomatis baris tanpa kategori")
+
+	err := flags.Parse(args)
+	if err != nil || *path == "" {
+		fmt.Fprintln(os.Stderr, "penggunaan: app import --file data.csv [--source twitter] [--merge] [--auto-label]")
+		return 2
+	}
+
+	if *source == "" {
+		*source = strings.TrimSuffix(filepath.Base(*path), filepath.Ext(*path))
+	}
+
+	container, err := load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	container.AuditService.SetActor(model.User{Username: commandActor()})
+
+	result, err := container.ImportService.ImportFile(*path, *source, *merge, *autoLabel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Baris dibaca:      %d\n", result.Total)
+	fmt.Printf("Berhasil diimport: %d\n", result.Imported)
+	fmt.Printf("Duplikat dilewati: %d\n", result.Skipped)
+	fmt.Printf("Duplikat digabung: %d\n", result.Merged)
+	fmt.Printf("Dilabeli otomatis: %d\n", result.AutoLabeled)
+	fmt.Printf("Ditolak:           %d\n", len(result.Rejected))
+	if result.RejectsPath != "" {
+		fmt.Printf("Baris yang ditolak ditulis ke %s\n", result.RejectsPath)
+	}
+
+	return 0
+}
+
+// commandActor names the actor of the changes made by a subcommand in the audit log,
+// which is the operating system account that ran it.
+//
+// Returns:
+//   - string: "cli:" followed by the name of the account, or just "cli" if it is unknown
+func commandActor() string {
+	current, err := user.Current()
+	if err != nil {
+		return "cli"
+	}
+
+	return "cli:" + current.Username
+}
+
+// serve starts the REST API and blocks until it has shut down.
+// The journal of the startup profile is replayed first. Background jobs are not started
+// because they would change the storage outside the lock of the API.
+//
+// Parameters:
+//   - args: The flag --port (default API_PORT, or 8080)
+//
+// Returns:
+//   - int: The exit code, 0 after a graceful shutdown, 1 when the server fails and 2 on wrong usage
+func serve(args []string) int {
+	port, _ := strconv.Atoi(helper.GetEnv("API_PORT", "8080"))
+
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.IntVar(&port, "port", port, "port HTTP")
+
+	err := flags.Parse(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "penggunaan: app serve [--port 8080]")
+		return 2
+	}
+
+	container, err := load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	err = container.ApiServer.Run(port)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// load wires the dependencies and replays the journal of the startup profile (PROFILE),
+// exactly as on a normal start, without any output.
+//
+// Returns:
+//   - *config.AppContainer: The wired dependencies with the data loaded
+//   - error: An error if the journal cannot be replayed
+func load() (*config.AppContainer, error) {
+	container := config.DependencyConfig()
+
+	err := container.ProfileService.Switch(helper.GetEnv("PROFILE", services.DefaultProfile))
+	if err != nil {
+		return nil, err
+	}
+
+	return container, nil
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"tugas-besar/lib/api"
 	"tugas-besar/lib/controllers"
+	"tugas-besar/lib/helper"
 	"tugas-besar/lib/repository"
 	"tugas-besar/lib/services"
 )
@@ -15,6 +17,46 @@ type AppContainer struct {
 	UserController    *controllers.UserController
 	CommentController *controllers.CommentController
 	AdminController   *controllers.AdminController
+	GuestController   *controllers.GuestController
+	ProfileController *controllers.ProfileController
+	ProjectController *controllers.ProjectController
+	SearchController  *controllers.SearchController
+
+	// Journal is the operation journal shared by all repositories.
+	// It is exposed so the bootstrap can replay it on startup.
+	Journal repository.JournalRepository
+
+	// ReportService is exposed so the bootstrap can start the scheduled report delivery.
+	ReportService services.ReportService
+
+	// SettingsService is exposed so the bootstrap can apply the settings and start the backups.
+	SettingsService services.SettingsService
+
+	// ProfileService is exposed so the bootstrap can load the startup profile.
+	ProfileService services.ProfileService
+
+	// CommentService is exposed so the bootstrap can start publishing scheduled comments.
+	CommentService services.CommentService
+
+	// UserService is exposed so the bootstrap can remove the deleted users whose grace
+	// period has passed.
+	UserService services.UserService
+
+	// RetentionService is exposed so the bootstrap can expire old comments at startup.
+	RetentionService services.RetentionService
+
+	// UpdateService is exposed so the bootstrap can start the update check.
+	UpdateService services.UpdateService
+
+	// ImportService is exposed so the import subcommand can load files without the menus.
+	ImportService services.ImportService
+
+	// AuditService is exposed so the import subcommand can record its changes under the
+	// account that ran it.
+	AuditService services.AuditService
+
+	// ApiServer is exposed so the serve subcommand can start the REST API.
+	ApiServer api.Server
 }
 
 // DependencyConfig initializes and wires all application dependencies.
@@ -22,18 +64,47 @@ type AppContainer struct {
 // following the dependency injection pattern.
 // Returns an AppContainer with all initialized controllers ready for use.
 func DependencyConfig() *AppContainer {
+	ids := repository.NewIDGenerator(helper.GetEnv("USE_UUID", "false") == "true")
+	events := repository.NewEventBus()
+	journalFile := helper.GetEnv("JOURNAL_FILE", "journal.jsonl")
+	journal := repository.NewJournalRepository(journalFile, ids, events)
+	tx := repository.NewTransactionRepository(journal, ids)
+
 	mainService := services.NewMainService()
 	mainController := controllers.NewMainController(mainService)
-	commentService := services.NewCommentService(repository.NewCommentRepository())
-	userService := services.NewUserService(repository.NewUserRepository())
+	commentRepo := repository.NewCommentRepository(journal, ids)
+	templateRepo := repository.NewTemplateRepository(journal)
+	userRepo := repository.NewUserRepository(journal, ids)
+	sentimentService := services.NewSentimentService()
+	commentService := services.NewCommentService(commentRepo, repository.NewScheduleRepository(journal, ids), templateRepo, userRepo, repository.NewBookmarkRepository(journal), sentimentService)
+	userService := services.NewUserService(userRepo, repository.NewLoginRepository(journal))
 
 	authService := services.NewAuthService(userService)
 	authController := controllers.NewAuthController(authService)
 	userController := controllers.NewUserController(userService)
 	commentController := controllers.NewCommentController(commentService)
 
-	adminService := services.NewAdminService(userService, commentService, repository.NewCommentRepository())
-	adminController := controllers.NewAdminController(adminService)
+	mailService := services.NewMailService()
+	reportService := services.NewReportService(userService, repository.NewCommentRepository(journal, ids), sentimentService, mailService, services.NewInferenceService())
+
+	auditService := services.NewAuditService(repository.NewAuditRepository(helper.GetEnv("AUDIT_FILE", "audit.jsonl")))
+	adminService := services.NewAdminService(userService, commentService, repository.NewCommentRepository(journal, ids), journal, tx, sentimentService, auditService)
+	importService := services.NewImportService(userService, repository.NewCommentRepository(journal, ids), tx, sentimentService, auditService)
+	maintenanceService := services.NewMaintenanceService(userService, repository.NewCommentRepository(journal, ids), repository.NewIntegrityRepository(ids), tx, journal, auditService)
+	settingsService := services.NewSettingsService(helper.EnvFile, journal)
+	projectService := services.NewProjectService(repository.NewProjectRepository(journal), repository.NewCommentRepository(journal, ids))
+	projectController := controllers.NewProjectController(projectService)
+	adminController := controllers.NewAdminController(adminService, reportService, importService, maintenanceService, services.NewPermissionService(), settingsService, projectService, services.NewTemplateService(templateRepo), auditService)
+
+	guestService := services.NewGuestService(repository.NewCommentRepository(journal, ids), events)
+	guestController := controllers.NewGuestController(guestService)
+
+	searchController := controllers.NewSearchController(services.NewSearchService(userService, repository.NewCommentRepository(journal, ids)))
+
+	profileService := services.NewProfileService(journalFile, journal)
+	profileController := controllers.NewProfileController(profileService)
+
+	retentionService := services.NewRetentionService(repository.NewCommentRepository(journal, ids), tx)
 
 	return &AppContainer{
 		MainController:    mainController,
@@ -41,5 +112,20 @@ func DependencyConfig() *AppContainer {
 		UserController:    userController,
 		CommentController: commentController,
 		AdminController:   adminController,
+		GuestController:   guestController,
+		ProfileController: profileController,
+		ProjectController: projectController,
+		SearchController:  searchController,
+		Journal:           journal,
+		ReportService:     reportService,
+		SettingsService:   settingsService,
+		ProfileService:    profileService,
+		UserService:       userService,
+		RetentionService:  retentionService,
+		CommentService:    commentService,
+		ImportService:     importService,
+		AuditService:      auditService,
+		UpdateService:     services.NewUpdateService(mainService),
+		ApiServer:         api.NewServer(userService, repository.NewCommentRepository(journal, ids), services.NewPermissionService(), reportService, auditService),
 	}
 }
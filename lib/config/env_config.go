@@ -3,6 +3,8 @@ package config
 import (
 	"github.com/fatih/color"
 	"github.com/joho/godotenv"
+
+	"tugas-besar/lib/helper"
 )
 
 // GetEnvConfig loads environment variables from the .env file at the project root.
@@ -17,3 +19,15 @@ func GetEnvConfig() {
 		color.Red("Error loading .env file")
 	}
 }
+
+// GetMenuConfig loads the menu configuration from the JSON file named by the MENU_FILE
+// environment variable (default "menu.json"). It lets instructors hide or rename menu
+// items without recompiling. If the file cannot be loaded, it displays the error in red
+// text and the built-in menus are used.
+func GetMenuConfig() {
+	err := helper.LoadMenuConfig(helper.GetEnv("MENU_FILE", "menu.json"))
+
+	if err != nil {
+		color.Red(err.Error())
+	}
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
 	"tugas-besar/lib/services"
 )
 
@@ -11,73 +13,338 @@ import (
 type AdminController struct {
 	// adminService handles the business logic for admin operations
 	adminService services.AdminService
+
+	// reportService handles the business logic for the admin reports
+	reportService services.ReportService
+
+	// importService handles importing comment datasets from files
+	importService services.ImportService
+
+	// maintenanceService handles the data maintenance tools
+	maintenanceService services.MaintenanceService
+
+	// permissions holds the role of the current session and the permissions matrix
+	permissions services.PermissionService
+
+	// settingsService handles the runtime settings
+	settingsService services.SettingsService
+
+	// projectService handles the selection and creation of projects
+	projectService services.ProjectService
+
+	// templateService handles the comment templates
+	templateService services.TemplateService
+
+	// auditService records the changes of the admin session and shows the audit log
+	auditService services.AuditService
+
+	// userMenu runs the user menu for a user, set by SetUserMenu, used to impersonate a user
+	userMenu func(user model.User)
 }
 
 // NewAdminController creates and returns a new AdminController instance.
 // It takes a services.AdminService implementation as a dependency for performing
-// admin-related operations.
-func NewAdminController(service services.AdminService) *AdminController {
+// admin-related operations, a services.ReportService implementation for the reports
+// a services.ImportService implementation for importing comments, a
+// services.MaintenanceService implementation for the maintenance tools and a
+// services.PermissionService implementation that decides which sub-flows a role may enter
+// a services.SettingsService implementation for the settings screen, a
+// services.ProjectService implementation for choosing the active project, a
+// services.TemplateService implementation for the comment templates and a
+// services.AuditService implementation for the audit log.
+func NewAdminController(service services.AdminService, reportService services.ReportService, importService services.ImportService, maintenanceService services.MaintenanceService, permissions services.PermissionService, settingsService services.SettingsService, projectService services.ProjectService, templateService services.TemplateService, auditService services.AuditService) *AdminController {
 	return &AdminController{
-		adminService: service,
+		adminService:       service,
+		reportService:      reportService,
+		importService:      importService,
+		maintenanceService: maintenanceService,
+		permissions:        permissions,
+		settingsService:    settingsService,
+		projectService:     projectService,
+		templateService:    templateService,
+		auditService:       auditService,
+	}
+}
+
+// AdminMenu logs in to the administrative menu and then shows it.
+//
+// It asks the username and password of an account with the admin or moderator role,
+// see AdminService.AdminLogin, until the login succeeds or the user goes back, and then
+// shows the menu as that account with AdminMenuAs.
+//
+// An authentication error that is helper.ErrBack causes an immediate return from the
+// function and helper.ErrContinue asks again. Other errors are displayed to the user in
+// red text.
+func (c *AdminController) AdminMenu() {
+	for {
+		var user model.User
+		err := c.adminService.AdminLogin(&user)
+		helper.Trace("auth admin: user=%q role=%q err=%v", user.Username, user.Role, err)
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				return
+			}
+
+			if !errors.Is(err, helper.ErrContinue) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
+			continue
+		}
+
+		c.AdminMenuAs(user)
+		return
 	}
 }
 
-// AdminMenu displays and handles the administrative menu interface.
-// It manages authentication flow and provides access to administrative functions.
+// AdminMenuAs displays and handles the administrative menu interface for an account that
+// already logged in, either through AdminMenu or through the normal login of a user with
+// an admin role.
 //
 // The function runs in a continuous loop until the user selects "Exit" from the menu.
-// It first checks if the user is authenticated, and if not, prompts for admin credentials.
-// After successful authentication, it displays the admin menu and processes user selections.
 //
 // The menu supports the following operations:
 // - "Lihat User": View and manage user accounts
+// - "Lihat Komentar": View and manage comments
+// - "Lihat Grafik": View comment statistics
+// - "Laporan": Open the report menu
+// - "Journal": View and replay the operation journal
+// - "Proyek": Choose or create the project the comments are scoped to
+// - "Template": Manage the reusable comment templates
+// - "Pengaturan": View and change the runtime settings
+// - "Lihat Audit Log": View who changed which user or comment, filtered by action
 // - "Exit": Return to the previous menu
 //
-// Authentication errors with message "back" will cause immediate return from the function.
-// Other errors are displayed to the user in red text.
-func (c *AdminController) AdminMenu() {
+// After LOCK_MINUTES without activity the screen is locked until the password of the
+// account is entered again, see helper.LockSession.
+//
+// Before entering a sub-flow the role of the account (admin or moderator) is checked
+// against the permissions matrix; a denied sub-flow shows an error instead.
+//
+// Parameters:
+//   - user: The logged-in account, its Role decides the permissions
+func (c *AdminController) AdminMenuAs(user model.User) {
 	var result string
-	var isAuthenticated bool
 
-	for {
-		if !isAuthenticated {
-			err := c.adminService.AdminPassword()
-			if err != nil {
-				if err.Error() == "back" {
-					return
-				}
+	defer c.permissions.SetRole("")
+	defer c.auditService.SetActor(model.User{})
+	defer helper.EndSession()
+	defer c.projectService.Reset()
 
-				color.Red(err.Error())
-				fmt.Scanln()
-				continue
-			}
-		}
-
-		isAuthenticated = true
+	c.permissions.SetRole(user.Role)
+	c.auditService.SetActor(user)
+	helper.LockSession(func() (bool, error) { return c.adminService.Unlock(user) })
 
+	for {
 		err := c.adminService.AdminMenu(&result)
 		if err != nil {
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 		}
 
 		if result == "Exit" {
 			break
 		}
 
+		if !c.allowed(adminMenuPermissions[result]) {
+			continue
+		}
+
+		done := helper.TraceTime("admin > " + result)
 		switch result {
 		case "Lihat User":
 			c.adminLihatUser()
 		case "Lihat Komentar":
 			c.LihatComment()
 		case "Lihat Grafik":
-			err := c.adminService.Grafik()
+			c.Grafik()
+		case "Laporan":
+			c.adminLaporan()
+		case "Import Komentar":
+			c.ImportKomentar()
+		case "Maintenance":
+			c.adminMaintenance()
+		case "Journal":
+			err := c.adminService.Journal()
 			if err != nil {
 				color.Red(err.Error())
-				fmt.Scanln()
+				helper.Pause()
 			}
+		case "Proyek":
+			c.Proyek()
+		case "Template":
+			c.Template()
+		case "Pengaturan":
+			c.Pengaturan()
+		case "Lihat Audit Log":
+			c.AuditLog()
 		}
+		done()
+	}
+}
+
+// Proyek handles the project selection in the admin interface.
+// The admin can also create a new project from the selection screen.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the project selection again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) Proyek() {
+	for {
+		err := c.projectService.PilihProyek(true)
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// AuditLog handles the audit log screen in the admin interface.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Asks for another filter
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) AuditLog() {
+	for {
+		err := c.auditService.LihatAuditLog()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// Template handles the comment template management in the admin interface.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the template list again after an action
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and shows the template list again
+func (c *AdminController) Template() {
+	for {
+		err := c.templateService.TemplateMenu()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			continue
+		}
+
+		break
+	}
+}
+
+// Pengaturan handles the settings screen in the admin interface.
+//
+// It runs in a continuous loop, calling the Pengaturan method from the settings service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the settings screen again after a setting was changed
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) Pengaturan() {
+	for {
+		err := c.settingsService.Pengaturan()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// adminMenuPermissions maps every admin menu item to the permission it requires.
+var adminMenuPermissions = map[string]services.Permission{
+	"Lihat User":      services.PermissionManageUsers,
+	"Lihat Komentar":  services.PermissionManageComments,
+	"Lihat Grafik":    services.PermissionViewStats,
+	"Laporan":         services.PermissionViewStats,
+	"Import Komentar": services.PermissionManageComments,
+	"Maintenance":     services.PermissionManageUsers,
+	"Journal":         services.PermissionManageUsers,
+	"Proyek":          services.PermissionManageComments,
+	"Template":        services.PermissionManageComments,
+	"Pengaturan":      services.PermissionManageUsers,
+	"Lihat Audit Log": services.PermissionManageRoles,
+}
+
+// laporanPermissions maps the report menu items that write or send data to the
+// permission they require in addition to viewing stats.
+var laporanPermissions = map[string]services.Permission{
+	"Klasifikasi File":     services.PermissionExportData,
+	"Laporan Bulanan":      services.PermissionExportData,
+	"Kirim Laporan":        services.PermissionExportData,
+	"Export Grafik PNG":    services.PermissionExportData,
+	"Export Data":          services.PermissionExportData,
+	"Export Dataset JSONL": services.PermissionExportData,
+	"Split Train/Test":     services.PermissionExportData,
+}
+
+// allowed checks a permission for the current role before entering a sub-flow.
+// When the permission is missing the denial is shown in red and the function waits
+// for user input.
+//
+// Parameters:
+//   - permission: The permission the sub-flow requires, empty if it requires none
+//
+// Returns:
+//   - bool: true if the sub-flow may be entered, false otherwise
+func (c *AdminController) allowed(permission services.Permission) bool {
+	if permission == "" {
+		return true
 	}
+
+	err := c.permissions.Check(permission)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+		return false
+	}
+
+	return true
 }
 
 // adminLihatUser handles the user management menu in the admin interface.
@@ -89,7 +356,14 @@ func (c *AdminController) AdminMenu() {
 // - "Search": Search for users
 // - "Add": Create a new user
 // - "Edit": Modify an existing user
-// - "Delete": Remove a user
+// - "Delete": Remove a user, restorable until the grace period has passed
+// - "Dihapus": Restore or permanently remove the users pending deletion
+// - "Role": Give a user the admin or moderator role, needs the manage_roles permission
+// - "Merge": Merge two user accounts into one
+// - "Impersonasi": Open the user menu as a user, see Impersonasi
+// - "Riwayat Login": View the successful and failed login attempts
+// - "Import": Create accounts with temporary passwords from a CSV file
+// - "Export": Export the users with their comment statistics as CSV, needs the export_data permission
 // - "Exit": Return to the previous menu
 //
 // Any errors encountered while displaying the menu are shown to the user in red text.
@@ -102,13 +376,14 @@ func (c *AdminController) adminLihatUser() {
 		err := c.adminService.LihatUser(&result)
 		if err != nil {
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 		}
 
 		if result == "Exit" {
 			break
 		}
 
+		done := helper.TraceTime("admin > lihat user > " + result)
 		switch result {
 		case "Search":
 			c.userSearch()
@@ -118,7 +393,42 @@ func (c *AdminController) adminLihatUser() {
 			c.EditUser()
 		case "Delete":
 			c.DeleteUser()
+		case "Dihapus":
+			c.UserDihapus()
+		case "Role":
+			if !c.allowed(services.PermissionManageRoles) {
+				break
+			}
+
+			err := c.adminService.UbahRole()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
+		case "Merge":
+			c.MergeUser()
+		case "Impersonasi":
+			c.Impersonasi()
+		case "Riwayat Login":
+			c.RiwayatLogin()
+		case "Import":
+			err := c.importService.ImportUsers()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
+		case "Export":
+			if !c.allowed(services.PermissionExportData) {
+				break
+			}
+
+			err := c.reportService.ExportUsers()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
 		}
+		done()
 	}
 }
 
@@ -128,27 +438,27 @@ func (c *AdminController) adminLihatUser() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the search process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the search process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
-// The function terminates when either a "back" error is received, a non-"continue"
-// error occurs, or when the SearchUsers method completes successfully.
+// The function terminates when either helper.ErrBack is received, an error other than
+// helper.ErrContinue occurs, or when the SearchUsers method completes successfully.
 func (c *AdminController) userSearch() {
 	for {
 		err := c.adminService.SearchUsers()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 	}
@@ -160,8 +470,8 @@ func (c *AdminController) userSearch() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the user creation process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the user creation process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -171,21 +481,21 @@ func (c *AdminController) CreateUser() {
 	for {
 		err := c.adminService.CreateUser()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("User created successfully!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -196,8 +506,8 @@ func (c *AdminController) CreateUser() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the user editing process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the user editing process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -207,21 +517,21 @@ func (c *AdminController) EditUser() {
 	for {
 		err := c.adminService.EditUser()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("User edited successfully!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -232,8 +542,8 @@ func (c *AdminController) EditUser() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the user deletion process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the user deletion process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -243,21 +553,185 @@ func (c *AdminController) DeleteUser() {
 	for {
 		err := c.adminService.DeleteUser()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("User deleted successfully!")
-		fmt.Scanln()
+		helper.Pause()
+		break
+	}
+}
+
+// Grafik handles the statistics screen in the admin interface.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the statistics again after the filter was changed
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) Grafik() {
+	for {
+		err := c.adminService.Grafik()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// UserDihapus handles the users pending deletion in the admin interface.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the pending users again after an action
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) UserDihapus() {
+	for {
+		err := c.adminService.UserDihapus()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// SetUserMenu sets the flow that runs the user menu for a user. The user menu is owned
+// by the application bootstrap, so it is handed to the controller instead of injected.
+//
+// Parameters:
+//   - userMenu: Runs the user menu for the given user until the user exits
+func (c *AdminController) SetUserMenu(userMenu func(user model.User)) {
+	c.userMenu = userMenu
+}
+
+// Impersonasi handles opening the user menu as another user in the admin interface.
+//
+// It runs in a continuous loop, calling the Impersonasi method from the admin service
+// until a user is chosen, then runs the user menu as that user. The user menu is
+// marked as impersonated and read-only unless the admin allowed changes; the
+// impersonation ends when the admin exits the user menu.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Asks for the user again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) Impersonasi() {
+	for {
+		var user model.User
+		err := c.adminService.Impersonasi(&user)
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		if c.userMenu != nil {
+			c.userMenu(user)
+		}
+		c.adminService.SelesaiImpersonasi()
+		break
+	}
+}
+
+// RiwayatLogin handles the login history screen in the admin interface.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the history screen again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) RiwayatLogin() {
+	for {
+		err := c.adminService.RiwayatLogin()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		break
+	}
+}
+
+// MergeUser handles the user merge functionality in the admin interface.
+//
+// It runs in a continuous loop, calling the MergeUser method from the admin service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the merge process
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+//
+// On successful merge, the function waits for user input before returning
+// to the previous menu.
+func (c *AdminController) MergeUser() {
+	for {
+		err := c.adminService.MergeUser()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		helper.Pause()
 		break
 	}
 }
@@ -273,6 +747,16 @@ func (c *AdminController) DeleteUser() {
 // - "Edit": Modify an existing comment
 // - "Delete": Remove a comment
 // - "Sorting": Sort comments
+// - "Detail": View a comment with its edit history
+// - "Bulk": Bulk delete or re-categorize comments with a dry-run preview
+// - "Review": Confirm or correct the categories flagged as uncertain
+// - "Saran Label": Suggest the comments the classifier is least sure about for labeling
+// - "Status": Filter the comments by processing status and move them to the next status
+// - "Tindak Lanjut": Track the negative comments that need follow-up
+// - "Catatan": Write the internal admin note of a comment
+// - "Terakhir Dilihat": Reopen a comment recently viewed in the detail screen
+// - "Favorit": Manage the comments bookmarked by the admin
+// - "Export": Export the comments as CSV, needs the export_data permission
 // - "Exit": Return to the previous menu
 //
 // Any errors encountered while displaying the menu are shown to the user in red text.
@@ -292,6 +776,7 @@ func (c *AdminController) LihatComment() {
 			break
 		}
 
+		done := helper.TraceTime("admin > lihat komentar > " + result)
 		switch result {
 		case "Search":
 			c.SearchComment()
@@ -303,7 +788,36 @@ func (c *AdminController) LihatComment() {
 			c.DeleteComment()
 		case "Sorting":
 			c.SortingComment()
+		case "Detail":
+			c.DetailComment()
+		case "Bulk":
+			c.BulkComment()
+		case "Review":
+			c.ReviewKomentar()
+		case "Saran Label":
+			c.SaranLabel()
+		case "Status":
+			c.StatusKomentar()
+		case "Tindak Lanjut":
+			c.TindakLanjut()
+		case "Catatan":
+			c.Catatan()
+		case "Terakhir Dilihat":
+			c.TerakhirDilihat()
+		case "Favorit":
+			c.Favorit()
+		case "Export":
+			if !c.allowed(services.PermissionExportData) {
+				break
+			}
+
+			err := c.reportService.ExportComments()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
 		}
+		done()
 	}
 }
 
@@ -313,27 +827,27 @@ func (c *AdminController) LihatComment() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the search process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the search process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
-// The function terminates when either a "back" error is received, a non-"continue"
-// error occurs, or when the SearchAdminComment method completes successfully.
+// The function terminates when either helper.ErrBack is received, an error other than
+// helper.ErrContinue occurs, or when the SearchAdminComment method completes successfully.
 func (c *AdminController) SearchComment() {
 	for {
 		err := c.adminService.SearchAdminComment()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 	}
@@ -345,8 +859,8 @@ func (c *AdminController) SearchComment() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the comment creation process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the comment creation process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -356,21 +870,21 @@ func (c *AdminController) AddComment() {
 	for {
 		err := c.adminService.AddComment()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("Comment added successfully!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -381,8 +895,8 @@ func (c *AdminController) AddComment() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the comment editing process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the comment editing process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -392,21 +906,21 @@ func (c *AdminController) EditComment() {
 	for {
 		err := c.adminService.EditComment()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("Comment edited successfully!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -417,8 +931,8 @@ func (c *AdminController) EditComment() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the comment deletion process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the comment deletion process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -428,21 +942,21 @@ func (c *AdminController) DeleteComment() {
 	for {
 		err := c.adminService.DeleteComment()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("Comment deleted successfully!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -453,8 +967,8 @@ func (c *AdminController) DeleteComment() {
 // until a terminating condition is met. The function processes different error types:
 //
 // Error handling:
-//   - "back": Returns to the previous menu
-//   - "continue": Restarts the sorting process
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Restarts the sorting process
 //   - Other errors: Displays the error message in red text, waits for user input,
 //     and returns to the previous menu
 //
@@ -464,21 +978,459 @@ func (c *AdminController) SortingComment() {
 	for {
 		err := c.adminService.SortingKomentar()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 
 		color.Green("Comments sorted successfully!")
-		fmt.Scanln()
+		helper.Pause()
+		break
+	}
+}
+
+// BulkComment handles the bulk comment operation functionality in the admin interface.
+//
+// It runs in a continuous loop, calling the BulkComment method from the admin service
+// until a terminating condition is met. The service always shows a dry-run preview
+// before applying anything. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu (also used when the preview is not applied)
+//   - helper.ErrContinue: Restarts the bulk operation process
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+//
+// On successful application, the function displays a success message in green,
+// waits for user input, and returns to the previous menu.
+func (c *AdminController) BulkComment() {
+	for {
+		err := c.adminService.BulkComment()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		color.Green("Bulk operation applied successfully!")
+		helper.Pause()
+		break
+	}
+}
+
+// DetailComment handles the comment detail functionality in the admin interface.
+//
+// It runs in a continuous loop, calling the DetailComment method from the admin service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the detail screen again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) DetailComment() {
+	for {
+		err := c.adminService.DetailComment()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		break
+	}
+}
+
+// TerakhirDilihat handles the recently viewed comments list in the admin interface.
+//
+// It runs in a continuous loop, calling the TerakhirDilihat method from the admin service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the list again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) TerakhirDilihat() {
+	for {
+		err := c.adminService.TerakhirDilihat()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		break
+	}
+}
+
+// Favorit handles the favorite comments screen of the admin.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the favorites again after an action
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and shows the favorites again
+func (c *AdminController) Favorit() {
+	for {
+		err := c.adminService.Favorit()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			continue
+		}
+
+		break
+	}
+}
+
+// ImportKomentar handles the comment import functionality in the admin interface.
+//
+// It runs in a continuous loop, calling the ImportKomentar method from the import service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the import screen again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) ImportKomentar() {
+	for {
+		err := c.importService.ImportKomentar()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		break
+	}
+}
+
+// adminMaintenance handles the maintenance menu in the admin interface.
+//
+// It displays the list of maintenance tools through the maintenance service and runs
+// the selected tool in a continuous loop until "Exit" is chosen.
+//
+// The method supports the following tools:
+// - "Diagnostik": Check and repair the referential integrity of the store
+// - "Komentar Yatim": Clean up comments whose owner no longer exists
+// - "Buat Laporan Error": Bundle logs, redacted config and diagnostics into a zip
+// - "Kompaksi Penyimpanan": Replace the journal by a snapshot and report the reclaimed entries
+// - "Exit": Return to the previous menu
+//
+// Any errors encountered while running a tool are shown to the user in red text.
+func (c *AdminController) adminMaintenance() {
+	var result string
+
+	for {
+		err := c.maintenanceService.MaintenanceMenu(&result)
+		if err != nil {
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		if result == "Exit" {
+			break
+		}
+
+		done := helper.TraceTime("admin > maintenance > " + result)
+		switch result {
+		case "Diagnostik":
+			err := c.maintenanceService.Diagnostik()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
+		case "Komentar Yatim":
+			c.OrphanComments()
+		case "Buat Laporan Error":
+			err := c.maintenanceService.LaporanError()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
+		case "Kompaksi Penyimpanan":
+			err := c.maintenanceService.Kompaksi()
+			if err != nil && !errors.Is(err, helper.ErrBack) {
+				color.Red(err.Error())
+				helper.Pause()
+			}
+		}
+		done()
+	}
+}
+
+// OrphanComments handles the orphaned comment cleanup in the admin interface.
+//
+// It runs in a continuous loop, calling the OrphanComments method from the maintenance
+// service until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the orphaned comments again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) OrphanComments() {
+	for {
+		err := c.maintenanceService.OrphanComments()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// adminLaporan handles the report menu in the admin interface.
+//
+// It displays the list of available reports through the report service and runs
+// the selected report in a continuous loop until "Exit" is chosen.
+//
+// The method supports the following reports:
+// - "Perbandingan Label": Manual vs automatic label comparison
+// - "Klasifikasi HuggingFace": Manual labels vs the predictions of a HuggingFace model
+// - "Klasifikasi File": Label the lines of a text file into a CSV without importing them
+// - "Kesepakatan Label": Agreement and Cohen's kappa between the first and second labels
+// - "Sampel Acak": Random sample of comments for a manual label check
+// - "User x Kategori": Cross-tabulation of users against sentiment categories
+// - "Laporan Bulanan": Write a summary report file per month
+// - "Kirim Laporan": E-mail the weekly or monthly summary now
+// - "Export Grafik PNG": Render the sentiment charts to PNG files
+// - "Export Data": Export the users and comments as CSV, JSON or XLSX
+// - "Export Dataset JSONL": Write the comments as a JSON Lines dataset
+// - "Split Train/Test": Write stratified train and test JSON Lines files
+// - "Evaluasi Klasifikasi": Cross-validated accuracy, macro F1 and support of the classifier
+// - "Exit": Return to the previous menu
+//
+// Any errors encountered while displaying a report are shown to the user in red text.
+func (c *AdminController) adminLaporan() {
+	var result string
+
+	for {
+		err := c.reportService.LaporanMenu(&result)
+		if err != nil {
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		if result == "Exit" {
+			break
+		}
+
+		if !c.allowed(laporanPermissions[result]) {
+			continue
+		}
+
+		done := helper.TraceTime("admin > laporan > " + result)
+		switch result {
+		case "Perbandingan Label":
+			err = c.reportService.PerbandinganLabel()
+		case "Klasifikasi HuggingFace":
+			err = c.reportService.KlasifikasiHuggingFace()
+		case "Klasifikasi File":
+			err = c.reportService.KlasifikasiFile()
+		case "Kesepakatan Label":
+			err = c.reportService.KesepakatanLabel()
+		case "Sampel Acak":
+			err = c.reportService.SampelAcak()
+		case "User x Kategori":
+			err = c.reportService.UserKategori()
+		case "Laporan Bulanan":
+			err = c.reportService.LaporanBulanan()
+		case "Kirim Laporan":
+			err = c.reportService.KirimLaporan()
+		case "Export Grafik PNG":
+			err = c.reportService.ExportGrafikPNG()
+		case "Export Data":
+			err = c.reportService.ExportData()
+		case "Export Dataset JSONL":
+			err = c.reportService.ExportDataset()
+		case "Split Train/Test":
+			err = c.reportService.SplitDataset()
+		case "Evaluasi Klasifikasi":
+			err = c.reportService.EvaluasiKlasifikasi()
+		}
+		done()
+
+		if err != nil && !errors.Is(err, helper.ErrBack) {
+			color.Red(err.Error())
+			helper.Pause()
+		}
+	}
+}
+
+// ReviewKomentar handles the review queue of uncertain comments in the admin interface.
+//
+// It calls the ReviewKomentar method from the admin service, which walks through the
+// queue until it is finished or the admin stops. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) ReviewKomentar() {
+	err := c.adminService.ReviewKomentar()
+	if err != nil && !errors.Is(err, helper.ErrBack) {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
+
+// SaranLabel handles the active-learning suggestions in the admin interface.
+//
+// It calls the SaranLabel method from the admin service. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu (also used when nothing is queued)
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+//
+// When the suggestions are queued, the function displays a success message in green
+// and waits for user input.
+func (c *AdminController) SaranLabel() {
+	err := c.adminService.SaranLabel()
+	if err != nil {
+		if !errors.Is(err, helper.ErrBack) {
+			color.Red(err.Error())
+			helper.Pause()
+		}
+		return
+	}
+
+	color.Green("Saran berhasil dimasukkan ke antrian review!")
+	helper.Pause()
+}
+
+// TindakLanjut handles the follow-up board of negative comments in the admin interface.
+//
+// It runs in a continuous loop, calling the TindakLanjut method from the admin service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the board again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) TindakLanjut() {
+	for {
+		err := c.adminService.TindakLanjut()
+		if err == nil || errors.Is(err, helper.ErrContinue) {
+			continue
+		}
+
+		if !errors.Is(err, helper.ErrBack) {
+			color.Red(err.Error())
+			helper.Pause()
+		}
+		break
+	}
+}
+
+// Catatan handles the internal admin notes of comments in the admin interface.
+//
+// It runs in a continuous loop, calling the Catatan method from the admin service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Asks for another comment
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) Catatan() {
+	for {
+		err := c.adminService.Catatan()
+		if err == nil || errors.Is(err, helper.ErrContinue) {
+			continue
+		}
+
+		if !errors.Is(err, helper.ErrBack) {
+			color.Red(err.Error())
+			helper.Pause()
+		}
+		break
+	}
+}
+
+// StatusKomentar handles the processing status of comments in the admin interface.
+//
+// It runs in a continuous loop, calling the StatusKomentar method from the admin service
+// until a terminating condition is met. The function processes different error types:
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the status screen again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the previous menu
+func (c *AdminController) StatusKomentar() {
+	for {
+		err := c.adminService.StatusKomentar()
+		if err == nil || errors.Is(err, helper.ErrContinue) {
+			continue
+		}
+
+		if !errors.Is(err, helper.ErrBack) {
+			color.Red(err.Error())
+			helper.Pause()
+		}
 		break
 	}
 }
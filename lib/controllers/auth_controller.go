@@ -1,8 +1,9 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
 	"tugas-besar/lib/model"
 	"tugas-besar/lib/services"
 )
@@ -34,16 +35,16 @@ func (c *AuthController) Login(user *model.User) {
 	for {
 		err := c.authService.Login(user)
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			continue
 		} else {
 			break
@@ -60,20 +61,20 @@ func (c *AuthController) Register() {
 	for {
 		err := c.authService.Register()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			continue
 		} else {
 			color.Green("Registration successful! Please login to continue.")
-			fmt.Scanln()
+			helper.Pause()
 			break
 		}
 	}
@@ -1,10 +1,11 @@
 package controllers
 
 import (
-	"fmt"
+	"errors"
 
 	"github.com/fatih/color"
 
+	"tugas-besar/lib/helper"
 	"tugas-besar/lib/model"
 	"tugas-besar/lib/services"
 )
@@ -34,8 +35,8 @@ func NewCommentController(service services.CommentService) *CommentController {
 //
 // The function handles several control flow paths:
 // - On successful comment creation, it displays a success message and returns
-// - If the service returns "back" error, it exits the input flow
-// - If the service returns "continue" error, it restarts the input flow
+// - If the service returns helper.ErrBack, it exits the input flow
+// - If the service returns helper.ErrContinue, it restarts the input flow
 // - For other errors, it displays the error message and exits
 //
 // Parameters:
@@ -44,11 +45,11 @@ func (c *CommentController) CommentInputPage(user model.User) {
 	for {
 		err := c.commentService.CreateCommentPage(user)
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
@@ -57,7 +58,110 @@ func (c *CommentController) CommentInputPage(user model.User) {
 		}
 
 		color.Green("Komentar berhasil ditambahkan!")
-		fmt.Scanln()
+		helper.Pause()
+		break
+	}
+}
+
+// BalasKomentar handles the user interface flow for replying to a comment.
+//
+// The function handles several control flow paths:
+// - On a successful reply, it displays a success message and returns
+// - If the service returns helper.ErrBack, it exits the reply flow
+// - If the service returns helper.ErrContinue, it restarts the reply flow
+// - For other errors, it displays the error message and exits
+//
+// Parameters:
+//   - user: The model.User who writes the reply
+func (c *CommentController) BalasKomentar(user model.User) {
+	for {
+		err := c.commentService.BalasKomentar(user)
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			break
+		}
+
+		color.Green("Balasan berhasil ditambahkan!")
+		helper.Pause()
+		break
+	}
+}
+
+// LabelKedua runs the second-labeler mode for a user.
+// Any error encountered is shown to the user in red text.
+//
+// Parameters:
+//   - user: The model.User acting as the second labeler
+func (c *CommentController) LabelKedua(user model.User) {
+	err := c.commentService.LabelKedua(user)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
+
+// RapidEntry runs the rapid data-entry mode for a user.
+// Any error encountered is shown to the user in red text.
+//
+// Parameters:
+//   - user: The model.User who is creating the comments
+func (c *CommentController) RapidEntry(user model.User) {
+	err := c.commentService.RapidEntry(user)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
+
+// ScheduledComment displays the comments of a user that are waiting to be published.
+// Any error encountered is shown to the user in red text.
+//
+// Parameters:
+//   - user: The model.User whose scheduled comments are shown
+func (c *CommentController) ScheduledComment(user model.User) {
+	err := c.commentService.ScheduledComment(user)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
+
+// Favorit handles the favorite comments screen of a user.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the previous menu
+//   - helper.ErrContinue: Shows the favorites again after an action
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and shows the favorites again
+//
+// Parameters:
+//   - user: The model.User whose favorites are shown
+func (c *CommentController) Favorit(user model.User) {
+	for {
+		err := c.commentService.Favorit(user)
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+			continue
+		}
+
 		break
 	}
 }
@@ -79,7 +183,7 @@ func (c *CommentController) CommentView() {
 		err := c.commentService.ShowComment(&result)
 		if err != nil {
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			return
 		}
 
@@ -87,6 +191,7 @@ func (c *CommentController) CommentView() {
 			break
 		}
 
+		done := helper.TraceTime("komentar > " + result)
 		switch result {
 		case "Search":
 			c.searchComment()
@@ -96,6 +201,7 @@ func (c *CommentController) CommentView() {
 				return
 			}
 		}
+		done()
 	}
 }
 
@@ -103,8 +209,8 @@ func (c *CommentController) CommentView() {
 // It continuously calls the comment service's search functionality until exited.
 //
 // The function handles several control flow paths:
-// - If the service returns "back" error, it exits the search flow
-// - If the service returns "continue" error, it restarts the search flow
+// - If the service returns helper.ErrBack, it exits the search flow
+// - If the service returns helper.ErrContinue, it restarts the search flow
 // - For other errors, it displays the error message and exits
 //
 // This is an internal method with no parameters and no return values.
@@ -112,16 +218,16 @@ func (c *CommentController) searchComment() {
 	for {
 		err := c.commentService.SearchComment()
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			return
 		}
 	}
@@ -132,8 +238,8 @@ func (c *CommentController) searchComment() {
 //
 // The function handles several control flow paths:
 // - On successful comment edit, it displays a success message and returns
-// - If the service returns "back" error, it exits the edit flow
-// - If the service returns "continue" error, it restarts the edit flow
+// - If the service returns helper.ErrBack, it exits the edit flow
+// - If the service returns helper.ErrContinue, it restarts the edit flow
 // - For other errors, it displays the error message and exits
 //
 // Parameters:
@@ -142,21 +248,21 @@ func (c *CommentController) EditComment(user model.User) {
 	for {
 		err := c.commentService.EditUserComment(user)
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			return
 		}
 
 		color.Green("Komentar berhasil diubah!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -166,8 +272,8 @@ func (c *CommentController) EditComment(user model.User) {
 //
 // The function handles several control flow paths:
 // - On successful comment deletion, it displays a success message and returns
-// - If the service returns "back" error, it exits the deletion flow
-// - If the service returns "continue" error, it restarts the deletion flow
+// - If the service returns helper.ErrBack, it exits the deletion flow
+// - If the service returns helper.ErrContinue, it restarts the deletion flow
 // - For other errors, it displays the error message and exits
 //
 // Parameters:
@@ -176,21 +282,21 @@ func (c *CommentController) DeleteComment(user model.User) {
 	for {
 		err := c.commentService.DeleteUserComment(user)
 		if err != nil {
-			if err.Error() == "back" {
+			if errors.Is(err, helper.ErrBack) {
 				break
 			}
 
-			if err.Error() == "continue" {
+			if errors.Is(err, helper.ErrContinue) {
 				continue
 			}
 
 			color.Red(err.Error())
-			fmt.Scanln()
+			helper.Pause()
 			return
 		}
 
 		color.Green("Komentar berhasil dihapus!")
-		fmt.Scanln()
+		helper.Pause()
 		break
 	}
 }
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/services"
+)
+
+// GuestController manages the read-only guest mode through the guest service.
+type GuestController struct {
+	// guestService handles the business logic for the guest mode
+	guestService services.GuestService
+}
+
+// NewGuestController creates and returns a new GuestController instance.
+// It takes a services.GuestService implementation as a dependency.
+func NewGuestController(service services.GuestService) *GuestController {
+	return &GuestController{
+		guestService: service,
+	}
+}
+
+// GuestMenu displays the guest menu and captures the guest's choice.
+//
+// Parameters:
+//   - result: A pointer to a string that will store the guest's menu selection
+//
+// Errors are displayed in red and the function waits for the user to press Enter;
+// the selection is then set to "Exit" so the caller leaves the guest mode.
+func (c *GuestController) GuestMenu(result *string) {
+	err := c.guestService.GuestMenu(result)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+		*result = "Exit"
+	}
+}
+
+// Kiosk runs the full-screen kiosk mode until Enter is pressed.
+// Any error encountered is shown to the user in red text.
+func (c *GuestController) Kiosk() {
+	err := c.guestService.Kiosk()
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
+
+// Statistik displays the public sentiment statistics.
+// Any error encountered is shown to the user in red text.
+func (c *GuestController) Statistik() {
+	err := c.guestService.Statistik()
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
@@ -1,8 +1,8 @@
 package controllers
 
 import (
-	"fmt"
 	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
 	"tugas-besar/lib/services"
 )
 
@@ -39,7 +39,29 @@ func (c *MainController) MainMenu(result *string) {
 
 	if err != nil {
 		color.Red(err.Error())
-		fmt.Scanln()
+		helper.Pause()
 		return
 	}
 }
+
+// Bantuan displays the help screen.
+// Any error is displayed in red and the function waits for user acknowledgment.
+func (c *MainController) Bantuan() {
+	err := c.mainService.Bantuan()
+
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
+
+// Tentang displays the about screen.
+// Any error is displayed in red and the function waits for user acknowledgment.
+func (c *MainController) Tentang() {
+	err := c.mainService.Tentang()
+
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/services"
+)
+
+// ProfileController manages switching between the named data profiles.
+type ProfileController struct {
+	// profileService handles the business logic for the data profiles
+	profileService services.ProfileService
+}
+
+// NewProfileController creates and returns a new ProfileController instance.
+// It takes a services.ProfileService implementation as a dependency.
+func NewProfileController(service services.ProfileService) *ProfileController {
+	return &ProfileController{
+		profileService: service,
+	}
+}
+
+// ProfileMenu displays the profile screen until a profile is chosen or the user leaves.
+//
+// Error handling:
+//   - helper.ErrBack: Returns to the main menu
+//   - helper.ErrContinue: Shows the profile screen again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns to the main menu
+func (c *ProfileController) ProfileMenu() {
+	for {
+		err := c.profileService.ProfileMenu()
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/services"
+)
+
+// ProjectController manages the selection of the active project.
+type ProjectController struct {
+	// projectService handles the business logic for the projects
+	projectService services.ProjectService
+}
+
+// NewProjectController creates and returns a new ProjectController instance.
+// It takes a services.ProjectService implementation as a dependency.
+func NewProjectController(service services.ProjectService) *ProjectController {
+	return &ProjectController{
+		projectService: service,
+	}
+}
+
+// PilihProyek displays the project selection until a project is chosen or the user leaves.
+//
+// Parameters:
+//   - canCreate: Whether a new project can be created from the selection screen
+//
+// Error handling:
+//   - helper.ErrBack: Keeps the current project and returns
+//   - helper.ErrContinue: Shows the project selection again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and returns
+func (c *ProjectController) PilihProyek(canCreate bool) {
+	for {
+		err := c.projectService.PilihProyek(canCreate)
+		if err != nil {
+			if errors.Is(err, helper.ErrBack) {
+				break
+			}
+
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+
+			color.Red(err.Error())
+			helper.Pause()
+		}
+
+		break
+	}
+}
+
+// Reset clears the active project, e.g. when the user logs out.
+func (c *ProjectController) Reset() {
+	c.projectService.Reset()
+}
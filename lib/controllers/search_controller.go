@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/fatih/color"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/services"
+)
+
+// SearchController manages the global search of the main menu through the search service.
+type SearchController struct {
+	// searchService handles the business logic for the global search
+	searchService services.SearchService
+}
+
+// NewSearchController creates and returns a new SearchController instance.
+// It takes a services.SearchService implementation as a dependency.
+func NewSearchController(service services.SearchService) *SearchController {
+	return &SearchController{
+		searchService: service,
+	}
+}
+
+// Cari runs the global search until the user goes back.
+// A helper.ErrContinue starts a new search, a helper.ErrBack returns to the main menu,
+// and any other error is shown to the user in red text.
+func (c *SearchController) Cari() {
+	for {
+		err := c.searchService.Cari()
+		if err != nil {
+			if errors.Is(err, helper.ErrContinue) {
+				continue
+			}
+			if errors.Is(err, helper.ErrBack) {
+				return
+			}
+			color.Red(err.Error())
+			helper.Pause()
+		}
+		return
+	}
+}
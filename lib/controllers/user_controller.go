@@ -1,6 +1,12 @@
 package controllers
 
 import (
+	"errors"
+
+	"github.com/fatih/color"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
 	"tugas-besar/lib/services"
 )
 
@@ -25,16 +31,60 @@ func NewUserController(service services.UserService) *UserController {
 	}
 }
 
+// ChangePassword makes a user with a temporary password choose a new one.
+//
+// Error handling:
+//   - helper.ErrBack: The user cancelled, the password was not changed
+//   - helper.ErrContinue: Asks for the new password again
+//   - Other errors: Displays the error message in red text, waits for user input,
+//     and asks again
+//
+// Parameters:
+//   - user: Pointer to the logged-in user, updated with the new password
+//
+// Returns:
+//   - bool: true once the password has been changed, false when the user cancelled
+func (c *UserController) ChangePassword(user *model.User) bool {
+	for {
+		err := c.userService.ChangePassword(user)
+		if err == nil {
+			return true
+		}
+
+		if errors.Is(err, helper.ErrBack) {
+			return false
+		}
+
+		if !errors.Is(err, helper.ErrContinue) {
+			color.Red(err.Error())
+			helper.Pause()
+		}
+	}
+}
+
+// Unlock asks the password of the user again after the screen was locked for inactivity.
+//
+// Parameters:
+//   - user: The model.User representing the currently logged-in user
+//
+// Returns:
+//   - bool: true if the password of the user was entered
+//   - error: An error if the password could not be asked
+func (c *UserController) Unlock(user model.User) (bool, error) {
+	return c.userService.Unlock(user)
+}
+
 // UserPage displays the user menu interface and captures the user's selection.
 // This method delegates to the userService to display the menu and handle the user's choice.
 //
 // Parameters:
+//   - user: The model.User representing the currently logged-in user
 //   - chose: A pointer to a string that will store the user's menu selection
 //
 // Returns:
 //   - error: An error if displaying the menu or capturing the selection fails, nil on success
-func (c *UserController) UserPage(chose *string) error {
-	err := c.userService.UserPage(chose)
+func (c *UserController) UserPage(user model.User, chose *string) error {
+	err := c.userService.UserPage(user, chose)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,10 @@
+package global
+
+import "tugas-besar/lib/model"
+
+// Bookmarks is an in-memory storage array that holds up to 255 favorite comments of all users.
+// It serves as the storage mechanism for the bookmarkRepository implementation.
+var Bookmarks [255]model.Bookmark
+
+// BookmarkCount tracks the current number of bookmarks stored in the Bookmarks array.
+var BookmarkCount int
@@ -0,0 +1,10 @@
+package global
+
+import "tugas-besar/lib/model"
+
+// LoginAttempts is an in-memory storage array that holds the last 255 login attempts.
+// It serves as the storage mechanism for the loginRepository implementation.
+var LoginAttempts [255]model.LoginAttempt
+
+// LoginAttemptCount tracks the current number of login attempts stored in the LoginAttempts array.
+var LoginAttemptCount int
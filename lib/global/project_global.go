@@ -0,0 +1,15 @@
+package global
+
+import "tugas-besar/lib/model"
+
+// Projects is an in-memory storage array that holds up to 255 project records.
+// It serves as the persistent storage mechanism for the projectRepository implementation.
+var Projects [255]model.Project
+
+// ProjectCount tracks the current number of projects stored in the Projects array.
+var ProjectCount int
+
+// ActiveProjectId is the ID of the project the comment listings, statistics and exports
+// are scoped to, and that new comments are added to. 0 means no project is selected and
+// every comment is included.
+var ActiveProjectId int
@@ -0,0 +1,10 @@
+package global
+
+import "tugas-besar/lib/model"
+
+// ScheduledComments is an in-memory storage array that holds up to 255 comments waiting
+// to be published. It serves as the storage mechanism for the scheduleRepository implementation.
+var ScheduledComments [255]model.ScheduledComment
+
+// ScheduledCount tracks the current number of comments stored in the ScheduledComments array.
+var ScheduledCount int
@@ -0,0 +1,10 @@
+package global
+
+import "tugas-besar/lib/model"
+
+// Templates is an in-memory storage array that holds up to 255 comment templates.
+// It serves as the storage mechanism for the templateRepository implementation.
+var Templates [255]model.Template
+
+// TemplateCount tracks the current number of templates stored in the Templates array.
+var TemplateCount int
@@ -18,10 +18,10 @@ var UserCount int
 // It's used both as an index for adding new comments and for iteration limits when displaying or processing comments.
 var CommentCount int
 
-// IdUserIncrement is a counter used to generate unique IDs for user records.
-// It increments each time a new user is created, ensuring each user has a unique identifier.
-var IdUserIncrement int
+// CommentRevisions is an in-memory storage array that holds up to 255 previous comment versions.
+// It serves as the edit history storage for the commentRepository implementation.
+var CommentRevisions [255]model.CommentRevision
 
-// IdCommentIncrement is a counter used to generate unique IDs for comment records.
-// It increments each time a new comment is created, ensuring each comment has a unique identifier.
-var IdCommentIncrement int
+// RevisionCount tracks the current number of revisions stored in the CommentRevisions array.
+// When the array is full, the oldest revision is discarded to make room for the newest one.
+var RevisionCount int
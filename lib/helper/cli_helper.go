@@ -5,14 +5,61 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/global"
 )
 
+// activeProfile is the name of the data profile in use, shown above every screen header.
+var activeProfile string
+
+// SetActiveProfile sets the name of the data profile shown above every screen header.
+//
+// Parameters:
+//   - name: The name of the active profile, empty to hide it
+func SetActiveProfile(name string) {
+	activeProfile = name
+}
+
 // ClearScreen clears the terminal/console screen.
 // It works cross-platform by using the appropriate command based on the operating system:
 // - Windows: uses "cls" command
 // - Unix/Linux/macOS: uses "clear" command
 // If the command execution fails, it falls back to using ANSI escape sequences.
+// Afterwards the active data profile and project are printed, so they appear above every
+// screen header.
+//
+// Every screen starts with ClearScreen, so it also counts as activity for the idle lock
+// and, while the screen is locked, first asks the password of the session, see LockSession.
 func ClearScreen() {
+	waitUnlock()
+	Touch()
+	clearTerminal()
+
+	context := activeProfile
+	if context != "" {
+		context = "Profil: " + context
+	}
+
+	for i := 0; i < global.ProjectCount; i++ {
+		if global.Projects[i].Id == global.ActiveProjectId {
+			if context != "" {
+				context += " | "
+			}
+			context += "Proyek: " + global.Projects[i].Nama
+		}
+	}
+
+	if context != "" {
+		color.Cyan("[%s]", context)
+	}
+}
+
+// clearTerminal clears the terminal with "cls" on Windows and "clear" elsewhere,
+// falling back to ANSI escape sequences when the command fails.
+func clearTerminal() {
 	var cmd *exec.Cmd
 
 	if runtime.GOOS == "windows" {
@@ -30,3 +77,42 @@ func ClearScreen() {
 		fmt.Print("\033[H\033[2J")
 	}
 }
+
+// ConfirmDelete asks the user to confirm a destructive action before it is performed.
+// It prints the text of the record that is about to be deleted so the user can
+// verify it is the right one, then shows a "Yakin ingin menghapus?" confirmation prompt.
+//
+// Parameters:
+//   - text: The text of the record that will be deleted (comment text or username)
+//
+// Returns:
+//   - bool: true if the user confirmed the deletion, false otherwise
+func ConfirmDelete(text string) bool {
+	color.Cyan("Data yang akan dihapus: %s", text)
+
+	confirmPrompt := promptui.Prompt{
+		Label:     "Yakin ingin menghapus?",
+		IsConfirm: true,
+	}
+
+	_, err := confirmPrompt.Run()
+	return err == nil
+}
+
+// Truncate shortens a text to at most n characters for a one-line menu item,
+// ending it with "..." when it was cut.
+//
+// Parameters:
+//   - text: The text to shorten
+//   - n: The maximum number of characters, at least 4
+//
+// Returns:
+//   - string: The text, shortened if it was longer than n characters
+func Truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+
+	return string(runes[:n-3]) + "..."
+}
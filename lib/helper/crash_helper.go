@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// crashDump is the content of a crash file.
+type crashDump struct {
+	Time      time.Time                `json:"time"`
+	Version   string                   `json:"version"`
+	Panic     string                   `json:"panic"`
+	Stack     string                   `json:"stack"`
+	Users     []model.User             `json:"users"`
+	Comments  []model.Comment          `json:"comments"`
+	Projects  []model.Project          `json:"projects"`
+	Scheduled []model.ScheduledComment `json:"scheduled"`
+	Templates []model.Template         `json:"templates"`
+}
+
+// DumpCrash writes the in-memory state together with the panic and its stack trace to
+// a crash file named crash-YYYYMMDD-HHMMSS.json in CRASH_DIR (the current directory by
+// default), so the data can be recovered and the bug reported.
+//
+// Parameters:
+//   - recovered: The value returned by recover()
+//   - stack: The stack trace of the panic
+//
+// Returns:
+//   - string: The path of the crash file
+//   - error: An error if the crash file cannot be written
+func DumpCrash(recovered any, stack []byte) (string, error) {
+	dir := GetEnv("CRASH_DIR", ".")
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	// The dump is meant to be attached to bug reports, so it must not carry passwords.
+	// Legacy accounts still hold their password in plaintext.
+	users := make([]model.User, global.UserCount)
+	copy(users, global.Users[:global.UserCount])
+	for i := range users {
+		users[i].Password = ""
+	}
+
+	now := time.Now()
+	dump := crashDump{
+		Time:      now,
+		Version:   BuildInfo(),
+		Panic:     fmt.Sprint(recovered),
+		Stack:     string(stack),
+		Users:     users,
+		Comments:  global.Comments[:global.CommentCount],
+		Projects:  global.Projects[:global.ProjectCount],
+		Scheduled: global.ScheduledComments[:global.ScheduledCount],
+		Templates: global.Templates[:global.TemplateCount],
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := fmt.Sprintf("%s/crash-%s.json", dir, now.Format("20060102-150405"))
+
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"encoding/csv"
+	"os"
+)
+
+// WriteCSV writes a header row followed by data rows to a CSV file.
+// The file is created if it does not exist and truncated if it does.
+//
+// Parameters:
+//   - path: The location of the CSV file to write
+//   - header: The column names written as the first row
+//   - rows: The data rows written after the header
+//
+// Returns:
+//   - error: An error if the file cannot be created or written, nil on success
+func WriteCSV(path string, header []string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	err = writer.Write(header)
+	if err != nil {
+		return err
+	}
+
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+// AppendCSV appends data rows to a CSV file.
+// When the file does not exist yet it is created and the header row is written first,
+// so repeated calls build up a single file with one header.
+//
+// Parameters:
+//   - path: The location of the CSV file to append to
+//   - header: The column names written when the file is created
+//   - rows: The data rows appended to the file
+//
+// Returns:
+//   - error: An error if the file cannot be opened or written, nil on success
+func AppendCSV(path string, header []string, rows [][]string) error {
+	_, err := os.Stat(path)
+	isNew := os.IsNotExist(err)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	if isNew {
+		err = writer.Write(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
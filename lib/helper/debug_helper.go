@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// debugLog writes trace lines to the log file, nil until the first trace
+	debugLog *log.Logger
+
+	// debugOnce opens the log file once
+	debugOnce sync.Once
+)
+
+// Debug reports whether debug tracing is enabled with DEBUG=1 (or DEBUG=true).
+//
+// Returns:
+//   - bool: true if trace lines are written to the log file
+func Debug() bool {
+	value := GetEnv("DEBUG", "")
+	return value == "1" || value == "true"
+}
+
+// LogFile returns the path of the debug log file, LOG_FILE or "debug.log" by default.
+//
+// Returns:
+//   - string: The path of the debug log file
+func LogFile() string {
+	return GetEnv("LOG_FILE", "debug.log")
+}
+
+// Trace writes one line to the debug log file (LOG_FILE, default "debug.log") when
+// debug tracing is enabled. Every line is prefixed with the date and time including
+// microseconds. When the log file cannot be opened, tracing is silently disabled so
+// it never gets in the way of the application.
+//
+// Parameters:
+//   - format: The fmt format of the line
+//   - args: The values for the format
+func Trace(format string, args ...any) {
+	if !Debug() {
+		return
+	}
+
+	debugOnce.Do(func() {
+		file, err := os.OpenFile(LogFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return
+		}
+
+		debugLog = log.New(file, "", log.LstdFlags|log.Lmicroseconds)
+	})
+
+	if debugLog != nil {
+		debugLog.Output(2, fmt.Sprintf(format, args...))
+	}
+}
+
+// TraceTime traces the start of an operation and returns a function that traces its
+// end together with the elapsed time. It is meant to be used with defer:
+//
+//	defer helper.TraceTime("admin > Laporan")()
+//
+// Parameters:
+//   - name: The name of the operation
+//
+// Returns:
+//   - func(): The function that traces the end of the operation
+func TraceTime(name string) func() {
+	if !Debug() {
+		return func() {}
+	}
+
+	start := time.Now()
+	Trace("mulai   %s", name)
+
+	return func() {
+		Trace("selesai %s (%s)", name, time.Since(start))
+	}
+}
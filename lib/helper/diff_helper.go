@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// WordDiff builds a colored, word-level diff between two versions of a text.
+// It computes the longest common subsequence of the words in both texts and marks
+// every word that only exists in the old text in red (prefixed with "-") and every
+// word that only exists in the new text in green (prefixed with "+"). Words that
+// are present in both versions are printed unchanged.
+//
+// Parameters:
+//   - oldText: The previous version of the text
+//   - newText: The current version of the text
+//
+// Returns:
+//   - string: The diff as a single line ready to be printed to the terminal
+func WordDiff(oldText, newText string) string {
+	oldWords := strings.Fields(oldText)
+	newWords := strings.Fields(newText)
+
+	// lcs[i][j] holds the LCS length of oldWords[i:] and newWords[j:]
+	lcs := make([][]int, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newWords)+1)
+	}
+
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i] == newWords[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	removed := color.New(color.FgRed, color.CrossedOut).SprintFunc()
+	added := color.New(color.FgGreen).SprintFunc()
+
+	var parts []string
+	i, j := 0, 0
+	for i < len(oldWords) && j < len(newWords) {
+		if oldWords[i] == newWords[j] {
+			parts = append(parts, oldWords[i])
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			parts = append(parts, removed("-"+oldWords[i]))
+			i++
+		} else {
+			parts = append(parts, added("+"+newWords[j]))
+			j++
+		}
+	}
+
+	for ; i < len(oldWords); i++ {
+		parts = append(parts, removed("-"+oldWords[i]))
+	}
+
+	for ; j < len(newWords); j++ {
+		parts = append(parts, added("+"+newWords[j]))
+	}
+
+	return strings.Join(parts, " ")
+}
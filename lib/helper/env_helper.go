@@ -1,6 +1,14 @@
 package helper
 
-import "os"
+import (
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// EnvFile is the .env file the configuration is loaded from and settings are saved to.
+const EnvFile = ".env"
 
 // GetEnv retrieves the value of an environment variable by its key.
 // If the environment variable is not set or is empty, it returns
@@ -22,3 +30,36 @@ func GetEnv(key, fallback string) string {
 
 	return value
 }
+
+// SaveEnv sets a variable for the running process and persists it to a .env file.
+// The other variables in the file are kept; the file is rewritten in sorted order.
+//
+// Parameters:
+//   - path: The location of the .env file
+//   - key: The environment variable to set
+//   - value: The new value
+//
+// Returns:
+//   - error: An error if the .env file cannot be read or written
+func SaveEnv(path, key, value string) error {
+	err := os.Setenv(key, value)
+	if err != nil {
+		return err
+	}
+
+	values, err := godotenv.Read(path)
+	if os.IsNotExist(err) {
+		values = make(map[string]string)
+	} else if err != nil {
+		return fmt.Errorf("gagal membaca %s: %v", path, err)
+	}
+
+	values[key] = value
+
+	err = godotenv.Write(values, path)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan %s: %v", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword hashes a password with bcrypt so it is never stored in plain text.
+//
+// Parameters:
+//   - password: The password entered by the user
+//
+// Returns:
+//   - string: The bcrypt hash of the password
+//   - error: An error if the password cannot be hashed, e.g. when it is longer than 72 bytes
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// CheckPasswordHash reports whether a password matches a stored password.
+//
+// Accounts created before passwords were hashed still have their password stored in
+// plain text in the journal. Such a stored password is compared directly, see
+// IsPasswordHash, so those users can log in and get their password hashed.
+//
+// Parameters:
+//   - password: The password entered by the user
+//   - hash: The stored password, a bcrypt hash or a legacy plain text password
+//
+// Returns:
+//   - bool: true if the password matches
+func CheckPasswordHash(password, hash string) bool {
+	if !IsPasswordHash(hash) {
+		return subtle.ConstantTimeCompare([]byte(password), []byte(hash)) == 1
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// IsPasswordHash reports whether a stored password is a bcrypt hash rather than a
+// legacy plain text password.
+//
+// Parameters:
+//   - password: The stored password
+//
+// Returns:
+//   - bool: true if the password is a bcrypt hash
+func IsPasswordHash(password string) bool {
+	_, err := bcrypt.Cost([]byte(password))
+
+	return err == nil && strings.HasPrefix(password, "$2")
+}
@@ -0,0 +1,174 @@
+package helper
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// headerWidth is the width of the title banner, the border included. Titles that do not
+// fit widen the banner instead of breaking the border.
+const headerWidth = 40
+
+// headerTranslations holds the English text of the breadcrumbs and titles, used when
+// LANGUAGE is set to en. A text that is missing here is shown as it is.
+var headerTranslations = map[string]string{
+	"ADD":                      "ADD",
+	"ADMIN":                    "ADMIN",
+	"ADMIN MENU":               "ADMIN MENU",
+	"ANALISIS SENTIMEN - LIVE": "SENTIMENT ANALYSIS - LIVE",
+	"AUDIT LOG":                "AUDIT LOG",
+	"BALAS KOMENTAR":           "REPLY TO COMMENT",
+	"BANTUAN":                  "HELP",
+	"BUAT LAPORAN ERROR":       "CREATE ERROR REPORT",
+	"BULK":                     "BULK",
+	"BULK KOMENTAR":            "BULK COMMENTS",
+	"CARI":                     "SEARCH",
+	"CARI KOMENTAR":            "SEARCH COMMENTS",
+	"CATATAN":                  "NOTES",
+	"CATATAN ADMIN":            "ADMIN NOTES",
+	"CONFUSION MATRIX":         "CONFUSION MATRIX",
+	"DATA KOMENTAR":            "COMMENT DATA",
+	"DATA USER":                "USER DATA",
+	"DELETE":                   "DELETE",
+	"DELETE KOMENTAR":          "DELETE COMMENT",
+	"DETAIL KOMENTAR":          "COMMENT DETAIL",
+	"DETAIL USER":              "USER DETAIL",
+	"DIAGNOSTIK":               "DIAGNOSTICS",
+	"DIHAPUS":                  "DELETED",
+	"EDIT":                     "EDIT",
+	"EDIT KOMENTAR":            "EDIT COMMENT",
+	"EVALUASI KLASIFIKASI":     "CLASSIFIER EVALUATION",
+	"EXPORT":                   "EXPORT",
+	"EXPORT DATA":              "EXPORT DATA",
+	"EXPORT DATASET JSONL":     "EXPORT JSONL DATASET",
+	"EXPORT GRAFIK PNG":        "EXPORT PNG CHART",
+	"EXPORT KOMENTAR":          "EXPORT COMMENTS",
+	"EXPORT USER":              "EXPORT USERS",
+	"FAVORIT":                  "FAVORITES",
+	"GANTI PASSWORD":           "CHANGE PASSWORD",
+	"GRAFIK":                   "CHARTS",
+	"HAPUS KOMENTAR":           "DELETE COMMENT",
+	"IMPERSONASI":              "IMPERSONATE",
+	"IMPORT":                   "IMPORT",
+	"IMPORT KOMENTAR":          "IMPORT COMMENTS",
+	"IMPORT USER":              "IMPORT USERS",
+	"INPUT CEPAT":              "QUICK INPUT",
+	"INPUT KOMENTAR":           "ADD COMMENT",
+	"JOURNAL":                  "JOURNAL",
+	"KESEPAKATAN LABEL":        "LABEL AGREEMENT",
+	"KIRIM LAPORAN":            "SEND REPORT",
+	"KLASIFIKASI FILE":         "CLASSIFY FILE",
+	"KLASIFIKASI HUGGINGFACE":  "HUGGINGFACE CLASSIFICATION",
+	"KOMENTAR FAVORIT":         "FAVORITE COMMENTS",
+	"KOMENTAR TERJADWAL":       "SCHEDULED COMMENTS",
+	"KOMENTAR YATIM":           "ORPHAN COMMENTS",
+	"KOMPAKSI":                 "COMPACTION",
+	"KOMPAKSI PENYIMPANAN":     "STORAGE COMPACTION",
+	"LABEL KEDUA":              "SECOND LABEL",
+	"LAPORAN":                  "REPORTS",
+	"LAPORAN BULANAN":          "MONTHLY REPORT",
+	"LIHAT KOMENTAR":           "VIEW COMMENTS",
+	"LIHAT SEBAGAI TAMU":       "VIEW AS GUEST",
+	"LIHAT SEBAGAI USER":       "VIEW AS USER",
+	"LIHAT USER":               "VIEW USERS",
+	"LOGIN":                    "LOGIN",
+	"MAINTENANCE":              "MAINTENANCE",
+	"MENU":                     "MENU",
+	"MENU USER":                "USER MENU",
+	"MERGE":                    "MERGE",
+	"MERGE USER":               "MERGE USERS",
+	"PEMULIHAN DATA":           "DATA RECOVERY",
+	"PENGATURAN":               "SETTINGS",
+	"PERBANDINGAN LABEL":       "LABEL COMPARISON",
+	"PRATINJAU":                "PREVIEW",
+	"PRATINJAU (DRY-RUN)":      "PREVIEW (DRY-RUN)",
+	"PROFIL":                   "PROFILES",
+	"PROYEK":                   "PROJECTS",
+	"REGISTER":                 "REGISTER",
+	"RETENSI":                  "RETENTION",
+	"REVIEW":                   "REVIEW",
+	"REVIEW KOMENTAR":          "REVIEW COMMENTS",
+	"RIWAYAT LOGIN":            "LOGIN HISTORY",
+	"ROLE":                     "ROLE",
+	"ROLE USER":                "USER ROLES",
+	"SAMPEL ACAK":              "RANDOM SAMPLE",
+	"SARAN LABEL":              "LABEL SUGGESTIONS",
+	"SEARCH":                   "SEARCH",
+	"SORTING":                  "SORTING",
+	"SORTING KOMENTAR":         "SORT COMMENTS",
+	"SPLIT TRAIN/TEST":         "TRAIN/TEST SPLIT",
+	"STATISTIK":                "STATISTICS",
+	"STATUS":                   "STATUS",
+	"STATUS KOMENTAR":          "COMMENT STATUS",
+	"TAMBAH KOMENTAR":          "ADD COMMENT",
+	"TAMU":                     "GUEST",
+	"TEMPLATE":                 "TEMPLATES",
+	"TEMPLATE KOMENTAR":        "COMMENT TEMPLATES",
+	"TENTANG":                  "ABOUT",
+	"TERAKHIR DILIHAT":         "RECENTLY VIEWED",
+	"TINDAK LANJUT":            "FOLLOW-UP",
+	"USER":                     "USER",
+	"USER DIHAPUS":             "DELETED USERS",
+	"USER X KATEGORI":          "USER X CATEGORY",
+}
+
+// Header clears the screen and prints the header of a screen: the breadcrumb that tells
+// the user where they are, followed by the title centered in a banner. Every screen
+// renders its header here so the width, the language and the colors are the same
+// everywhere; the colors follow the THEME setting through color.NoColor.
+//
+// Example:
+//
+//	helper.Header("MENU > ADMIN > LIHAT USER", "DATA USER")
+//
+// prints "* MENU > ADMIN > LIHAT USER" followed by "DATA USER" centered in a banner of
+// headerWidth characters framed by "=".
+//
+// Parameters:
+//   - breadcrumb: The path of the screen with the parts separated by " > ", or empty
+//     for full-screen views without a breadcrumb
+//   - title: The title shown in the banner
+func Header(breadcrumb string, title string) {
+	ClearScreen()
+
+	yellow := color.New(color.FgYellow)
+
+	if breadcrumb != "" {
+		parts := strings.Split(breadcrumb, " > ")
+		for i, part := range parts {
+			parts[i] = translateHeader(part)
+		}
+		yellow.Println("* " + strings.Join(parts, " > "))
+	}
+
+	title = translateHeader(title)
+	width := max(headerWidth, utf8.RuneCountInString(title)+4)
+	left := (width - 2 - utf8.RuneCountInString(title)) / 2
+	right := width - 2 - utf8.RuneCountInString(title) - left
+
+	yellow.Println(strings.Repeat("=", width))
+	yellow.Println("=" + strings.Repeat(" ", left) + title + strings.Repeat(" ", right) + "=")
+	yellow.Println(strings.Repeat("=", width))
+}
+
+// translateHeader returns the text of a breadcrumb part or a title in the language of
+// the LANGUAGE setting.
+//
+// Parameters:
+//   - text: The Indonesian text
+//
+// Returns:
+//   - string: The English text when LANGUAGE is en and a translation exists, text otherwise
+func translateHeader(text string) string {
+	if GetEnv("LANGUAGE", "id") != "en" {
+		return text
+	}
+
+	if translated, ok := headerTranslations[text]; ok {
+		return translated
+	}
+
+	return text
+}
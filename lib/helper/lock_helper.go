@@ -0,0 +1,145 @@
+package helper
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+var (
+	// lockMu guards the idle lock state below
+	lockMu sync.Mutex
+
+	// lastActivity is the time of the last key press or screen change
+	lastActivity = time.Now()
+
+	// locked reports whether the screen was blanked and waits for the password
+	locked bool
+
+	// unlocking is set while the password is asked, so the prompt does not lock itself
+	unlocking bool
+
+	// unlockSession asks the password of the logged-in session, nil outside a session
+	unlockSession func() (bool, error)
+)
+
+// LockSession starts guarding a logged-in session with the idle lock. After LOCK_MINUTES
+// without activity the screen is blanked and unlock is asked until it reports the
+// password was entered correctly. The session itself stays logged in, unless the
+// password cannot be asked at all, see waitUnlock.
+//
+// Parameters:
+//   - unlock: Asks the password of the session again, true when it matched, or an
+//     error when the prompt failed
+func LockSession(unlock func() (bool, error)) {
+	lockMu.Lock()
+	defer lockMu.Unlock()
+
+	unlockSession = unlock
+	lastActivity = time.Now()
+	locked = false
+}
+
+// EndSession stops guarding the session started by LockSession, e.g. on logout.
+func EndSession() {
+	lockMu.Lock()
+	defer lockMu.Unlock()
+
+	unlockSession = nil
+	locked = false
+}
+
+// Touch records activity, postponing the idle lock.
+func Touch() {
+	lockMu.Lock()
+	defer lockMu.Unlock()
+
+	lastActivity = time.Now()
+}
+
+// StartIdleLock starts the background job that locks an idle session.
+//
+// A goroutine checks every 10 seconds whether LOCK_MINUTES minutes have passed since the
+// last activity in a session started by LockSession and, if so, blanks the screen. Until
+// the password is entered the prompt that was open receives no keys, see shortcutReader.
+// The setting is read on every check, so changing it on the settings screen takes effect
+// without a restart; 0 disables the lock.
+func StartIdleLock() {
+	go func() {
+		for range time.Tick(10 * time.Second) {
+			minutes, err := strconv.Atoi(GetEnv("LOCK_MINUTES", "0"))
+			if err != nil || minutes <= 0 {
+				continue
+			}
+
+			lockMu.Lock()
+			idle := unlockSession != nil && !locked && !unlocking && time.Since(lastActivity) >= time.Duration(minutes)*time.Minute
+			if idle {
+				locked = true
+			}
+			lockMu.Unlock()
+
+			if idle {
+				Trace("idle lock after %d minutes", minutes)
+				clearTerminal()
+				color.Yellow("Layar terkunci setelah %d menit tanpa aktivitas. Tekan tombol apa saja untuk membuka.", minutes)
+			}
+		}
+	}()
+}
+
+// waitUnlock blocks while the screen is locked, asking the password of the session
+// until it matches. It is called by the shortcut reader when a key is pressed on the
+// locked screen and by ClearScreen, so no key reaches a prompt and no screen is drawn
+// while locked.
+//
+// Called from the shortcut reader, the password prompt runs nested inside the read of
+// the prompt that was open: that prompt stays in its read, keeps its terminal mode and
+// its typed text, and receives the next key once the screen is unlocked. The password
+// prompt reads through the same reader, which passes its keys on unfiltered.
+//
+// When the password cannot be asked, e.g. on Ctrl+C or when the input ended, the
+// session is ended and every open screen goes back to the main menu, which logs the
+// user out, instead of asking again.
+func waitUnlock() {
+	lockMu.Lock()
+	if !locked || unlocking || unlockSession == nil {
+		lockMu.Unlock()
+		return
+	}
+	unlocking = true
+	unlock := unlockSession
+	lockMu.Unlock()
+
+	clearTerminal()
+	color.Yellow("Layar terkunci. Masukkan password untuk melanjutkan.")
+	for {
+		ok, err := unlock()
+		if err != nil {
+			lockMu.Lock()
+			unlocking = false
+			lockMu.Unlock()
+
+			EndSession()
+			RequestMainMenu()
+			color.Red("Password tidak dimasukkan, sesi diakhiri.")
+
+			return
+		}
+		if ok {
+			break
+		}
+
+		color.Red("Password salah")
+	}
+
+	lockMu.Lock()
+	locked = false
+	unlocking = false
+	lastActivity = time.Now()
+	lockMu.Unlock()
+
+	color.Green("Layar terbuka, lanjutkan input sebelumnya.")
+}
@@ -0,0 +1,175 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// MenuItemConfig describes how a single menu item is displayed.
+type MenuItemConfig struct {
+	// Label replaces the built-in label of the item, e.g. to translate it.
+	Label string `json:"label"`
+
+	// Hidden removes the item from the menu.
+	Hidden bool `json:"hidden"`
+}
+
+// menuConfig holds the loaded menu configuration, keyed by menu name and then by the
+// built-in label of the item.
+var menuConfig map[string]map[string]MenuItemConfig
+
+var (
+	// menuStateMu guards menuState
+	menuStateMu sync.Mutex
+
+	// menuState holds the built-in label of the item last selected in every menu, keyed
+	// by menu name. It is read from MENU_STATE_FILE on first use.
+	menuState map[string]string
+)
+
+// LoadMenuConfig reads the menu configuration from a JSON file.
+//
+// The file maps a menu name to its items, and each item's built-in label to its
+// configuration, for example:
+//
+//	{"admin": {"Import Komentar": {"hidden": true}, "Laporan": {"label": "Reports"}}}
+//
+// A missing file is not an error; every menu then uses its built-in items.
+//
+// Parameters:
+//   - path: The location of the JSON menu configuration file
+//
+// Returns:
+//   - error: An error if the file exists but cannot be read or decoded
+func LoadMenuConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		menuConfig = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read menu config: %v", err)
+	}
+
+	var config map[string]map[string]MenuItemConfig
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return fmt.Errorf("invalid menu config %s: %v", path, err)
+	}
+
+	menuConfig = config
+
+	return nil
+}
+
+// MenuItems applies the menu configuration to the built-in items of a menu.
+//
+// Hidden items are left out and the remaining items get their configured label.
+// The "Exit" and "Kembali" items cannot be hidden, so a menu can always be left.
+// The selected index of the returned labels maps to the built-in label in keys, which
+// is what the controllers compare against, so renaming an item does not change how
+// it is handled.
+//
+// Parameters:
+//   - menu: The name of the menu in the configuration file
+//   - items: The built-in labels of the menu items, in display order
+//
+// Returns:
+//   - []string: The labels to display
+//   - []string: The built-in label of each displayed item
+func MenuItems(menu string, items []string) ([]string, []string) {
+	labels := make([]string, 0, len(items))
+	keys := make([]string, 0, len(items))
+
+	for _, item := range items {
+		config := menuConfig[menu][item]
+		if config.Hidden && item != "Exit" && item != "Kembali" {
+			continue
+		}
+
+		label := item
+		if config.Label != "" {
+			label = config.Label
+		}
+
+		labels = append(labels, label)
+		keys = append(keys, item)
+	}
+
+	return labels, keys
+}
+
+// MenuCursor returns the position of the item that was selected the last time a menu
+// was used, so the menu opens with that item highlighted. The positions are kept in
+// MENU_STATE_FILE (default menu_state.json) and survive a restart.
+//
+// Parameters:
+//   - menu: The name of the menu, as passed to MenuItems
+//   - keys: The built-in label of each displayed item
+//
+// Returns:
+//   - int: The index of the last selected item, 0 if there is none or it is not displayed
+func MenuCursor(menu string, keys []string) int {
+	menuStateMu.Lock()
+	defer menuStateMu.Unlock()
+
+	loadMenuState()
+
+	return max(slices.Index(keys, menuState[menu]), 0)
+}
+
+// RememberMenu stores the item selected in a menu for MenuCursor and writes the positions
+// to MENU_STATE_FILE. The "Exit" and "Kembali" items are not remembered, so a menu never
+// opens on the item that leaves it. A file that cannot be written only loses the
+// positions, the menu keeps working.
+//
+// Parameters:
+//   - menu: The name of the menu, as passed to MenuItems
+//   - key: The built-in label of the selected item
+func RememberMenu(menu string, key string) {
+	if key == "Exit" || key == "Kembali" {
+		return
+	}
+
+	menuStateMu.Lock()
+	defer menuStateMu.Unlock()
+
+	loadMenuState()
+
+	if menuState[menu] == key {
+		return
+	}
+	menuState[menu] = key
+
+	data, err := json.MarshalIndent(menuState, "", "  ")
+	if err == nil {
+		err = os.WriteFile(GetEnv("MENU_STATE_FILE", "menu_state.json"), data, 0644)
+	}
+	if err != nil {
+		Trace("menu state: %v", err)
+	}
+}
+
+// loadMenuState reads MENU_STATE_FILE the first time the menu positions are needed.
+// A missing or invalid file starts with no remembered positions. menuStateMu must be held.
+func loadMenuState() {
+	if menuState != nil {
+		return
+	}
+
+	menuState = make(map[string]string)
+
+	data, err := os.ReadFile(GetEnv("MENU_STATE_FILE", "menu_state.json"))
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &menuState)
+	if err != nil {
+		Trace("menu state: %v", err)
+		menuState = make(map[string]string)
+	}
+}
@@ -0,0 +1,14 @@
+package helper
+
+import "errors"
+
+// Navigation errors are returned by the services to tell a controller where to go next
+// instead of reporting a failure. Controllers compare against them with errors.Is, so a
+// real error whose message happens to read "back" is never mistaken for navigation.
+var (
+	// ErrBack leaves the current screen and returns to the previous menu.
+	ErrBack = errors.New("back")
+
+	// ErrContinue shows the current screen again, e.g. after an action or invalid input.
+	ErrContinue = errors.New("continue")
+)
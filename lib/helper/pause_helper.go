@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Pause shows "Tekan Enter untuk melanjutkan" (or the English text when LANGUAGE is en)
+// and waits until the user presses Enter. Every screen that waits for the user to read
+// it pauses here instead of calling fmt.Scanln directly.
+//
+// The pause is configured with:
+//   - PAUSE_TIMEOUT: Seconds after which the screen continues by itself, e.g. for a kiosk
+//     without keyboard. 0, the default, waits for Enter.
+//   - HEADLESS: When true the pause returns immediately without showing anything, for
+//     scripted runs and tests that feed the menus through stdin.
+//
+// The pause is skipped while the screens go back to the main menu, see RequestMainMenu.
+func Pause() {
+	if GetEnv("HEADLESS", "false") == "true" || MainMenuRequested() {
+		return
+	}
+
+	timeout, err := strconv.Atoi(GetEnv("PAUSE_TIMEOUT", "0"))
+	if err != nil || timeout < 0 {
+		timeout = 0
+	}
+
+	message := "Tekan Enter untuk melanjutkan"
+	if GetEnv("LANGUAGE", "id") == "en" {
+		message = "Press Enter to continue"
+	}
+
+	if timeout == 0 {
+		color.Cyan(message)
+		waitEnter(nil)
+		return
+	}
+
+	if GetEnv("LANGUAGE", "id") == "en" {
+		color.Cyan("%s (continues in %d seconds)", message, timeout)
+	} else {
+		color.Cyan("%s (lanjut otomatis dalam %d detik)", message, timeout)
+	}
+
+	timer := time.NewTimer(time.Duration(timeout) * time.Second)
+	defer timer.Stop()
+
+	waitEnter(timer.C)
+}
+
+// EnterPressed waits for Enter in the background, for screens that keep refreshing while
+// they wait, such as the kiosk. Like Pause, the keys are read through the shortcut reader
+// shared with the prompts.
+//
+// Returns:
+//   - <-chan struct{}: Closed when Enter is pressed
+//   - func(): Stops waiting, so no keys typed afterwards are taken; safe to call more than once
+func EnterPressed() (<-chan struct{}, func()) {
+	pressed := make(chan struct{})
+	cancel := make(chan time.Time)
+
+	go func() {
+		waitEnter(cancel)
+		close(pressed)
+	}()
+
+	return pressed, sync.OnceFunc(func() { close(cancel) })
+}
+
+// waitEnter blocks until Enter is pressed or the timeout channel fires. The keys are read
+// through the shortcut reader shared with the prompts, so a pause that timed out leaves
+// no reader behind that would take the next keys typed into a menu.
+//
+// Parameters:
+//   - timeout: Fires when the pause should end without Enter, nil to wait for Enter only
+func waitEnter(timeout <-chan time.Time) {
+	buf := make([]byte, 1024)
+	for {
+		n, err := stdinReader.read(buf, timeout)
+		if err != nil || bytes.ContainsAny(buf[:n], "\r\n") || bytes.IndexByte(buf[:n], interruptKey) >= 0 {
+			return
+		}
+	}
+}
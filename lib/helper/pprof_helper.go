@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+)
+
+// StartProfiling starts the profilers used to measure the sort and search
+// implementations on big datasets.
+//
+// When addr is not empty the pprof endpoints (/debug/pprof/) are served on that address
+// in the background, so profiles can be taken with `go tool pprof` while the application
+// runs. When dir is not empty a CPU profile is recorded to dir/cpu.pprof and a heap
+// profile is written to dir/mem.pprof when the returned function is called.
+//
+// Parameters:
+//   - addr: The address of the pprof endpoint, e.g. "localhost:6060", or empty
+//   - dir: The directory of the profile files, or empty
+//
+// Returns:
+//   - func(): The function that stops the CPU profile and writes the heap profile,
+//     it must be called before the program exits
+//   - error: An error if the profile files cannot be created
+func StartProfiling(addr string, dir string) (func(), error) {
+	if addr != "" {
+		go func() {
+			err := http.ListenAndServe(addr, nil)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "pprof: %v\n", err)
+			}
+		}()
+	}
+
+	if dir == "" {
+		return func() {}, nil
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	cpuFile, err := os.Create(filepath.Join(dir, "cpu.pprof"))
+	if err != nil {
+		return nil, err
+	}
+
+	err = pprof.StartCPUProfile(cpuFile)
+	if err != nil {
+		cpuFile.Close()
+		return nil, err
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+
+		memFile, err := os.Create(filepath.Join(dir, "mem.pprof"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "pprof: %v\n", err)
+			return
+		}
+		defer memFile.Close()
+
+		// Collect garbage first so the heap profile only shows live data
+		runtime.GC()
+
+		err = pprof.WriteHeapProfile(memFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "pprof: %v\n", err)
+		}
+	}, nil
+}
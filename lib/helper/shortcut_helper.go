@@ -0,0 +1,293 @@
+package helper
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chzyer/readline"
+)
+
+// MainMenuCommand is typed in a prompt, followed by Enter, to jump back to the main menu.
+const MainMenuCommand = ":menu"
+
+const (
+	// escKey is the byte sent by the Esc key
+	escKey = 0x1b
+
+	// interruptKey is the byte sent by Ctrl+C, which makes a prompt return promptui.ErrInterrupt
+	interruptKey = 0x03
+)
+
+var (
+	// mainMenuRequested is set when the user asked to jump back to the main menu and
+	// cleared again by the main menu.
+	mainMenuRequested atomic.Bool
+
+	// stdinReader is the only reader of the standard input, shared by the prompts and Pause
+	stdinReader = &shortcutReader{chunks: make(chan stdinChunk, 1)}
+
+	// errReadCancelled is returned by a read that was cancelled before any key arrived
+	errReadCancelled = errors.New("read cancelled")
+)
+
+// InstallShortcuts makes every promptui prompt read the keyboard through a filter that
+// recognizes the main menu shortcut: Esc in any prompt, or MainMenuCommand followed by
+// Enter in a text prompt. The shortcut interrupts the prompt like Ctrl+C and, until the
+// main menu is shown again, interrupts every following prompt too, so all nested screens
+// go back one after another without the user walking each Exit option.
+func InstallShortcuts() {
+	readline.Stdin = stdinReader
+}
+
+// RequestMainMenu asks every open screen to go back to the main menu.
+func RequestMainMenu() {
+	mainMenuRequested.Store(true)
+}
+
+// MainMenuRequested reports whether the user asked to jump back to the main menu.
+//
+// Returns:
+//   - bool: true until ClearMainMenuRequest is called
+func MainMenuRequested() bool {
+	return mainMenuRequested.Load()
+}
+
+// ClearMainMenuRequest is called by the main menu once it is reached.
+func ClearMainMenuRequest() {
+	mainMenuRequested.Store(false)
+}
+
+// LeaveMenu selects the last item of a menu, which is its Exit or Kembali item, when
+// the user asked to jump back to the main menu. Menus call it after their prompt
+// returned, so the controller leaves the menu instead of handling the interrupt as an
+// error.
+//
+// Parameters:
+//   - keys: The built-in labels of the menu items, see MenuItems
+//   - chose: Pointer to the selected menu option
+//
+// Returns:
+//   - bool: true if the menu is being left and the caller should return nil
+func LeaveMenu(keys []string, chose *string) bool {
+	if !MainMenuRequested() || len(keys) == 0 {
+		return false
+	}
+
+	*chose = keys[len(keys)-1]
+
+	return true
+}
+
+// stdinChunk is the result of one read of the standard input.
+type stdinChunk struct {
+	data []byte
+	err  error
+}
+
+// shortcutReader wraps the standard input read by the prompts and turns the main menu
+// shortcut into Ctrl+C. It is the only reader of the standard input: a single goroutine
+// at a time reads it and hands the keys to the next caller, so a read that is given up,
+// such as a Pause that timed out, does not keep consuming the keys meant for a prompt.
+type shortcutReader struct {
+	// mu guards line, reading and pending
+	mu sync.Mutex
+
+	// line holds the text typed since the last Enter, to recognize MainMenuCommand
+	line []byte
+
+	// reading is set while a goroutine reads the standard input into chunks
+	reading bool
+
+	// chunks receives the result of the read in progress
+	chunks chan stdinChunk
+
+	// pending holds the part of a read that did not fit into the caller's buffer yet
+	pending *stdinChunk
+}
+
+// Read reads the next keys from the standard input. While a jump to the main menu is
+// pending, Ctrl+C is returned without reading so the prompt is interrupted at once.
+//
+// A read of exactly one Esc byte is the Esc key; escape sequences such as the arrow keys
+// arrive as a single read of several bytes. The shortcut is ignored while the idle lock
+// asks the password, so the lock cannot be skipped.
+//
+// Parameters:
+//   - p: The buffer to read into
+//
+// Returns:
+//   - int: The number of bytes read
+//   - error: Any error of the standard input
+func (r *shortcutReader) Read(p []byte) (int, error) {
+	return r.read(p, nil)
+}
+
+// read is Read with a cancellation. Every key counts as activity for the idle lock.
+// While the screen is locked the keys are not passed on: they wake the screen, the
+// password of the session is asked and the keys are dropped, so a key pressed on the
+// locked screen cannot confirm the prompt that was open.
+//
+// Parameters:
+//   - p: The buffer to read into
+//   - cancel: Fires when the caller stops waiting for keys, nil to wait until they arrive
+//
+// Returns:
+//   - int: The number of bytes read
+//   - error: errReadCancelled when cancel fired first, or any error of the standard input
+func (r *shortcutReader) read(p []byte, cancel <-chan time.Time) (int, error) {
+	for {
+		if MainMenuRequested() && len(p) > 0 && !screenUnlocking() {
+			p[0] = interruptKey
+			return 1, nil
+		}
+
+		n, err := r.next(p, cancel)
+		if n == 0 || screenUnlocking() {
+			return n, err
+		}
+
+		if screenLocked() {
+			r.mu.Lock()
+			r.pending = nil
+			r.mu.Unlock()
+
+			waitUnlock()
+			continue
+		}
+
+		Touch()
+
+		return r.filter(p, n, err)
+	}
+}
+
+// next copies the keys of the next read of the standard input into p, starting the
+// read when none is in progress. Keys that do not fit stay pending for the next call.
+//
+// Parameters:
+//   - p: The buffer to read into
+//   - cancel: Fires when the caller stops waiting, the read then stays in progress
+//
+// Returns:
+//   - int: The number of bytes copied
+//   - error: errReadCancelled when cancel fired first, or the error of the read once all
+//     of its keys were copied
+func (r *shortcutReader) next(p []byte, cancel <-chan time.Time) (int, error) {
+	r.mu.Lock()
+	if r.pending == nil {
+		if !r.reading {
+			r.reading = true
+			go r.pump()
+		}
+		r.mu.Unlock()
+
+		select {
+		case chunk := <-r.chunks:
+			r.mu.Lock()
+			r.reading = false
+			r.pending = &chunk
+		case <-cancel:
+			return 0, errReadCancelled
+		}
+	}
+	defer r.mu.Unlock()
+
+	n := copy(p, r.pending.data)
+	r.pending.data = r.pending.data[n:]
+	if len(r.pending.data) > 0 {
+		return n, nil
+	}
+
+	err := r.pending.err
+	r.pending = nil
+
+	return n, err
+}
+
+// pump reads the standard input once and hands the result to chunks.
+func (r *shortcutReader) pump() {
+	buf := make([]byte, 1024)
+	n, err := os.Stdin.Read(buf)
+	r.chunks <- stdinChunk{data: buf[:n], err: err}
+}
+
+// filter recognizes the main menu shortcut in the keys that were read.
+//
+// Parameters:
+//   - p: The buffer holding the keys
+//   - n: The number of keys in the buffer
+//   - err: The error of the read, returned unchanged
+//
+// Returns:
+//   - int: The number of bytes passed on, up to and including a shortcut
+//   - error: The error of the read
+func (r *shortcutReader) filter(p []byte, n int, err error) (int, error) {
+	if n == 1 && p[0] == escKey {
+		RequestMainMenu()
+		p[0] = interruptKey
+		return 1, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		switch b := p[i]; {
+		case b == '\r' || b == '\n':
+			if string(r.line) == MainMenuCommand {
+				r.line = r.line[:0]
+				RequestMainMenu()
+				p[i] = interruptKey
+				return i + 1, err
+			}
+			r.line = r.line[:0]
+		case b == 0x7f || b == 0x08:
+			if len(r.line) > 0 {
+				r.line = r.line[:len(r.line)-1]
+			}
+		case b == escKey:
+			// The rest of an escape sequence moves the cursor, so the typed text is unknown
+			r.line = r.line[:0]
+			return n, err
+		case b < 0x20:
+			r.line = r.line[:0]
+		default:
+			r.line = append(r.line, b)
+		}
+	}
+
+	return n, err
+}
+
+// Close leaves the standard input open, it is shared by every prompt.
+//
+// Returns:
+//   - error: Always nil
+func (r *shortcutReader) Close() error {
+	return nil
+}
+
+// screenUnlocking reports whether the idle lock is asking the password.
+//
+// Returns:
+//   - bool: true while the password prompt of the idle lock is open
+func screenUnlocking() bool {
+	lockMu.Lock()
+	defer lockMu.Unlock()
+
+	return unlocking
+}
+
+// screenLocked reports whether the idle lock blanked the screen and waits for the password.
+//
+// Returns:
+//   - bool: true while the screen is locked
+func screenLocked() bool {
+	lockMu.Lock()
+	defer lockMu.Unlock()
+
+	return locked
+}
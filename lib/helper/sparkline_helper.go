@@ -0,0 +1,68 @@
+package helper
+
+import "time"
+
+// sparkTicks are the block characters used to draw a sparkline, from lowest to highest.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders a compact one-line chart of the given values.
+// Every value is mapped to one of eight block characters proportionally to the
+// largest value, so the shape of a series can be seen at a glance in the terminal.
+//
+// Parameters:
+//   - values: The series to draw, one character per value
+//
+// Returns:
+//   - string: The sparkline, using the lowest block for every value when all are zero
+func Sparkline(values []int) string {
+	highest := 0
+	for _, value := range values {
+		if value > highest {
+			highest = value
+		}
+	}
+
+	line := make([]rune, len(values))
+	for i, value := range values {
+		if highest == 0 {
+			line[i] = sparkTicks[0]
+			continue
+		}
+
+		line[i] = sparkTicks[value*(len(sparkTicks)-1)/highest]
+	}
+
+	return string(line)
+}
+
+// DailyCounts counts how many timestamps fall on each of the last days calendar days.
+// The result is ordered from the oldest day to today, so it can be passed directly
+// to Sparkline. Timestamps outside the window (or zero timestamps) are ignored.
+//
+// Parameters:
+//   - times: The timestamps to count
+//   - days: The number of days in the window, including today
+//   - now: The reference time that defines "today"
+//
+// Returns:
+//   - []int: The number of timestamps per day, oldest first
+func DailyCounts(times []time.Time, days int, now time.Time) []int {
+	counts := make([]int, days)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, t := range times {
+		if t.IsZero() {
+			continue
+		}
+
+		local := t.In(now.Location())
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, now.Location())
+		ago := int(today.Sub(day).Hours() / 24)
+
+		if ago >= 0 && ago < days {
+			counts[days-1-ago]++
+		}
+	}
+
+	return counts
+}
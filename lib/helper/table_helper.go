@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// NewTable creates a table that renders to the terminal in the style chosen in the
+// settings, see TableStyle. Every table on screen is created here so the style is the
+// same everywhere.
+//
+// Returns:
+//   - table.Writer: The new table
+func NewTable() table.Writer {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(TableStyle())
+
+	return t
+}
+
+// TableStyle returns the table style of the TABLE_STYLE setting:
+//   - warna: Colored with borders, the default
+//   - kompak: Thin lines without padding, so wide tables fit in a small terminal
+//   - polos: No borders or column lines, only a line under the header
+//
+// Returns:
+//   - table.Style: The style to render tables with
+func TableStyle() table.Style {
+	switch GetEnv("TABLE_STYLE", "warna") {
+	case "kompak":
+		style := table.StyleLight
+		style.Box.PaddingLeft = ""
+		style.Box.PaddingRight = ""
+		return style
+	case "polos":
+		style := table.StyleDefault
+		style.Options.DrawBorder = false
+		style.Options.SeparateColumns = false
+		return style
+	}
+
+	return table.StyleColoredBright
+}
@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Version, Commit and BuildDate describe the build. They are set at build time with
+// ldflags, for example:
+//
+//	go build -ldflags "-X tugas-besar/lib/helper.Version=1.2.0 -X tugas-besar/lib/helper.Commit=$(git rev-parse --short HEAD) -X tugas-besar/lib/helper.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// BuildInfo describes the running build in one line, so bug reports can state which
+// build was used. When the commit or build date were not set with ldflags, the VCS
+// information that the Go toolchain embeds in the binary is used instead.
+//
+// Returns:
+//   - string: The version, commit, build date and Go version
+func BuildInfo() string {
+	commit, date := Commit, BuildDate
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && commit == "":
+				commit = setting.Value
+				if len(commit) > 7 {
+					commit = commit[:7]
+				}
+			case setting.Key == "vcs.time" && date == "":
+				date = setting.Value
+			case setting.Key == "vcs.modified" && setting.Value == "true" && Commit == "":
+				commit += "-dirty"
+			}
+		}
+	}
+
+	if commit == "" || commit == "-dirty" {
+		commit = "unknown"
+	}
+
+	if date == "" {
+		date = "unknown"
+	}
+
+	return fmt.Sprintf("%s (commit %s, dibangun %s, %s)", Version, commit, date, runtime.Version())
+}
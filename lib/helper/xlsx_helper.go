@@ -0,0 +1,162 @@
+package helper
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Sheet is one worksheet of an XLSX workbook.
+type Sheet struct {
+	// Name is the name of the worksheet tab.
+	Name string
+
+	// Header holds the column names written as the first row.
+	Header []string
+
+	// Rows holds the data rows written after the header.
+	Rows [][]string
+}
+
+// WriteXLSX writes worksheets to an XLSX (Office Open XML) workbook.
+// Every cell is written as an inline string, which is enough for exports that are
+// opened in a spreadsheet program, without depending on a spreadsheet library.
+// The file is created if it does not exist and truncated if it does.
+//
+// Parameters:
+//   - path: The location of the XLSX file to write
+//   - sheets: The worksheets in tab order
+//
+// Returns:
+//   - error: An error if the file cannot be created or written, nil on success
+func WriteXLSX(path string, sheets []Sheet) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	var overrides, entries, relations strings.Builder
+	for i, sheet := range sheets {
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&entries, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(sheet.Name), i+1, i+1)
+		fmt.Fprintf(&relations, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + entries.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			relations.String() + `</Relationships>`},
+	}
+
+	for _, part := range parts {
+		err = writeZipEntry(archive, part.name, part.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, sheet := range sheets {
+		err = writeZipEntry(archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(sheet))
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// worksheetXML renders a worksheet with the header as the first row.
+//
+// Parameters:
+//   - sheet: The worksheet to render
+//
+// Returns:
+//   - string: The XML of the worksheet part
+func worksheetXML(sheet Sheet) string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	rows := append([][]string{sheet.Header}, sheet.Rows...)
+	for r, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, value := range row {
+			fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, columnName(c), r+1, escapeXML(value))
+		}
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return b.String()
+}
+
+// columnName converts a zero-based column index to its spreadsheet letters (0 = A, 26 = AA).
+//
+// Parameters:
+//   - index: The zero-based column index
+//
+// Returns:
+//   - string: The column letters
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+// escapeXML escapes a value for use in XML text or attributes.
+//
+// Parameters:
+//   - value: The raw text
+//
+// Returns:
+//   - string: The escaped text
+func escapeXML(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+
+	return b.String()
+}
+
+// writeZipEntry adds a file with the given content to a zip archive.
+//
+// Parameters:
+//   - archive: The zip archive being written
+//   - name: The path of the file inside the archive
+//   - content: The content of the file
+//
+// Returns:
+//   - error: An error if the entry cannot be written
+func writeZipEntry(archive *zip.Writer, name, content string) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(entry, content)
+
+	return err
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry represents a single change made by an admin to a user or a comment.
+// Unlike the journal, which records how to rebuild the data, the audit log records who
+// made the change and what the data looked like before and after it.
+type AuditEntry struct {
+	// At is the time of the change.
+	At time.Time `json:"at"`
+
+	// Actor is the username of the admin account that made the change.
+	Actor string `json:"actor"`
+
+	// Role is the role the admin was logged in with, "admin" or "moderator".
+	Role string `json:"role"`
+
+	// Action names the change, e.g. "create_user", "edit_comment" or "delete_comment".
+	Action string `json:"action"`
+
+	// TargetId is the ID of the user or comment that was changed.
+	TargetId int `json:"target_id"`
+
+	// Old is the JSON encoded value before the change, empty for a create.
+	Old json.RawMessage `json:"old,omitempty"`
+
+	// New is the JSON encoded value after the change, empty for a delete.
+	New json.RawMessage `json:"new,omitempty"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Bookmark represents a comment a user added to their personal favorites list.
+type Bookmark struct {
+	// UserId is the ID of the user the favorite belongs to, 0 for the admin.
+	UserId int `json:"user_id"`
+
+	// CommentId is the ID of the bookmarked comment.
+	CommentId int `json:"comment_id"`
+
+	// CreatedAt is the time the comment was bookmarked.
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,11 +1,17 @@
 package model
 
+import "time"
+
 // Comment represents a user entity in the system.
 // It contains basic identification and authentication information.
 type Comment struct {
 	// Id is the unique identifier for the comment.
 	Id int `json:"id"`
 
+	// Uuid is a globally unique identifier, set only when UUIDs are enabled.
+	// Unlike Id it does not collide when datasets from several machines are merged.
+	Uuid string `json:"uuid,omitempty"`
+
 	// UserId is the unique identifier for the user who made the comment.
 	UserId int `json:"user_id"`
 
@@ -14,4 +20,62 @@ type Comment struct {
 
 	// Kategori is the category or topic of the comment.
 	Kategori string `json:"kategori"`
+
+	// Kategori2 is the category assigned independently by a second labeler, empty until
+	// the comment has been labeled twice. It is used to measure label reliability.
+	Kategori2 string `json:"kategori2,omitempty"`
+
+	// Pelabel2 is the ID of the user who assigned Kategori2.
+	Pelabel2 int `json:"pelabel2,omitempty"`
+
+	// Review is "ragu" while the category is uncertain and queued for review, and
+	// "ditinjau" once an admin has confirmed or corrected it. Empty when never flagged.
+	Review string `json:"review,omitempty"`
+
+	// Status is the processing status of the comment: "Baru", "Ditinjau" or "Selesai".
+	// Empty for comments that were never processed, which counts as "Baru".
+	Status string `json:"status,omitempty"`
+
+	// TindakLanjut is the follow-up status of a negative comment that needs action:
+	// "Open", "In Progress" or "Closed". Empty when it was never escalated.
+	TindakLanjut string `json:"tindak_lanjut,omitempty"`
+
+	// Assignee is the username of the person handling the follow-up, empty if unassigned.
+	Assignee string `json:"assignee,omitempty"`
+
+	// Catatan is an internal response or note of the admins. It is not shown to users and
+	// is only exported when requested.
+	Catatan string `json:"catatan,omitempty"`
+
+	// ProjectId is the ID of the project the comment belongs to, 0 if it belongs to none.
+	ProjectId int `json:"project_id,omitempty"`
+
+	// Sumber is the origin of the comment (e.g. "twitter", "survey"), empty for comments entered in the app.
+	Sumber string `json:"sumber,omitempty"`
+
+	// ParentId is the ID of the comment this comment replies to, 0 for a comment that
+	// starts a discussion.
+	ParentId int `json:"parent_id,omitempty"`
+
+	// CreatedAt is the time the comment was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the time the text or category of the comment was last edited.
+	// It equals CreatedAt for comments that were never edited.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Version starts at 1 and is incremented on every edit.
+	// Edits must pass the version they read so concurrent changes are detected.
+	Version int `json:"version,omitempty"`
+}
+
+// SearchResult represents a comment found by a ranked search.
+type SearchResult struct {
+	// Comment is the matching comment.
+	Comment Comment `json:"comment"`
+
+	// Relevance is the match quality, higher is better. Every search word adds 3 for an
+	// exact word, 2 for the start of a word and 1 for a match inside a word, averaged over
+	// the search words, plus a recency boost between 0 and 1.
+	Relevance float64 `json:"relevance"`
 }
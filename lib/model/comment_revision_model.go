@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// CommentRevision represents a previous version of a comment.
+// A revision is recorded every time a comment is edited, capturing the
+// text and category the comment had before the edit was applied.
+type CommentRevision struct {
+	// CommentId is the ID of the comment this revision belongs to.
+	CommentId int `json:"comment_id"`
+
+	// Komentar is the text content of the comment before the edit.
+	Komentar string `json:"komentar"`
+
+	// Kategori is the category of the comment before the edit.
+	Kategori string `json:"kategori"`
+
+	// EditedAt is the time the edit that replaced this revision was made.
+	EditedAt time.Time `json:"edited_at"`
+}
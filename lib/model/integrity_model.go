@@ -0,0 +1,29 @@
+package model
+
+// IntegrityCheck represents the outcome of a single data integrity check.
+// A check passes when it has no issues.
+type IntegrityCheck struct {
+	// Name is the human readable name of the check.
+	Name string
+
+	// Issues describes every inconsistency the check found.
+	Issues []string
+
+	// Repairable reports whether the issues can be fixed automatically.
+	Repairable bool
+}
+
+// CompactionResult represents the outcome of replacing the journal by a snapshot.
+type CompactionResult struct {
+	// EntriesBefore is the number of journal entries before compaction.
+	EntriesBefore int
+
+	// EntriesAfter is the number of snapshot entries, one per live record.
+	EntriesAfter int
+
+	// BytesBefore is the size of the journal entries before compaction.
+	BytesBefore int64
+
+	// BytesAfter is the size of the snapshot entries.
+	BytesAfter int64
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// JournalEntry represents a single mutation recorded in the operation journal.
+// Each entry stores the command name together with the arguments that were passed
+// to the repository, so the whole store can be rebuilt by replaying the entries in order.
+type JournalEntry struct {
+	// Command is the name of the repository operation (e.g. "create_comment", "delete_user").
+	Command string `json:"command"`
+
+	// Index is the array index used by index-based user operations.
+	Index int `json:"index,omitempty"`
+
+	// Id is the ID of the record targeted by edit and delete operations.
+	Id int `json:"id,omitempty"`
+
+	// UserId is the owner ID passed to comment operations.
+	UserId int `json:"user_id,omitempty"`
+
+	// User holds the user data passed to user create and edit operations.
+	User *User `json:"user,omitempty"`
+
+	// Comment holds the comment data passed to comment create and edit operations.
+	Comment *Comment `json:"comment,omitempty"`
+
+	// Project holds the project data passed to project create operations.
+	Project *Project `json:"project,omitempty"`
+
+	// Scheduled holds the queued comment passed to schedule operations.
+	Scheduled *ScheduledComment `json:"scheduled,omitempty"`
+
+	// Template holds the template data passed to template create operations.
+	Template *Template `json:"template,omitempty"`
+
+	// Login holds the login attempt passed to login record operations.
+	Login *LoginAttempt `json:"login,omitempty"`
+
+	// Revision holds the comment revision restored by a journal snapshot.
+	Revision *CommentRevision `json:"revision,omitempty"`
+
+	// Bookmark holds the bookmark restored by a journal snapshot.
+	Bookmark *Bookmark `json:"bookmark,omitempty"`
+
+	// Timestamp is the time the mutation was recorded.
+	Timestamp time.Time `json:"timestamp"`
+}
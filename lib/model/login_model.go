@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// LoginAttempt represents a single successful or failed login of a user account.
+type LoginAttempt struct {
+	// Username is the username that was entered, also kept for unknown usernames.
+	Username string `json:"username"`
+
+	// UserId is the ID of the account, 0 when the username does not exist.
+	UserId int `json:"user_id,omitempty"`
+
+	// Success reports whether the password matched.
+	Success bool `json:"success"`
+
+	// At is the time of the attempt.
+	At time.Time `json:"at"`
+}
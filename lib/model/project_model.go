@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Project represents a group of comments, e.g. a product being reviewed.
+type Project struct {
+	// Id is the unique identifier for the project.
+	Id int `json:"id"`
+
+	// Nama is the name of the project.
+	Nama string `json:"nama"`
+
+	// CreatedAt is the time the project was created.
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// ScheduledComment represents a comment that is queued to appear at a future time.
+type ScheduledComment struct {
+	// Id is the unique identifier for the queued comment.
+	Id int `json:"id"`
+
+	// UserId is the unique identifier for the user who wrote the comment.
+	UserId int `json:"user_id"`
+
+	// Comment holds the text, category and project of the comment.
+	Comment Comment `json:"comment"`
+
+	// PublishAt is the time the comment is moved into the visible store.
+	PublishAt time.Time `json:"publish_at"`
+}
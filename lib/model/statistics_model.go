@@ -0,0 +1,24 @@
+package model
+
+// LengthStats represents the length statistics of the comments of one category.
+// Lengths are counted in characters.
+type LengthStats struct {
+	// Kategori is the category of the comments, "Semua" for every comment.
+	Kategori string `json:"kategori"`
+
+	// Count is the number of comments.
+	Count int `json:"count"`
+
+	// Average is the mean length of the comments, 0 when there are none.
+	Average float64 `json:"average"`
+
+	// Median is the middle length of the comments, the mean of the two middle lengths
+	// for an even count and 0 when there are none.
+	Median float64 `json:"median"`
+
+	// Shortest is the length of the shortest comment.
+	Shortest int `json:"shortest"`
+
+	// Longest is the length of the longest comment.
+	Longest int `json:"longest"`
+}
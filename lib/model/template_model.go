@@ -0,0 +1,17 @@
+package model
+
+// Template represents a reusable comment text, e.g. a survey prompt, that can be
+// selected while entering a comment.
+type Template struct {
+	// Id is the unique identifier for the template.
+	Id int `json:"id"`
+
+	// Nama is the short name shown in the template selection.
+	Nama string `json:"nama"`
+
+	// Komentar is the text the comment input is prefilled with.
+	Komentar string `json:"komentar"`
+
+	// Kategori is the category preselected for the comment, empty for none.
+	Kategori string `json:"kategori,omitempty"`
+}
@@ -1,15 +1,40 @@
 package model
 
+import "time"
+
 // User represents a user entity in the system.
 // It contains basic identification and authentication information.
 type User struct {
 	// Id is the unique identifier for the user.
 	Id int `json:"id"`
 
+	// Uuid is a globally unique identifier, set only when UUIDs are enabled.
+	// Unlike Id it does not collide when datasets from several machines are merged.
+	Uuid string `json:"uuid,omitempty"`
+
 	// Username is the unique name used by the user to log in.
 	Username string `json:"username"`
 
-	// Password is the user's authentication credential.
-	// Note: In a production system, this should be stored as a hash, not plaintext.
+	// Password is the bcrypt hash of the user's password. Accounts created before hashing
+	// was introduced still hold the plaintext password until the user's next login.
 	Password string `json:"password"`
+
+	// MustChangePassword is set on accounts whose password was handed out by the admin or
+	// the user import. The user has to choose a new password before the user menu appears.
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+
+	// Role is the admin role of the account, "admin" or "moderator", or empty for a regular
+	// user. Accounts with a role log in to the admin menu with their own password.
+	Role string `json:"role,omitempty"`
+
+	// CreatedAt is the time the user registered.
+	CreatedAt time.Time `json:"created_at"`
+
+	// DeletedAt is set when the admin deleted the user. Until the grace period has passed
+	// the user is hidden and cannot log in, but can still be restored.
+	DeletedAt time.Time `json:"deleted_at"`
+
+	// Version starts at 1 and is incremented on every edit.
+	// Edits must pass the version they read so concurrent changes are detected.
+	Version int `json:"version,omitempty"`
 }
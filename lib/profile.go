@@ -0,0 +1,45 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"tugas-besar/lib/helper"
+)
+
+// Profile starts profiling when it is requested, before the menus or a subcommand run.
+//
+// Profiling is enabled with a leading --pprof flag, which serves the pprof endpoint on
+// localhost:6060 and records profiles to the directory "profile", or with the environment
+// variables PPROF_ADDR and PPROF_DIR, which set the address and the directory separately.
+// The environment variables take precedence over the defaults of the flag.
+//
+// Parameters:
+//   - args: The command-line arguments after the program name
+//
+// Returns:
+//   - []string: The arguments without the --pprof flag
+//   - func(): The function that writes the profiles, it must be called before the program exits
+func Profile(args []string) ([]string, func()) {
+	// A missing .env file is not an error here, Bootstrap reports it later
+	godotenv.Load()
+
+	addr := helper.GetEnv("PPROF_ADDR", "")
+	dir := helper.GetEnv("PPROF_DIR", "")
+
+	if len(args) > 0 && args[0] == "--pprof" {
+		args = args[1:]
+		addr = helper.GetEnv("PPROF_ADDR", "localhost:6060")
+		dir = helper.GetEnv("PPROF_DIR", "profile")
+	}
+
+	stop, err := helper.StartProfiling(addr, dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pprof: %v\n", err)
+		return args, func() {}
+	}
+
+	return args, stop
+}
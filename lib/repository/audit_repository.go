@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"tugas-besar/lib/model"
+)
+
+// auditRepository implements the AuditRepository interface using an append-only
+// JSON Lines file on disk, separate from the journal so compacting or replaying the
+// journal never rewrites it.
+type auditRepository struct {
+	path string
+
+	// mu serializes the appends of the admin menu and the background jobs
+	mu sync.Mutex
+}
+
+// AuditRepository defines the interface for the audit log of admin actions.
+// Entries can only be appended; there is no way to change or remove them.
+type AuditRepository interface {
+	// Append writes a single audit entry to the end of the audit log.
+	Append(entry model.AuditEntry) error
+
+	// GetAllEntries reads every entry of the audit log, oldest first.
+	GetAllEntries() ([]model.AuditEntry, error)
+}
+
+// NewAuditRepository creates and returns a new AuditRepository implementation.
+//
+// Parameters:
+//   - path: The location of the JSON Lines audit log file
+//
+// Returns:
+//   - AuditRepository: A new instance of the auditRepository implementation
+func NewAuditRepository(path string) AuditRepository {
+	return &auditRepository{path: path}
+}
+
+// Append writes a single audit entry as one JSON line to the end of the audit log.
+// The file is created if it does not exist yet.
+//
+// Parameters:
+//   - entry: The audit entry describing the change
+//
+// Returns:
+//   - error: An error if the file cannot be opened or written, nil on success
+func (a *auditRepository) Append(entry model.AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %v", err)
+	}
+
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %v", err)
+	}
+
+	return nil
+}
+
+// GetAllEntries reads every entry of the audit log file.
+// A missing audit log is treated as an empty log.
+//
+// Returns:
+//   - []model.AuditEntry: The audit entries in the order they were recorded
+//   - error: An error if the file cannot be read or an entry cannot be decoded
+func (a *auditRepository) GetAllEntries() ([]model.AuditEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var entries []model.AuditEntry
+
+	file, err := os.Open(a.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry model.AuditEntry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode audit entry on line %d: %v", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
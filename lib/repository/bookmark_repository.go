@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// bookmarkRepository implements the BookmarkRepository interface using an in-memory
+// storage mechanism for the favorite comments of every user.
+type bookmarkRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+}
+
+// BookmarkRepository defines the interface for favorite comment data operations.
+type BookmarkRepository interface {
+	// GetBookmarksByUserId retrieves the bookmarks of a user, packed from index 0,
+	// most recently bookmarked first. It returns the number of bookmarks.
+	GetBookmarksByUserId(userId int, bookmarks *[255]model.Bookmark) (int, error)
+
+	// IsBookmarked reports whether a user bookmarked a comment.
+	IsBookmarked(userId int, commentId int) bool
+
+	// Add bookmarks a comment for a user.
+	// Returns an error if the comment is already bookmarked or the storage is full.
+	Add(userId int, commentId int) error
+
+	// Remove removes a bookmark of a user.
+	Remove(userId int, commentId int) error
+}
+
+// NewBookmarkRepository creates and returns a new BookmarkRepository implementation.
+//
+// Parameters:
+//   - journal: The journal every mutation is recorded to, may be nil
+//
+// Returns:
+//   - BookmarkRepository: A new instance of the bookmarkRepository implementation
+func NewBookmarkRepository(journal JournalRepository) BookmarkRepository {
+	return &bookmarkRepository{
+		journal: journal,
+	}
+}
+
+// GetBookmarksByUserId copies the bookmarks of a user into the provided array, packed
+// from index 0, with the most recently bookmarked comment first.
+//
+// Parameters:
+//   - userId: The ID of the user, 0 for the admin
+//   - bookmarks: A pointer to an array that will be filled with the bookmarks
+//
+// Returns:
+//   - int: The number of bookmarks of the user
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (b *bookmarkRepository) GetBookmarksByUserId(userId int, bookmarks *[255]model.Bookmark) (int, error) {
+	defer readStore()()
+
+	n := 0
+	for i := global.BookmarkCount - 1; i >= 0; i-- {
+		if global.Bookmarks[i].UserId == userId {
+			(*bookmarks)[n] = global.Bookmarks[i]
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// IsBookmarked reports whether a user bookmarked a comment.
+//
+// Parameters:
+//   - userId: The ID of the user, 0 for the admin
+//   - commentId: The ID of the comment
+//
+// Returns:
+//   - bool: true if the comment is in the favorites of the user
+func (b *bookmarkRepository) IsBookmarked(userId int, commentId int) bool {
+	defer readStore()()
+
+	return isBookmarked(userId, commentId)
+}
+
+// isBookmarked is IsBookmarked for callers that already hold the store lock.
+//
+// Parameters:
+//   - userId: The ID of the user, 0 for the admin
+//   - commentId: The ID of the comment
+//
+// Returns:
+//   - bool: true if the comment is in the favorites of the user
+func isBookmarked(userId int, commentId int) bool {
+	for i := 0; i < global.BookmarkCount; i++ {
+		if global.Bookmarks[i].UserId == userId && global.Bookmarks[i].CommentId == commentId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add stores a new bookmark at the end of the storage.
+//
+// Parameters:
+//   - userId: The ID of the user, 0 for the admin
+//   - commentId: The ID of the comment to bookmark
+//
+// Returns:
+//   - error: An error if the comment is already bookmarked, the storage is full or the
+//     mutation cannot be written to the journal, nil otherwise
+func (b *bookmarkRepository) Add(userId int, commentId int) error {
+	defer lockStore()()
+
+	if isBookmarked(userId, commentId) {
+		return fmt.Errorf("comment %d is already bookmarked", commentId)
+	}
+
+	if global.BookmarkCount >= len(global.Bookmarks) {
+		return fmt.Errorf("bookmark storage is full (%d bookmarks)", len(global.Bookmarks))
+	}
+
+	global.Bookmarks[global.BookmarkCount] = model.Bookmark{
+		UserId:    userId,
+		CommentId: commentId,
+		CreatedAt: time.Now(),
+	}
+	global.BookmarkCount++
+
+	return record(b.journal, model.JournalEntry{
+		Command: "add_bookmark",
+		Id:      commentId,
+		UserId:  userId,
+	})
+}
+
+// Remove removes a bookmark by shifting all subsequent bookmarks up by one position
+// in the array and decrementing the bookmark count.
+//
+// Parameters:
+//   - userId: The ID of the user, 0 for the admin
+//   - commentId: The ID of the bookmarked comment
+//
+// Returns:
+//   - error: An error if the bookmark is not found, nil on success
+func (b *bookmarkRepository) Remove(userId int, commentId int) error {
+	defer lockStore()()
+
+	for i := 0; i < global.BookmarkCount; i++ {
+		if global.Bookmarks[i].UserId == userId && global.Bookmarks[i].CommentId == commentId {
+			for j := i; j < global.BookmarkCount-1; j++ {
+				global.Bookmarks[j] = global.Bookmarks[j+1]
+			}
+			global.BookmarkCount--
+			global.Bookmarks[global.BookmarkCount] = model.Bookmark{}
+
+			return record(b.journal, model.JournalEntry{
+				Command: "remove_bookmark",
+				Id:      commentId,
+				UserId:  userId,
+			})
+		}
+	}
+
+	return fmt.Errorf("comment %d is not bookmarked", commentId)
+}
@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// SortOrder is the direction of a CommentQuery ordering.
+type SortOrder int
+
+const (
+	// Asc orders from the lowest to the highest value
+	Asc SortOrder = iota
+
+	// Desc orders from the highest to the lowest value
+	Desc
+)
+
+// CommentQuery defines a fluent builder for filtering, ordering and paging the comments
+// of the active project, e.g.
+//
+//	repo.Comments().WhereKategori("Negatif").Contains("lambat").OrderBy("created_at", repository.Desc).Limit(20).Find(&comments)
+//
+// Every method except Find returns the same query so the calls can be chained.
+type CommentQuery interface {
+	// WhereKategori keeps only the comments with the given category.
+	WhereKategori(kategori string) CommentQuery
+
+	// WhereUser keeps only the comments written by the given user.
+	WhereUser(userId int) CommentQuery
+
+	// WhereStatus keeps only the comments with the given processing status.
+	WhereStatus(status string) CommentQuery
+
+	// WhereSumber keeps only the comments from the given source, "" for comments entered in the app.
+	WhereSumber(sumber string) CommentQuery
+
+	// WhereProject keeps only the comments of the given project instead of the active project.
+	WhereProject(projectId int) CommentQuery
+
+	// CreatedBetween keeps only the comments created from from up to, but not including, to.
+	CreatedBetween(from, to time.Time) CommentQuery
+
+	// Contains keeps only the comments whose text contains the given text, ignoring case.
+	Contains(text string) CommentQuery
+
+	// OrderBy orders the result by "id", "created_at", "komentar" or "kategori".
+	OrderBy(field string, order SortOrder) CommentQuery
+
+	// Offset skips the first n matching comments.
+	Offset(n int) CommentQuery
+
+	// Limit returns at most n comments.
+	Limit(n int) CommentQuery
+
+	// Find runs the query, fills the provided array from index 0 and returns the number of comments found.
+	Find(comments *[255]model.Comment) (int, error)
+
+	// LengthStats runs the query and aggregates the length of the matching comments per category.
+	LengthStats() ([]model.LengthStats, error)
+}
+
+// commentQuery implements the CommentQuery interface on the in-memory comment storage.
+type commentQuery struct {
+	kategori  string
+	status    string
+	userId    int
+	byUser    bool
+	sumber    string
+	bySumber  bool
+	project   int
+	byProject bool
+	from      time.Time
+	to        time.Time
+	text      string
+	field     string
+	order     SortOrder
+	offset    int
+	limit     int
+}
+
+// Comments starts a new query on the comments of the active project.
+// Without further calls the query finds every comment in storage order.
+//
+// Returns:
+//   - CommentQuery: The new query
+func (c *commentRepository) Comments() CommentQuery {
+	return &commentQuery{limit: len(global.Comments)}
+}
+
+// WhereKategori keeps only the comments with the given category.
+// An empty category keeps every comment.
+//
+// Parameters:
+//   - kategori: The category to keep (e.g., "Positif", "Netral", "Negatif")
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) WhereKategori(kategori string) CommentQuery {
+	q.kategori = kategori
+	return q
+}
+
+// WhereStatus keeps only the comments with the given processing status, ignoring case.
+// Comments without a status are "Baru". An empty status keeps every comment.
+//
+// Parameters:
+//   - status: The status to keep ("Baru", "Ditinjau" or "Selesai")
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) WhereStatus(status string) CommentQuery {
+	q.status = status
+	return q
+}
+
+// WhereUser keeps only the comments written by the given user. UserId 0 is the admin.
+//
+// Parameters:
+//   - userId: The ID of the author
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) WhereUser(userId int) CommentQuery {
+	q.userId = userId
+	q.byUser = true
+	return q
+}
+
+// WhereSumber keeps only the comments from the given source, ignoring case.
+//
+// Parameters:
+//   - sumber: The source to keep (e.g. "twitter"), "" for the comments entered in the app
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) WhereSumber(sumber string) CommentQuery {
+	q.sumber = sumber
+	q.bySumber = true
+	return q
+}
+
+// WhereProject keeps only the comments of the given project. It replaces the scope of the
+// active project, so the comments of another project can be queried as well.
+//
+// Parameters:
+//   - projectId: The ID of the project, 0 for the comments without a project
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) WhereProject(projectId int) CommentQuery {
+	q.project = projectId
+	q.byProject = true
+	return q
+}
+
+// CreatedBetween keeps only the comments created in a period. A zero time leaves that
+// side of the period open.
+//
+// Parameters:
+//   - from: The start of the period, inclusive
+//   - to: The end of the period, exclusive
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) CreatedBetween(from, to time.Time) CommentQuery {
+	q.from = from
+	q.to = to
+	return q
+}
+
+// Contains keeps only the comments whose text contains the given text, ignoring case.
+// An empty text keeps every comment.
+//
+// Parameters:
+//   - text: The text to search for
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) Contains(text string) CommentQuery {
+	q.text = strings.ToLower(text)
+	return q
+}
+
+// OrderBy orders the result. Comments with an equal value keep their storage order.
+//
+// Parameters:
+//   - field: "id", "created_at", "komentar" or "kategori"
+//   - order: Asc or Desc
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) OrderBy(field string, order SortOrder) CommentQuery {
+	q.field = field
+	q.order = order
+	return q
+}
+
+// Offset skips the first n matching comments, after ordering.
+//
+// Parameters:
+//   - n: The number of comments to skip
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) Offset(n int) CommentQuery {
+	q.offset = n
+	return q
+}
+
+// Limit returns at most n comments. The limit cannot exceed the size of the result array.
+//
+// Parameters:
+//   - n: The maximum number of comments
+//
+// Returns:
+//   - CommentQuery: The same query
+func (q *commentQuery) Limit(n int) CommentQuery {
+	q.limit = n
+	return q
+}
+
+// Find runs the query on the comments of the active project.
+//
+// Parameters:
+//   - comments: A pointer to an array that will be filled with the result, packed from index 0
+//
+// Returns:
+//   - int: The number of comments found
+//   - error: An error if the order field is unknown or the offset or limit is negative
+func (q *commentQuery) Find(comments *[255]model.Comment) (int, error) {
+	defer readStore()()
+
+	if q.offset < 0 || q.limit < 0 {
+		return 0, fmt.Errorf("invalid page: offset %d, limit %d", q.offset, q.limit)
+	}
+
+	var less func(a, b model.Comment) bool
+	switch q.field {
+	case "":
+	case "id":
+		less = func(a, b model.Comment) bool { return a.Id < b.Id }
+	case "created_at":
+		less = func(a, b model.Comment) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "komentar":
+		less = func(a, b model.Comment) bool { return strings.ToLower(a.Komentar) < strings.ToLower(b.Komentar) }
+	case "kategori":
+		less = func(a, b model.Comment) bool { return a.Kategori < b.Kategori }
+	default:
+		return 0, fmt.Errorf("unknown order field %q", q.field)
+	}
+
+	var matches []model.Comment
+	for i := 0; i < global.CommentCount; i++ {
+		comment := global.Comments[i]
+		if q.byProject && comment.ProjectId != q.project {
+			continue
+		}
+
+		if !q.byProject && !inActiveProject(comment) {
+			continue
+		}
+
+		if q.kategori != "" && comment.Kategori != q.kategori {
+			continue
+		}
+
+		if q.status != "" && !strings.EqualFold(StatusOf(comment), q.status) {
+			continue
+		}
+
+		if q.byUser && comment.UserId != q.userId {
+			continue
+		}
+
+		if q.bySumber && !strings.EqualFold(comment.Sumber, q.sumber) {
+			continue
+		}
+
+		if !q.from.IsZero() && comment.CreatedAt.Before(q.from) {
+			continue
+		}
+
+		if !q.to.IsZero() && !comment.CreatedAt.Before(q.to) {
+			continue
+		}
+
+		if q.text != "" && !strings.Contains(strings.ToLower(comment.Komentar), q.text) {
+			continue
+		}
+
+		matches = append(matches, comment)
+	}
+
+	if less != nil {
+		sort.SliceStable(matches, func(i, j int) bool {
+			if q.order == Desc {
+				return less(matches[j], matches[i])
+			}
+
+			return less(matches[i], matches[j])
+		})
+	}
+
+	n := 0
+	for i := q.offset; i < len(matches) && n < min(q.limit, len(comments)); i++ {
+		(*comments)[n] = matches[i]
+		n++
+	}
+
+	return n, nil
+}
+
+// LengthStats runs the query and aggregates the length of the matching comments, in
+// characters, per category. Ordering, offset and limit do not apply to the aggregate.
+//
+// The result lists Positif, Netral and Negatif first, also when they have no comments,
+// followed by any other category in alphabetical order and a final "Semua" entry for
+// every matching comment.
+//
+// Returns:
+//   - []model.LengthStats: The statistics per category and for every comment
+//   - error: An error if the query cannot be run
+func (q *commentQuery) LengthStats() ([]model.LengthStats, error) {
+	var matches [255]model.Comment
+
+	page := *q
+	page.field = ""
+	page.offset = 0
+	page.limit = len(matches)
+
+	n, err := page.Find(&matches)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+	lengths := map[string][]int{}
+	var all []int
+	for i := 0; i < n; i++ {
+		length := utf8.RuneCountInString(strings.TrimSpace(matches[i].Komentar))
+		kategori := matches[i].Kategori
+
+		if _, ok := lengths[kategori]; !ok && kategori != "Positif" && kategori != "Netral" && kategori != "Negatif" {
+			categories = append(categories, kategori)
+		}
+
+		lengths[kategori] = append(lengths[kategori], length)
+		all = append(all, length)
+	}
+	sort.Strings(categories[3:])
+
+	var stats []model.LengthStats
+	for _, kategori := range categories {
+		stats = append(stats, lengthStats(kategori, lengths[kategori]))
+	}
+
+	return append(stats, lengthStats("Semua", all)), nil
+}
+
+// lengthStats computes the length statistics of one group of comments.
+//
+// Parameters:
+//   - kategori: The name of the group
+//   - lengths: The lengths of the comments in the group, sorted in place
+//
+// Returns:
+//   - model.LengthStats: The count, average, median, shortest and longest length
+func lengthStats(kategori string, lengths []int) model.LengthStats {
+	stats := model.LengthStats{Kategori: kategori, Count: len(lengths)}
+	if len(lengths) == 0 {
+		return stats
+	}
+
+	sort.Ints(lengths)
+
+	total := 0
+	for _, length := range lengths {
+		total += length
+	}
+
+	middle := len(lengths) / 2
+	stats.Median = float64(lengths[middle])
+	if len(lengths)%2 == 0 {
+		stats.Median = float64(lengths[middle-1]+lengths[middle]) / 2
+	}
+
+	stats.Average = float64(total) / float64(len(lengths))
+	stats.Shortest = lengths[0]
+	stats.Longest = lengths[len(lengths)-1]
+
+	return stats
+}
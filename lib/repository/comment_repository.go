@@ -2,7 +2,7 @@ package repository
 
 import (
 	"fmt"
-	"strings"
+	"time"
 
 	"tugas-besar/lib/global"
 	"tugas-besar/lib/model"
@@ -11,6 +11,15 @@ import (
 // commentRepository implements the CommentRepository interface using an in-memory
 // storage mechanism for comment data.
 type commentRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+
+	// ids issues the IDs of new comments
+	ids IDGenerator
+
+	// replaying is set while journal entries are applied. The UUIDs recorded in the
+	// journal are kept as they are instead of generating new ones, see assignUUIDs.
+	replaying bool
 }
 
 // CommentRepository defines the interface for comment data operations.
@@ -20,6 +29,30 @@ type CommentRepository interface {
 	// It populates the provided comments array with all comments currently stored in the system.
 	GetAllComments(comments *[255]model.Comment) error
 
+	// CountComments returns the number of comments in the active project, or the number
+	// of all comments when no project is active.
+	CountComments() int
+
+	// GetComments retrieves one page of the comments in the active project, packed from
+	// index 0, and returns the number of comments copied.
+	GetComments(offset int, limit int, comments *[255]model.Comment) (int, error)
+
+	// GetThreads retrieves one page of the comments in the active project in thread order,
+	// each reply following the comment it replies to, together with the reply depth of
+	// every comment, and returns the number of comments copied.
+	GetThreads(offset int, limit int, comments *[255]model.Comment, depths *[255]int) (int, error)
+
+	// RankSearch finds the comments in the active project containing every search word,
+	// packed from index 0 and ordered from the most to the least relevant.
+	RankSearch(search string, results *[255]model.SearchResult) (int, error)
+
+	// Comments starts a fluent query that filters, orders and pages the comments in the active project.
+	Comments() CommentQuery
+
+	// ForEachComment calls fn for every comment in the active project, in storage order,
+	// until fn returns false. Unlike GetAllComments no fixed-size array is filled.
+	ForEachComment(fn func(comment model.Comment) bool) error
+
 	// Create adds a new comment to the repository.
 	// Returns an error if the operation fails, nil otherwise.
 	Create(comment *model.Comment, userId int) error
@@ -39,11 +72,13 @@ type CommentRepository interface {
 	// EditComment updates a comment with the specified ID.
 	// It searches through all comments to find a match with the specified commentId.
 	// Only fields that contain values in the provided comment model will be updated
-	// (empty strings are ignored).
+	// (empty strings are ignored). comment.Version must match the stored version,
+	// otherwise an error wrapping ErrVersionConflict is returned.
 	EditComment(commentId int, comment model.Comment) error
 
 	// EditUserComment updates a comment that belongs to a specific user.
 	// Only allows editing if the comment exists and belongs to the specified user.
+	// comment.Version must match the stored version.
 	EditUserComment(commentId int, userId int, comment model.Comment) error
 
 	// DeleteComment removes a comment with the specified ID from the repository.
@@ -60,24 +95,67 @@ type CommentRepository interface {
 	// It populates the provided comments array with all comments from the specified user.
 	GetCommentByUserId(userId int, comments *[255]model.Comment) error
 
+	// ReassignComments moves every comment owned by one user to another user.
+	// It returns the number of comments that were moved.
+	ReassignComments(fromUserId int, toUserId int) (int, error)
+
+	// SetSecondLabel stores the category assigned to a comment by a second labeler.
+	// Returns an error if the comment is not found, nil otherwise.
+	SetSecondLabel(commentId int, labelerId int, kategori string) error
+
+	// SetReview sets the review status of a comment ("ragu" or "ditinjau").
+	// Returns an error if the comment is not found, nil otherwise.
+	SetReview(commentId int, status string) error
+
+	// SetFollowUp sets the follow-up status and the assignee of a comment.
+	// Returns an error if the comment is not found.
+	SetFollowUp(commentId int, status string, assignee string) error
+
+	// SetNote sets the internal admin note of a comment, an empty note removes it.
+	// Returns an error if the comment is not found.
+	SetNote(commentId int, note string) error
+
+	// SetStatus sets the processing status of a comment.
+	// Returns an error if the comment is not found.
+	SetStatus(commentId int, status string) error
+
 	// GetCommentByKategori retrieves all comments with the specified category.
 	// It iterates through all comments in the global storage and copies those
 	// that match the specified category to the provided array, maintaining
 	// their original index positions.
 	GetCommentByKategori(kategori string, comments *[255]model.Comment) (int, error)
+
+	// FindCommentById retrieves a single comment by its ID.
+	// It populates the provided comment model with data if found.
+	// Returns an error if the comment is not found, nil otherwise.
+	FindCommentById(commentId int, comment *model.Comment) error
+
+	// GetCommentRevisions retrieves the edit history of a comment, oldest first.
+	// It populates the provided array with the previous versions of the comment
+	// and returns how many revisions were found.
+	GetCommentRevisions(commentId int, revisions *[255]model.CommentRevision) (int, error)
 }
 
 // NewCommentRepository creates and returns a new CommentRepository implementation.
 //
+// Parameters:
+//   - journal: The journal that records every mutation, nil disables journaling
+//   - ids: The generator that issues the IDs of new comments
+//
 // Returns:
 //   - CommentRepository: A new instance of the commentRepository implementation
-func NewCommentRepository() CommentRepository {
-	return &commentRepository{}
+func NewCommentRepository(journal JournalRepository, ids IDGenerator) CommentRepository {
+	return &commentRepository{
+		journal: journal,
+		ids:     ids,
+	}
 }
 
 // GetAllComments retrieves all available comments from the repository.
-// It directly assigns the global comment storage to the provided array pointer,
-// which means the caller gets access to all comments currently in the system.
+// When no project is active it directly assigns the global comment storage to the
+// provided array pointer, which means the caller gets access to all comments currently
+// in the system. Otherwise only the comments of the active project are copied, keeping
+// their original index positions, so the other slots are left empty.
 //
 // Parameters:
 //   - comments: A pointer to an array that will be filled with all comments
@@ -85,64 +163,333 @@ func NewCommentRepository() CommentRepository {
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (c *commentRepository) GetAllComments(comments *[255]model.Comment) error {
-	*comments = global.Comments
+	defer readStore()()
+
+	if global.ActiveProjectId == 0 {
+		*comments = global.Comments
+		return nil
+	}
+
+	*comments = [255]model.Comment{}
+	for i := 0; i < global.CommentCount; i++ {
+		if inActiveProject(global.Comments[i]) {
+			(*comments)[i] = global.Comments[i]
+		}
+	}
+
+	return nil
+}
+
+// CountComments returns the number of comments in the active project.
+//
+// Returns:
+//   - int: The number of comments in the active project, or global.CommentCount when
+//     no project is active
+func (c *commentRepository) CountComments() int {
+	defer readStore()()
+
+	count := 0
+	for i := 0; i < global.CommentCount; i++ {
+		if inActiveProject(global.Comments[i]) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// GetComments copies one page of the comments of the active project into the provided
+// array, packed from index 0, so a table or an API response only receives the rows it
+// shows instead of a copy of the whole store.
+//
+// Parameters:
+//   - offset: The number of comments to skip, counted in storage order
+//   - limit: The maximum number of comments to copy, at most the size of the array
+//   - comments: A pointer to an array that will be filled with the page
+//
+// Returns:
+//   - int: The number of comments copied, 0 when offset is past the last comment
+//   - error: An error if offset or limit is negative, nil otherwise
+func (c *commentRepository) GetComments(offset int, limit int, comments *[255]model.Comment) (int, error) {
+	defer readStore()()
+
+	if offset < 0 || limit < 0 {
+		return 0, fmt.Errorf("invalid page: offset %d, limit %d", offset, limit)
+	}
+
+	limit = min(limit, len(comments))
+
+	n := 0
+	skipped := 0
+	for i := 0; i < global.CommentCount && n < limit; i++ {
+		if !inActiveProject(global.Comments[i]) {
+			continue
+		}
+
+		if skipped < offset {
+			skipped++
+			continue
+		}
+
+		(*comments)[n] = global.Comments[i]
+		n++
+	}
+
+	return n, nil
+}
+
+// GetThreads copies one page of the comments of the active project in thread order into
+// the provided arrays, packed from index 0. Like GetComments only the rows of the page are
+// copied; the thread order itself is computed on the storage indices, see threadOrder.
+//
+// Parameters:
+//   - offset: The number of comments to skip, counted in thread order
+//   - limit: The maximum number of comments to copy, at most the size of the array
+//   - comments: A pointer to an array that will be filled with the page
+//   - depths: A pointer to an array that will be filled with the reply depth of every
+//     comment of the page, 0 for the comment that starts a thread
+//
+// Returns:
+//   - int: The number of comments copied, 0 when offset is past the last comment
+//   - error: An error if offset or limit is negative, nil otherwise
+func (c *commentRepository) GetThreads(offset int, limit int, comments *[255]model.Comment, depths *[255]int) (int, error) {
+	defer readStore()()
+
+	if offset < 0 || limit < 0 {
+		return 0, fmt.Errorf("invalid page: offset %d, limit %d", offset, limit)
+	}
+
+	limit = min(limit, len(comments))
+
+	order, levels := threadOrder()
+
+	n := 0
+	for i := offset; i < len(order) && n < limit; i++ {
+		(*comments)[n] = global.Comments[order[i]]
+		(*depths)[n] = levels[i]
+		n++
+	}
+
+	return n, nil
+}
+
+// threadOrder orders the comments of the active project as discussion threads. Every
+// comment that does not reply to another comment of the project starts a thread, in
+// storage order, and is followed by its replies, each reply followed by its own replies.
+// Replies to a deleted comment therefore start a thread of their own.
+//
+// Returns:
+//   - []int: The storage indices of the comments in thread order
+//   - []int: The reply depth of every comment, 0 for the comment that starts a thread
+func threadOrder() ([]int, []int) {
+	known := make(map[int]bool, global.CommentCount)
+	for i := 0; i < global.CommentCount; i++ {
+		if inActiveProject(global.Comments[i]) {
+			known[global.Comments[i].Id] = true
+		}
+	}
+
+	replies := make(map[int][]int)
+	var roots []int
+	for i := 0; i < global.CommentCount; i++ {
+		comment := global.Comments[i]
+		if !inActiveProject(comment) {
+			continue
+		}
+
+		if comment.ParentId != 0 && comment.ParentId != comment.Id && known[comment.ParentId] {
+			replies[comment.ParentId] = append(replies[comment.ParentId], i)
+			continue
+		}
+
+		roots = append(roots, i)
+	}
+
+	order := make([]int, 0, len(known))
+	depths := make([]int, 0, len(known))
+	visited := make(map[int]bool, len(known))
+
+	var walk func(index int, depth int)
+	walk = func(index int, depth int) {
+		if visited[index] {
+			return
+		}
+		visited[index] = true
+
+		order = append(order, index)
+		depths = append(depths, depth)
+		for _, reply := range replies[global.Comments[index].Id] {
+			walk(reply, depth+1)
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, 0)
+	}
+
+	// Comments whose replies form a cycle have no thread to start from
+	for i := 0; i < global.CommentCount; i++ {
+		if inActiveProject(global.Comments[i]) {
+			walk(i, 0)
+		}
+	}
+
+	return order, depths
+}
+
+// ForEachComment streams the comments of the active project to fn, in storage order.
+// The iteration stops early when fn returns false. Reports and exports use it instead of
+// GetAllComments so they do not depend on the size of the storage array, which matters
+// once a backend holds more than 255 comments. fn is called on a copy of the comments,
+// without the store lock, so it may use the repository, see readStore.
+//
+// Parameters:
+//   - fn: The function called with each comment, returning false stops the iteration
+//
+// Returns:
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (c *commentRepository) ForEachComment(fn func(comment model.Comment) bool) error {
+	var comments [255]model.Comment
+	release := readStore()
+	n := projectComments(&comments)
+	release()
+
+	for i := 0; i < n; i++ {
+		if !fn(comments[i]) {
+			break
+		}
+	}
+
 	return nil
 }
 
+// inActiveProject reports whether a comment belongs to the active project.
+// Every comment belongs to it when no project is active.
+//
+// Parameters:
+//   - comment: The comment to check
+//
+// Returns:
+//   - bool: true if the comment is included in the active project's listings
+func inActiveProject(comment model.Comment) bool {
+	return global.ActiveProjectId == 0 || comment.ProjectId == global.ActiveProjectId
+}
+
+// projectComments copies the comments of the active project into the provided array,
+// packed from index 0.
+//
+// Parameters:
+//   - comments: A pointer to an array that will be filled with the comments
+//
+// Returns:
+//   - int: The number of comments copied
+func projectComments(comments *[255]model.Comment) int {
+	n := 0
+	for i := 0; i < global.CommentCount; i++ {
+		if inActiveProject(global.Comments[i]) {
+			(*comments)[n] = global.Comments[i]
+			n++
+		}
+	}
+
+	return n
+}
+
 // Create adds a new comment to the in-memory repository.
-// The comment is assigned the next available index in the global comment storage.
+// The comment is assigned the next available index in the global comment storage and a
+// new ID from the ID generator, which is also written back to comment.Id. When UUIDs are
+// enabled and the comment has no UUID yet, a new one is generated, except while replaying.
+// If the comment has no CreatedAt time yet, the current time is used, and if it has no
+// project yet it is added to the active project. UpdatedAt starts at CreatedAt.
+// A reply keeps the ParentId of the comment it replies to.
 //
 // Parameters:
 //   - comment: A pointer to the Comment model to be stored
 //
 // Returns:
-//   - error: Always returns nil as this implementation doesn't have failure cases
+//   - error: An error if the storage is full or the mutation cannot be written to the journal, nil otherwise
 func (c *commentRepository) Create(comment *model.Comment, userId int) error {
+	defer lockStore()()
+
+	return c.create(comment, userId)
+}
+
+// create adds a new comment like Create, for callers that already hold the store lock,
+// see scheduleRepository.PublishDue.
+//
+// Parameters:
+//   - comment: A pointer to the Comment model to be stored
+//   - userId: The ID of the user who wrote the comment
+//
+// Returns:
+//   - error: An error if the storage is full or the mutation cannot be written to the journal, nil otherwise
+func (c *commentRepository) create(comment *model.Comment, userId int) error {
+	if global.CommentCount >= len(global.Comments) {
+		return fmt.Errorf("comment storage is full (%d comments)", len(global.Comments))
+	}
+
+	createdAt := comment.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	comment.Id = c.ids.NextCommentId()
+	if comment.Uuid == "" && !c.replaying {
+		comment.Uuid = c.ids.NewUUID()
+	}
+	if comment.ProjectId == 0 {
+		comment.ProjectId = global.ActiveProjectId
+	}
+
 	global.Comments[global.CommentCount] = model.Comment{
-		Id:       global.IdCommentIncrement + 1,
-		UserId:   userId,
-		Komentar: comment.Komentar,
-		Kategori: comment.Kategori,
+		Id:        comment.Id,
+		Uuid:      comment.Uuid,
+		UserId:    userId,
+		Komentar:  comment.Komentar,
+		Kategori:  comment.Kategori,
+		ProjectId: comment.ProjectId,
+		Sumber:    comment.Sumber,
+		ParentId:  comment.ParentId,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Version:   1,
 	}
+	commentIndex.add(global.Comments[global.CommentCount])
 	global.CommentCount++
-	global.IdCommentIncrement++
 
-	return nil
+	return record(c.journal, model.JournalEntry{
+		Command: "create_comment",
+		UserId:  userId,
+		Comment: &model.Comment{Uuid: comment.Uuid, Komentar: comment.Komentar, Kategori: comment.Kategori, ProjectId: comment.ProjectId, Sumber: comment.Sumber, ParentId: comment.ParentId, CreatedAt: createdAt},
+	})
 }
 
-// SearchComments searches for comments containing the specified search string.
-// It implements a case-insensitive substring search by converting both the
-// search term and comment text to lowercase before comparison.
+// SearchComments searches for comments containing every word of the specified search string.
+// The words are looked up in the inverted index of the comments instead of scanning the
+// text of every comment. The search ignores case and a search word also matches the words
+// it is a prefix of, so "pelayanan lamb" finds "Pelayanannya lambat sekali". A search without
+// words matches every comment. Only comments in the active project are searched.
 //
-// The method uses a manual substring matching algorithm that checks each position
-// in the comment text as a potential starting point for a match.
+// Matching comments keep their original index positions, so the other slots are left empty.
 //
 // Parameters:
-//   - search: The string to search for within comments
+//   - search: The words to search for within comments
 //   - comments: A pointer to an array that will be filled with matching comments
 //
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (c *commentRepository) SearchComments(search string, comments *[255]model.Comment) error {
-	searchLower := strings.ToLower(search)
-
-	for i := 0; i < global.CommentCount; i++ {
-		commentLower := strings.ToLower(global.Comments[i].Komentar)
+	defer readStore()()
 
-		for j := 0; j <= len(commentLower)-len(searchLower); j++ {
-			isMatch := true
+	matches := commentIndex.match(search)
 
-			for k := 0; k < len(searchLower); k++ {
-				if commentLower[j+k] != searchLower[k] {
-					isMatch = false
-					break
-				}
-			}
+	for i := 0; i < global.CommentCount; i++ {
+		if !inActiveProject(global.Comments[i]) {
+			continue
+		}
 
-			if isMatch {
-				(*comments)[i] = global.Comments[i]
-				break
-			}
+		if matches == nil || matches[global.Comments[i].Id] {
+			(*comments)[i] = global.Comments[i]
 		}
 	}
 
@@ -150,8 +497,8 @@ func (c *commentRepository) SearchComments(search string, comments *[255]model.C
 }
 
 // SortCommentsByComment sorts the comments based on the length of the comment text.
-// It first copies all global comments to the provided array, then sorts them using
-// selection sort algorithm.
+// It first copies the comments of the active project to the provided array, packed from
+// index 0, then sorts them using selection sort algorithm.
 //
 // The function implements a selection sort where:
 // - For mode 0 (ascending): Comments with shorter text appear first
@@ -164,14 +511,14 @@ func (c *commentRepository) SearchComments(search string, comments *[255]model.C
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (c *commentRepository) SortCommentsByComment(comments *[255]model.Comment, mode int) error {
-	for i := 0; i < global.CommentCount; i++ {
-		(*comments)[i] = global.Comments[i]
-	}
+	defer readStore()()
 
-	for i := 0; i < global.CommentCount-1; i++ {
+	n := projectComments(comments)
+
+	for i := 0; i < n-1; i++ {
 		index := i
 
-		for j := i + 1; j < global.CommentCount; j++ {
+		for j := i + 1; j < n; j++ {
 			if mode == 0 { // Ascending
 				if len((*comments)[j].Komentar) < len((*comments)[index].Komentar) {
 					index = j
@@ -192,8 +539,8 @@ func (c *commentRepository) SortCommentsByComment(comments *[255]model.Comment,
 }
 
 // SortCommentsByKategori sorts the comments based on their category value.
-// It first copies all global comments to the provided array, then sorts them using
-// insertion sort algorithm.
+// It first copies the comments of the active project to the provided array, packed from
+// index 0, then sorts them using insertion sort algorithm.
 //
 // The function uses the following category values for sorting:
 // - Positif: 1
@@ -211,9 +558,9 @@ func (c *commentRepository) SortCommentsByComment(comments *[255]model.Comment,
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (c *commentRepository) SortCommentsByKategori(comments *[255]model.Comment, mode int) error {
-	for i := 0; i < global.CommentCount; i++ {
-		(*comments)[i] = global.Comments[i]
-	}
+	defer readStore()()
+
+	n := projectComments(comments)
 
 	getCategoryValue := func(category string) int {
 		switch category {
@@ -228,7 +575,7 @@ func (c *commentRepository) SortCommentsByKategori(comments *[255]model.Comment,
 		}
 	}
 
-	for i := 1; i < global.CommentCount; i++ {
+	for i := 1; i < n; i++ {
 		current := (*comments)[i]
 		currentValue := getCategoryValue(current.Kategori)
 		j := i - 1
@@ -254,19 +601,33 @@ func (c *commentRepository) SortCommentsByKategori(comments *[255]model.Comment,
 // EditUserComment updates a comment that belongs to a specific user.
 // It searches through all comments to find a match with both the specified commentId and userId.
 // Only fields that contain values in the provided data will be updated (empty strings are ignored).
+// The previous version of the comment is stored in the edit history before it is changed,
+// and UpdatedAt is set to the current time.
 //
 // Parameters:
 //   - commentId: The ID of the comment to edit
 //   - userId: The ID of the user who owns the comment
-//   - data: The model.Comment containing fields to update
+//   - data: The model.Comment containing fields to update and the version that was read
 //
 // Returns:
-//   - error: An error if the comment is not found or doesn't belong to the user, nil on success
+//   - error: An error if the comment is not found, doesn't belong to the user or was
+//     modified since it was read, nil on success
 func (c *commentRepository) EditUserComment(commentId int, userId int, data model.Comment) error {
+	defer lockStore()()
+
 	for i := 0; i < global.CommentCount; i++ {
 		if global.Comments[i].Id == commentId && global.Comments[i].UserId == userId {
 			comment := &global.Comments[i]
 
+			err := checkVersion("comment", commentId, data.Version, comment.Version)
+			if err != nil {
+				return err
+			}
+
+			saveRevision(*comment)
+			comment.Version++
+			comment.UpdatedAt = time.Now()
+
 			if data.Komentar != "" {
 				comment.Komentar = data.Komentar
 			}
@@ -274,8 +635,14 @@ func (c *commentRepository) EditUserComment(commentId int, userId int, data mode
 			if data.Kategori != "" {
 				comment.Kategori = data.Kategori
 			}
+			commentIndex.add(*comment)
 
-			return nil
+			return record(c.journal, model.JournalEntry{
+				Command: "edit_user_comment",
+				Id:      commentId,
+				UserId:  userId,
+				Comment: &data,
+			})
 		}
 	}
 
@@ -289,15 +656,29 @@ func (c *commentRepository) EditUserComment(commentId int, userId int, data mode
 // - Komentar field is updated if comment.Komentar is not empty
 // - Kategori field is updated if comment.Kategori is not empty
 //
+// The previous version of the comment is stored in the edit history before it is changed,
+// and UpdatedAt is set to the current time.
+//
 // Parameters:
 //   - commentId: The ID of the comment to edit
-//   - comment: The model.Comment containing fields to update
+//   - comment: The model.Comment containing fields to update and the version that was read
 //
 // Returns:
-//   - error: An error if the comment is not found, nil on success
+//   - error: An error if the comment is not found or was modified since it was read, nil on success
 func (c *commentRepository) EditComment(commentId int, comment model.Comment) error {
+	defer lockStore()()
+
 	for i := 0; i < global.CommentCount; i++ {
 		if global.Comments[i].Id == commentId {
+			err := checkVersion("comment", commentId, comment.Version, global.Comments[i].Version)
+			if err != nil {
+				return err
+			}
+
+			saveRevision(global.Comments[i])
+			global.Comments[i].Version++
+			global.Comments[i].UpdatedAt = time.Now()
+
 			if comment.Komentar != "" {
 				global.Comments[i].Komentar = comment.Komentar
 			}
@@ -305,8 +686,238 @@ func (c *commentRepository) EditComment(commentId int, comment model.Comment) er
 			if comment.Kategori != "" {
 				global.Comments[i].Kategori = comment.Kategori
 			}
+			commentIndex.add(global.Comments[i])
 
-			return nil
+			return record(c.journal, model.JournalEntry{
+				Command: "edit_comment",
+				Id:      commentId,
+				Comment: &comment,
+			})
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+// ReassignComments moves every comment owned by one user to another user.
+// The comments keep their ID, text, category and position in the storage;
+// only their UserId is changed.
+//
+// Parameters:
+//   - fromUserId: The ID of the user whose comments are moved
+//   - toUserId: The ID of the user that becomes the new owner
+//
+// Returns:
+//   - int: The number of comments that were moved
+//   - error: An error if the journal entry cannot be written, nil otherwise
+func (c *commentRepository) ReassignComments(fromUserId int, toUserId int) (int, error) {
+	defer lockStore()()
+
+	moved := 0
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].UserId == fromUserId {
+			global.Comments[i].UserId = toUserId
+			moved++
+		}
+	}
+
+	return moved, record(c.journal, model.JournalEntry{
+		Command: "reassign_comments",
+		Id:      fromUserId,
+		UserId:  toUserId,
+	})
+}
+
+// SetSecondLabel stores the category assigned to a comment by a second labeler.
+// The first category, the version and the edit history of the comment are not changed,
+// because the second label is an independent annotation rather than an edit.
+//
+// Parameters:
+//   - commentId: The ID of the labeled comment
+//   - labelerId: The ID of the user who assigned the label
+//   - kategori: The category assigned by the second labeler
+//
+// Returns:
+//   - error: An error if the comment is not found or the journal entry cannot be written
+func (c *commentRepository) SetSecondLabel(commentId int, labelerId int, kategori string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			global.Comments[i].Kategori2 = kategori
+			global.Comments[i].Pelabel2 = labelerId
+
+			return record(c.journal, model.JournalEntry{
+				Command: "second_label",
+				Id:      commentId,
+				UserId:  labelerId,
+				Comment: &model.Comment{Kategori2: kategori},
+			})
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+// SetReview sets the review status of a comment. Flagging a comment as "ragu" queues
+// it for review; "ditinjau" marks the review as done. Like the second label, the status
+// does not change the version of the comment.
+//
+// Parameters:
+//   - commentId: The ID of the comment
+//   - status: The new review status
+//
+// Returns:
+//   - error: An error if the comment is not found or the journal entry cannot be written
+func (c *commentRepository) SetReview(commentId int, status string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			global.Comments[i].Review = status
+
+			return record(c.journal, model.JournalEntry{
+				Command: "set_review",
+				Id:      commentId,
+				Comment: &model.Comment{Review: status},
+			})
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+// SetFollowUp escalates a comment as "perlu tindak lanjut" or updates its follow-up.
+// Like the review status, the follow-up does not change the version of the comment.
+//
+// Parameters:
+//   - commentId: The ID of the comment
+//   - status: The follow-up status ("Open", "In Progress" or "Closed")
+//   - assignee: The username of the person handling the follow-up, empty if unassigned
+//
+// Returns:
+//   - error: An error if the comment is not found or the journal entry cannot be written
+func (c *commentRepository) SetFollowUp(commentId int, status string, assignee string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			global.Comments[i].TindakLanjut = status
+			global.Comments[i].Assignee = assignee
+
+			return record(c.journal, model.JournalEntry{
+				Command: "set_follow_up",
+				Id:      commentId,
+				Comment: &model.Comment{TindakLanjut: status, Assignee: assignee},
+			})
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+const (
+	// StatusBaru is the status of a comment that has not been processed yet
+	StatusBaru = "Baru"
+
+	// StatusDitinjau is the status of a comment that is being processed
+	StatusDitinjau = "Ditinjau"
+
+	// StatusSelesai is the status of a comment that has been processed
+	StatusSelesai = "Selesai"
+)
+
+// StatusOf returns the processing status of a comment, StatusBaru when none was set.
+//
+// Parameters:
+//   - comment: The comment
+//
+// Returns:
+//   - string: The status of the comment
+func StatusOf(comment model.Comment) string {
+	if comment.Status == "" {
+		return StatusBaru
+	}
+
+	return comment.Status
+}
+
+// SetStatus sets the processing status of a comment. Like the review status, the
+// processing status does not change the version or the edit history of the comment.
+// Which transitions are allowed is decided by the caller.
+//
+// Parameters:
+//   - commentId: The ID of the comment
+//   - status: The new status
+//
+// Returns:
+//   - error: An error if the comment is not found or the journal entry cannot be written
+func (c *commentRepository) SetStatus(commentId int, status string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			global.Comments[i].Status = status
+
+			return record(c.journal, model.JournalEntry{
+				Command: "set_status",
+				Id:      commentId,
+				Comment: &model.Comment{Status: status},
+			})
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+// SetNote sets the internal admin note of a comment. Like the review status, the note
+// does not change the version or the edit history of the comment.
+//
+// Parameters:
+//   - commentId: The ID of the comment
+//   - note: The note, empty to remove it
+//
+// Returns:
+//   - error: An error if the comment is not found or the journal entry cannot be written
+func (c *commentRepository) SetNote(commentId int, note string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			global.Comments[i].Catatan = note
+
+			return record(c.journal, model.JournalEntry{
+				Command: "set_note",
+				Id:      commentId,
+				Comment: &model.Comment{Catatan: note},
+			})
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+// setUuid assigns a UUID to a comment that has none, see assignUUIDs. Like the note, the
+// UUID does not change the version or the edit history of the comment.
+//
+// Parameters:
+//   - commentId: The ID of the comment
+//   - uuid: The UUID to assign
+//
+// Returns:
+//   - error: An error if the comment is not found or the journal entry cannot be written
+func (c *commentRepository) setUuid(commentId int, uuid string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			global.Comments[i].Uuid = uuid
+
+			return record(c.journal, model.JournalEntry{
+				Command: "set_comment_uuid",
+				Id:      commentId,
+				Comment: &model.Comment{Uuid: uuid},
+			})
 		}
 	}
 
@@ -322,6 +933,8 @@ func (c *commentRepository) EditComment(commentId int, comment model.Comment) er
 // which may result in sparse population of the results array if user comments
 // are not contiguous in the global storage.
 //
+// Only comments in the active project are included.
+//
 // Parameters:
 //   - userId: The ID of the user whose comments to retrieve
 //   - comments: A pointer to an array that will be filled with the user's comments
@@ -329,8 +942,10 @@ func (c *commentRepository) EditComment(commentId int, comment model.Comment) er
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (c *commentRepository) GetCommentByUserId(userId int, comments *[255]model.Comment) error {
+	defer readStore()()
+
 	for i := 0; i < global.CommentCount; i++ {
-		if global.Comments[i].UserId == userId {
+		if global.Comments[i].UserId == userId && inActiveProject(global.Comments[i]) {
 			(*comments)[i] = global.Comments[i]
 		}
 	}
@@ -349,13 +964,21 @@ func (c *commentRepository) GetCommentByUserId(userId int, comments *[255]model.
 // Returns:
 //   - error: An error if the comment is not found, nil on success
 func (c *commentRepository) DeleteComment(commentId int) error {
+	defer lockStore()()
+
 	for i := 0; i < global.CommentCount; i++ {
 		if global.Comments[i].Id == commentId {
 			for j := i; j < global.CommentCount-1; j++ {
 				global.Comments[j] = global.Comments[j+1]
 			}
 			global.CommentCount--
-			return nil
+			global.Comments[global.CommentCount] = model.Comment{}
+			commentIndex.remove(commentId)
+
+			return record(c.journal, model.JournalEntry{
+				Command: "delete_comment",
+				Id:      commentId,
+			})
 		}
 	}
 
@@ -374,13 +997,22 @@ func (c *commentRepository) DeleteComment(commentId int) error {
 // Returns:
 //   - error: An error if the comment is not found or doesn't belong to the user, nil on success
 func (c *commentRepository) DeleteUserComment(commentId int, userId int) error {
+	defer lockStore()()
+
 	for i := 0; i < global.CommentCount; i++ {
 		if global.Comments[i].Id == commentId && global.Comments[i].UserId == userId {
 			for j := i; j < global.CommentCount-1; j++ {
 				global.Comments[j] = global.Comments[j+1]
 			}
 			global.CommentCount--
-			return nil
+			global.Comments[global.CommentCount] = model.Comment{}
+			commentIndex.remove(commentId)
+
+			return record(c.journal, model.JournalEntry{
+				Command: "delete_user_comment",
+				Id:      commentId,
+				UserId:  userId,
+			})
 		}
 	}
 
@@ -396,6 +1028,8 @@ func (c *commentRepository) DeleteUserComment(commentId int, userId int) error {
 // which may result in sparse population of the results array if comments
 // with the matching category are not contiguous in the global storage.
 //
+// Only comments in the active project are included.
+//
 // Parameters:
 //   - kategori: The category to filter comments by (e.g., "Positif", "Netral", "Negatif")
 //   - comments: A pointer to an array that will be filled with the matching comments
@@ -404,10 +1038,12 @@ func (c *commentRepository) DeleteUserComment(commentId int, userId int) error {
 //   - int: The count of comments matching the specified category
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (c *commentRepository) GetCommentByKategori(kategori string, comments *[255]model.Comment) (int, error) {
+	defer readStore()()
+
 	var j int
 
 	for i := 0; i < global.CommentCount; i++ {
-		if global.Comments[i].Kategori == kategori {
+		if global.Comments[i].Kategori == kategori && inActiveProject(global.Comments[i]) {
 			j++
 			(*comments)[i] = global.Comments[i]
 		}
@@ -415,3 +1051,75 @@ func (c *commentRepository) GetCommentByKategori(kategori string, comments *[255
 
 	return j, nil
 }
+
+// FindCommentById retrieves a single comment by its ID.
+// It iterates through all comments in the global storage and copies the
+// first comment whose Id matches commentId into the provided model.
+//
+// Parameters:
+//   - commentId: The ID of the comment to find
+//   - comment: A pointer to a Comment model that will be populated with the found comment's data
+//
+// Returns:
+//   - error: An error if the comment is not found, nil on success
+func (c *commentRepository) FindCommentById(commentId int, comment *model.Comment) error {
+	defer readStore()()
+
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id == commentId {
+			*comment = global.Comments[i]
+			return nil
+		}
+	}
+
+	return fmt.Errorf("comment with ID %d not found", commentId)
+}
+
+// GetCommentRevisions retrieves the edit history of a comment, oldest first.
+// It copies every revision recorded for the specified comment into the provided
+// array, packed from index 0, and returns the number of revisions found.
+//
+// Parameters:
+//   - commentId: The ID of the comment whose history to retrieve
+//   - revisions: A pointer to an array that will be filled with the comment's revisions
+//
+// Returns:
+//   - int: The number of revisions found for the comment
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (c *commentRepository) GetCommentRevisions(commentId int, revisions *[255]model.CommentRevision) (int, error) {
+	defer readStore()()
+
+	var n int
+
+	for i := 0; i < global.RevisionCount; i++ {
+		if global.CommentRevisions[i].CommentId == commentId {
+			(*revisions)[n] = global.CommentRevisions[i]
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// saveRevision appends the current state of a comment to the edit history.
+// When the history array is full, the oldest revision is dropped by shifting
+// every revision one position to the front.
+//
+// Parameters:
+//   - comment: The comment as it was before the edit
+func saveRevision(comment model.Comment) {
+	if global.RevisionCount == len(global.CommentRevisions) {
+		for i := 0; i < global.RevisionCount-1; i++ {
+			global.CommentRevisions[i] = global.CommentRevisions[i+1]
+		}
+		global.RevisionCount--
+	}
+
+	global.CommentRevisions[global.RevisionCount] = model.CommentRevision{
+		CommentId: comment.Id,
+		Komentar:  comment.Komentar,
+		Kategori:  comment.Kategori,
+		EditedAt:  time.Now(),
+	}
+	global.RevisionCount++
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"sync"
+
+	"tugas-besar/lib/model"
+)
+
+// eventBus implements the EventBus interface with one buffered channel per subscriber.
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[int]chan model.JournalEntry
+	next        int
+}
+
+// EventBus defines the interface for publishing domain events.
+// Every mutation recorded in the journal is published as an event, so screens such as
+// the kiosk display can refresh as soon as data changes instead of polling.
+type EventBus interface {
+	// Publish delivers an event to every subscriber without blocking.
+	Publish(event model.JournalEntry)
+
+	// Subscribe registers a new subscriber. It returns the channel the events arrive on
+	// and a function that unsubscribes and closes the channel.
+	Subscribe() (<-chan model.JournalEntry, func())
+}
+
+// NewEventBus creates and returns a new EventBus implementation.
+//
+// Returns:
+//   - EventBus: A new instance of the eventBus implementation
+func NewEventBus() EventBus {
+	return &eventBus{
+		subscribers: make(map[int]chan model.JournalEntry),
+	}
+}
+
+// Publish delivers an event to every subscriber.
+// A subscriber whose buffer is full misses the event rather than blocking the
+// mutation that published it; subscribers only use events as a refresh signal.
+//
+// Parameters:
+//   - event: The journal entry describing the mutation
+func (b *eventBus) Publish(event model.JournalEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a new subscriber.
+//
+// Returns:
+//   - <-chan model.JournalEntry: The channel the events arrive on
+//   - func(): Unsubscribes and closes the channel; safe to call more than once
+func (b *eventBus) Subscribe() (<-chan model.JournalEntry, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+
+	ch := make(chan model.JournalEntry, 16)
+	b.subscribers[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+)
+
+// idGenerator implements the IDGenerator interface with atomic counters, so IDs can be
+// issued safely from several goroutines (server mode, background jobs) at once.
+type idGenerator struct {
+	user    atomic.Int64
+	comment atomic.Int64
+	useUUID bool
+}
+
+// IDGenerator defines the interface for issuing unique record IDs.
+// It replaces incrementing shared counters by hand, which is not safe once records
+// are created concurrently.
+type IDGenerator interface {
+	// NextUserId reserves and returns the next user ID.
+	NextUserId() int
+
+	// NextCommentId reserves and returns the next comment ID.
+	NextCommentId() int
+
+	// LastUserId returns the most recently issued user ID, 0 if none was issued.
+	LastUserId() int
+
+	// LastCommentId returns the most recently issued comment ID, 0 if none was issued.
+	LastCommentId() int
+
+	// Reset sets the last issued IDs, e.g. to 0 before a replay or back to a snapshot.
+	Reset(lastUserId int, lastCommentId int)
+
+	// NewUUID returns a new random UUID, or an empty string when UUIDs are disabled.
+	NewUUID() string
+}
+
+// NewIDGenerator creates and returns a new IDGenerator implementation.
+// The first IDs it issues are 1.
+//
+// Parameters:
+//   - useUUID: Whether new records also get a UUID
+//
+// Returns:
+//   - IDGenerator: A new instance of the idGenerator implementation
+func NewIDGenerator(useUUID bool) IDGenerator {
+	return &idGenerator{
+		useUUID: useUUID,
+	}
+}
+
+// NextUserId reserves and returns the next user ID.
+//
+// Returns:
+//   - int: The new user ID
+func (g *idGenerator) NextUserId() int {
+	return int(g.user.Add(1))
+}
+
+// NextCommentId reserves and returns the next comment ID.
+//
+// Returns:
+//   - int: The new comment ID
+func (g *idGenerator) NextCommentId() int {
+	return int(g.comment.Add(1))
+}
+
+// LastUserId returns the most recently issued user ID.
+//
+// Returns:
+//   - int: The last user ID, 0 if none was issued
+func (g *idGenerator) LastUserId() int {
+	return int(g.user.Load())
+}
+
+// LastCommentId returns the most recently issued comment ID.
+//
+// Returns:
+//   - int: The last comment ID, 0 if none was issued
+func (g *idGenerator) LastCommentId() int {
+	return int(g.comment.Load())
+}
+
+// Reset sets the last issued IDs. The next IDs issued are one higher.
+//
+// Parameters:
+//   - lastUserId: The user ID to treat as the last one issued
+//   - lastCommentId: The comment ID to treat as the last one issued
+func (g *idGenerator) Reset(lastUserId int, lastCommentId int) {
+	g.user.Store(int64(lastUserId))
+	g.comment.Store(int64(lastCommentId))
+}
+
+// NewUUID returns a new random (version 4) UUID.
+//
+// Returns:
+//   - string: The UUID in its canonical 36 character form, or an empty string when
+//     UUIDs are disabled or no random data is available
+func (g *idGenerator) NewUUID() string {
+	if !g.useUUID {
+		return ""
+	}
+
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return ""
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"fmt"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// integrityRepository implements the IntegrityRepository interface by inspecting
+// the in-memory storage directly.
+type integrityRepository struct {
+	ids IDGenerator
+}
+
+// IntegrityRepository defines the interface for checking and repairing the
+// referential integrity of the in-memory store.
+type IntegrityRepository interface {
+	// Check runs every integrity check and returns their results, in a fixed order.
+	Check() []model.IntegrityCheck
+
+	// Repair fixes the issues reported by the repairable checks.
+	// It returns the number of records that were changed.
+	Repair() int
+}
+
+// NewIntegrityRepository creates and returns a new IntegrityRepository implementation.
+//
+// Parameters:
+//   - ids: The ID generator whose counters are checked against the stored IDs
+//
+// Returns:
+//   - IntegrityRepository: A new instance of the integrityRepository implementation
+func NewIntegrityRepository(ids IDGenerator) IntegrityRepository {
+	return &integrityRepository{
+		ids: ids,
+	}
+}
+
+// Check runs every integrity check against the in-memory store.
+//
+// The following checks are performed:
+//   - UserCount and CommentCount match the number of records actually stored,
+//     with no empty slots before the count and no records after it
+//   - User and comment IDs are unique
+//   - The ID generator has not issued IDs lower than the highest ID in use
+//   - Every Comment.UserId resolves to a user (UserId 0 belongs to the admin)
+//
+// Returns:
+//   - []model.IntegrityCheck: The result of each check
+func (r *integrityRepository) Check() []model.IntegrityCheck {
+	defer readStore()()
+
+	return r.check()
+}
+
+// check is Check for callers that already hold the store lock, such as Repair.
+//
+// Returns:
+//   - []model.IntegrityCheck: The result of each check
+func (r *integrityRepository) check() []model.IntegrityCheck {
+	knownUsers := map[int]bool{0: true}
+	for i := 0; i < global.UserCount && i < len(global.Users); i++ {
+		knownUsers[global.Users[i].Id] = true
+	}
+
+	var unresolved []string
+	for i := 0; i < global.CommentCount && i < len(global.Comments); i++ {
+		if !knownUsers[global.Comments[i].UserId] {
+			unresolved = append(unresolved, fmt.Sprintf("comment %d references missing user %d", global.Comments[i].Id, global.Comments[i].UserId))
+		}
+	}
+
+	return []model.IntegrityCheck{
+		{Name: "UserCount", Issues: countIssues("user", global.UserCount, userIds()), Repairable: true},
+		{Name: "CommentCount", Issues: countIssues("comment", global.CommentCount, commentIds()), Repairable: true},
+		{Name: "Unique user IDs", Issues: duplicateIssues("user", userIds()), Repairable: true},
+		{Name: "Unique comment IDs", Issues: duplicateIssues("comment", commentIds()), Repairable: true},
+		{Name: "ID counters", Issues: r.counterIssues(), Repairable: true},
+		{Name: "Comment owners", Issues: unresolved},
+	}
+}
+
+// Repair fixes the issues reported by the repairable checks.
+//
+// Empty slots are removed so the records are stored contiguously and the counts are
+// recalculated, the ID counters are raised to the highest ID in use, and records with
+// a duplicate ID after the first one get a fresh ID.
+//
+// Repairs are applied to the in-memory store only and are not written to the journal.
+//
+// Returns:
+//   - int: The number of issues that were repaired
+func (r *integrityRepository) Repair() int {
+	defer lockStore()()
+
+	repaired := 0
+	for _, check := range r.check() {
+		if check.Repairable {
+			repaired += len(check.Issues)
+		}
+	}
+
+	var users [255]model.User
+	global.UserCount = 0
+	for _, user := range global.Users {
+		if user.Id != 0 {
+			users[global.UserCount] = user
+			global.UserCount++
+		}
+	}
+	global.Users = users
+
+	var comments [255]model.Comment
+	global.CommentCount = 0
+	for _, comment := range global.Comments {
+		if comment.Id != 0 {
+			comments[global.CommentCount] = comment
+			global.CommentCount++
+		}
+	}
+	global.Comments = comments
+
+	maxUser, maxComment := maxId(userIds()), maxId(commentIds())
+	r.ids.Reset(max(r.ids.LastUserId(), maxUser), max(r.ids.LastCommentId(), maxComment))
+
+	seen := make(map[int]bool)
+	for i := 0; i < global.UserCount; i++ {
+		if seen[global.Users[i].Id] {
+			global.Users[i].Id = r.ids.NextUserId()
+		}
+		seen[global.Users[i].Id] = true
+	}
+
+	seen = make(map[int]bool)
+	for i := 0; i < global.CommentCount; i++ {
+		if seen[global.Comments[i].Id] {
+			global.Comments[i].Id = r.ids.NextCommentId()
+		}
+		seen[global.Comments[i].Id] = true
+	}
+
+	commentIndex.rebuild()
+	usernameIndex.rebuild()
+
+	return repaired
+}
+
+// userIds returns the ID stored in every slot of the user storage, 0 for empty slots.
+func userIds() []int {
+	ids := make([]int, len(global.Users))
+	for i, user := range global.Users {
+		ids[i] = user.Id
+	}
+
+	return ids
+}
+
+// commentIds returns the ID stored in every slot of the comment storage, 0 for empty slots.
+func commentIds() []int {
+	ids := make([]int, len(global.Comments))
+	for i, comment := range global.Comments {
+		ids[i] = comment.Id
+	}
+
+	return ids
+}
+
+// maxId returns the highest ID in a list of IDs.
+func maxId(ids []int) int {
+	highest := 0
+	for _, id := range ids {
+		if id > highest {
+			highest = id
+		}
+	}
+
+	return highest
+}
+
+// countIssues compares a record counter with the slots that are actually filled.
+//
+// Parameters:
+//   - kind: The record type used in the issue text ("user" or "comment")
+//   - count: The value of the counter
+//   - ids: The ID stored in every slot of the storage array, 0 for empty slots
+//
+// Returns:
+//   - []string: The mismatches that were found
+func countIssues(kind string, count int, ids []int) []string {
+	var issues []string
+
+	filled := 0
+	for i, id := range ids {
+		if id == 0 {
+			if i < count {
+				issues = append(issues, fmt.Sprintf("empty %s slot at index %d", kind, i))
+			}
+			continue
+		}
+
+		filled++
+		if i >= count {
+			issues = append(issues, fmt.Sprintf("%s %d stored after the counter at index %d", kind, id, i))
+		}
+	}
+
+	if filled != count {
+		issues = append(issues, fmt.Sprintf("counter is %d but %d %ss are stored", count, filled, kind))
+	}
+
+	return issues
+}
+
+// duplicateIssues reports IDs that are used by more than one record.
+//
+// Parameters:
+//   - kind: The record type used in the issue text ("user" or "comment")
+//   - ids: The ID stored in every slot of the storage array, 0 for empty slots
+//
+// Returns:
+//   - []string: One issue per duplicated ID
+func duplicateIssues(kind string, ids []int) []string {
+	var issues []string
+
+	seen := make(map[int]int)
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+
+		seen[id]++
+		if seen[id] == 2 {
+			issues = append(issues, fmt.Sprintf("%s ID %d is used more than once", kind, id))
+		}
+	}
+
+	return issues
+}
+
+// counterIssues reports ID generator counters that are lower than the highest ID in use,
+// which would make the next created record reuse an existing ID.
+//
+// Returns:
+//   - []string: The counters that are too low
+func (r *integrityRepository) counterIssues() []string {
+	var issues []string
+
+	maxUser, maxComment := maxId(userIds()), maxId(commentIds())
+	if r.ids.LastUserId() < maxUser {
+		issues = append(issues, fmt.Sprintf("last issued user ID is %d but user ID %d exists", r.ids.LastUserId(), maxUser))
+	}
+	if r.ids.LastCommentId() < maxComment {
+		issues = append(issues, fmt.Sprintf("last issued comment ID is %d but comment ID %d exists", r.ids.LastCommentId(), maxComment))
+	}
+
+	return issues
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+)
+
+// ErrChecksumMismatch is returned by Replay when the journal file does not match the
+// checksum in its manifest, e.g. because it was truncated or edited outside the application.
+var ErrChecksumMismatch = errors.New("journal does not match its checksum")
+
+// journalManifest is the checksum of a journal file, stored next to it as "<journal>.sum".
+type journalManifest struct {
+	// Size is the size of the journal file in bytes
+	Size int64 `json:"size"`
+
+	// Sha256 is the hex encoded SHA-256 hash of the journal file
+	Sha256 string `json:"sha256"`
+
+	// State is the hex encoded internal state of the SHA-256 digest after Size bytes, so
+	// an append only hashes the new entry instead of the whole journal, see extendJournal
+	State string `json:"state,omitempty"`
+}
+
+// manifestPath returns the location of the manifest of a journal file.
+//
+// Parameters:
+//   - path: The location of the journal file
+//
+// Returns:
+//   - string: The journal path with ".sum" appended
+func manifestPath(path string) string {
+	return path + ".sum"
+}
+
+// digest hashes the first size bytes of a file.
+//
+// Parameters:
+//   - path: The file to hash
+//   - size: The number of bytes to hash, or -1 for the whole file
+//
+// Returns:
+//   - hash.Hash: The SHA-256 digest of the hashed bytes
+//   - int64: The number of bytes hashed
+//   - error: An error if the file cannot be read or is shorter than size
+func digest(path string, size int64) (hash.Hash, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if size >= 0 {
+		reader = io.LimitReader(file, size)
+	}
+
+	sum := sha256.New()
+	n, err := io.Copy(sum, reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	if size >= 0 && n < size {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+
+	return sum, n, nil
+}
+
+// writeManifest writes the manifest of a journal file for a digest of its first size bytes.
+// The manifest is written to a temporary file first and then renamed, so an interrupted
+// write never leaves a half written manifest.
+//
+// Parameters:
+//   - path: The location of the journal file
+//   - size: The number of bytes covered by the digest
+//   - sum: The SHA-256 digest of the first size bytes
+//
+// Returns:
+//   - error: An error if the manifest cannot be encoded or written
+func writeManifest(path string, size int64, sum hash.Hash) error {
+	manifest := journalManifest{Size: size, Sha256: hex.EncodeToString(sum.Sum(nil))}
+
+	state, err := sum.(encoding.BinaryMarshaler).MarshalBinary()
+	if err == nil {
+		manifest.State = hex.EncodeToString(state)
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal checksum: %v", err)
+	}
+
+	tmp := manifestPath(path) + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write journal checksum: %v", err)
+	}
+
+	err = os.Rename(tmp, manifestPath(path))
+	if err != nil {
+		return fmt.Errorf("failed to write journal checksum: %v", err)
+	}
+
+	return nil
+}
+
+// sealJournal writes the manifest of a journal file with its current size and hash,
+// hashing the whole file. Without a journal file the manifest is removed.
+//
+// Parameters:
+//   - path: The location of the journal file
+//
+// Returns:
+//   - error: An error if the journal cannot be hashed or the manifest cannot be written
+func sealJournal(path string) error {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		err = os.Remove(manifestPath(path))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove journal checksum: %v", err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to inspect journal: %v", err)
+	}
+
+	sum, size, err := digest(path, -1)
+	if err != nil {
+		return fmt.Errorf("failed to hash journal: %v", err)
+	}
+
+	return writeManifest(path, size, sum)
+}
+
+// extendJournal updates the manifest of a journal file after data was appended to it.
+// When the manifest covers exactly the journal before the append and holds the state of
+// its digest, only the appended data is hashed. Otherwise, e.g. for a manifest written
+// before the state was stored, the whole journal is hashed by sealJournal.
+//
+// Parameters:
+//   - path: The location of the journal file
+//   - offset: The size of the journal before the append
+//   - data: The appended data
+//
+// Returns:
+//   - error: An error if the journal cannot be hashed or the manifest cannot be written
+func extendJournal(path string, offset int64, data []byte) error {
+	raw, err := os.ReadFile(manifestPath(path))
+	if err != nil {
+		return sealJournal(path)
+	}
+
+	var manifest journalManifest
+	err = json.Unmarshal(raw, &manifest)
+	if err != nil || manifest.Size != offset || manifest.State == "" {
+		return sealJournal(path)
+	}
+
+	state, err := hex.DecodeString(manifest.State)
+	if err != nil {
+		return sealJournal(path)
+	}
+
+	sum := sha256.New()
+	err = sum.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+	if err != nil {
+		return sealJournal(path)
+	}
+
+	sum.Write(data)
+
+	return writeManifest(path, offset+int64(len(data)), sum)
+}
+
+// verifyJournal checks a journal file against its manifest.
+//
+// The journal is accepted when its first manifest.Size bytes match the hash of the
+// manifest. A journal without a manifest is refused, since a deleted manifest would
+// otherwise let a truncated journal load silently; only a missing or empty journal
+// needs no manifest. Bytes after that are only accepted as complete lines: they are entries whose
+// manifest update was interrupted, while a partial line is a torn write.
+//
+// Parameters:
+//   - path: The location of the journal file
+//
+// Returns:
+//   - error: An error wrapping ErrChecksumMismatch that describes the difference, another
+//     error if the files cannot be read, or nil when the journal is intact
+func verifyJournal(path string) error {
+	data, err := os.ReadFile(manifestPath(path))
+	if os.IsNotExist(err) {
+		info, statErr := os.Stat(path)
+		if os.IsNotExist(statErr) || (statErr == nil && info.Size() == 0) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s has no checksum", ErrChecksumMismatch, path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read journal checksum: %v", err)
+	}
+
+	var manifest journalManifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return fmt.Errorf("%w: %s is damaged", ErrChecksumMismatch, manifestPath(path))
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		if manifest.Size == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s is missing, expected %d bytes", ErrChecksumMismatch, path, manifest.Size)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to inspect journal: %v", err)
+	}
+
+	if info.Size() < manifest.Size {
+		return fmt.Errorf("%w: %s is truncated to %d of %d bytes", ErrChecksumMismatch, path, info.Size(), manifest.Size)
+	}
+
+	sum, _, err := digest(path, manifest.Size)
+	if err != nil {
+		return fmt.Errorf("failed to hash journal: %v", err)
+	}
+	if hex.EncodeToString(sum.Sum(nil)) != manifest.Sha256 {
+		return fmt.Errorf("%w: %s was modified", ErrChecksumMismatch, path)
+	}
+
+	if info.Size() > manifest.Size {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open journal: %v", err)
+		}
+		defer file.Close()
+
+		tail := make([]byte, info.Size()-manifest.Size)
+		_, err = file.ReadAt(tail, manifest.Size)
+		if err != nil {
+			return fmt.Errorf("failed to read journal: %v", err)
+		}
+
+		if !bytes.HasSuffix(tail, []byte("\n")) {
+			return fmt.Errorf("%w: %s ends with an incomplete entry", ErrChecksumMismatch, path)
+		}
+	}
+
+	return nil
+}
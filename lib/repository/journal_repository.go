@@ -0,0 +1,721 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+)
+
+// journalRepository implements the JournalRepository interface using an
+// append-only JSON Lines file on disk.
+type journalRepository struct {
+	path   string
+	ids    IDGenerator
+	events EventBus
+}
+
+// JournalRepository defines the interface for the operation journal.
+// Every mutation performed by the user and comment repositories is appended to the
+// journal as a command, so the in-memory store can be rebuilt by replaying it.
+type JournalRepository interface {
+	// Append writes a single journal entry to the end of the journal.
+	// Returns an error if the entry cannot be written, nil otherwise.
+	Append(entry model.JournalEntry) error
+
+	// GetAllEntries reads every entry currently stored in the journal, in order.
+	GetAllEntries() ([]model.JournalEntry, error)
+
+	// Replay clears the in-memory store and re-applies every journal entry in order.
+	// It returns the number of entries that were replayed, and an error wrapping
+	// ErrChecksumMismatch without loading anything when the journal is corrupted.
+	Replay() (int, error)
+
+	// Seal records the current state of a journal file as intact, accepting its content.
+	Seal(path string) error
+
+	// Restore replaces a journal file by a backup. The replaced file is kept next to it.
+	// It returns the path the replaced file was moved to, empty if there was none.
+	Restore(path, backup string) (string, error)
+
+	// Checkpoint returns a marker for the current end of the journal.
+	Checkpoint() (int64, error)
+
+	// Rollback discards every entry appended after the given checkpoint.
+	Rollback(checkpoint int64) error
+
+	// Backup copies the journal into a directory and returns the path of the copy.
+	Backup(dir string) (string, error)
+
+	// Compact replaces the journal by a snapshot of the in-memory store, one entry per
+	// live record, and replays it. It returns the size of the journal before and after.
+	Compact() (model.CompactionResult, error)
+
+	// Path returns the location of the journal file currently in use.
+	Path() string
+
+	// Open switches to another journal file and replays it into the in-memory store.
+	// It returns the number of entries that were replayed, and an error wrapping
+	// ErrChecksumMismatch without switching when the file is corrupted.
+	Open(path string) (int, error)
+}
+
+// NewJournalRepository creates and returns a new JournalRepository implementation.
+//
+// Parameters:
+//   - path: The location of the JSON Lines journal file
+//   - ids: The ID generator that is reset and reused while replaying
+//   - events: The event bus every appended entry is published on, may be nil
+//
+// Returns:
+//   - JournalRepository: A new instance of the journalRepository implementation
+func NewJournalRepository(path string, ids IDGenerator, events EventBus) JournalRepository {
+	return &journalRepository{
+		path:   path,
+		ids:    ids,
+		events: events,
+	}
+}
+
+// Append writes a single journal entry to the end of the journal file.
+// The file is created if it does not exist yet. The entry is stamped with the
+// current time before it is encoded as one JSON line. Once written, the checksum
+// manifest is extended by the new line, see extendJournal, and the entry is published
+// on the event bus as a domain event.
+//
+// A manifest that cannot be updated does not fail the append: the next replay accepts
+// complete entries after the checksummed part of the journal, see verifyJournal. Only
+// the manifest of a journal created by this append has to be written, since a journal
+// without a manifest is refused; when it cannot be written the entry is taken back.
+//
+// Parameters:
+//   - entry: The journal entry describing the mutation
+//
+// Returns:
+//   - error: An error if the file cannot be opened or written, nil on success
+func (j *journalRepository) Append(entry model.JournalEntry) error {
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to inspect journal: %v", err)
+	}
+
+	entry.Timestamp = time.Now()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry: %v", err)
+	}
+
+	line := append(data, '\n')
+	_, err = file.Write(line)
+	if err != nil {
+		return fmt.Errorf("failed to write journal: %v", err)
+	}
+
+	helper.Trace("journal: %s id=%d user_id=%d", entry.Command, entry.Id, entry.UserId)
+
+	err = extendJournal(j.path, info.Size(), line)
+	if err != nil && info.Size() == 0 {
+		file.Truncate(0)
+		return err
+	}
+	if err != nil {
+		helper.Trace("journal: %v", err)
+	}
+
+	if j.events != nil {
+		j.events.Publish(entry)
+	}
+
+	return nil
+}
+
+// GetAllEntries reads every entry currently stored in the journal file.
+// A missing journal file is treated as an empty journal.
+//
+// Returns:
+//   - []model.JournalEntry: The journal entries in the order they were recorded
+//   - error: An error if the file cannot be read or an entry cannot be decoded
+func (j *journalRepository) GetAllEntries() ([]model.JournalEntry, error) {
+	var entries []model.JournalEntry
+
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry model.JournalEntry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid journal entry on line %d: %v", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %v", err)
+	}
+
+	return entries, nil
+}
+
+// Replay clears the in-memory store and re-applies every journal entry in order.
+// The active project is cleared as well, since the projects are rebuilt from the journal.
+//
+// The journal is checked against its checksum manifest first. A truncated or modified
+// journal is refused before the in-memory store is touched, so a corrupted file is never
+// loaded silently. After a successful replay the manifest is updated, which also creates
+// it for journals that do not have one yet.
+//
+// The entries are applied through repository instances that have no journal attached,
+// so replaying does not write the same commands to the journal again. Because the
+// store starts empty and the commands are deterministic, the generated IDs and
+// indexes match the ones from the original run.
+//
+// Returns:
+//   - int: The number of entries that were replayed
+//   - error: An error wrapping ErrChecksumMismatch for a corrupted journal, or an error if
+//     the journal cannot be read or an entry cannot be applied
+func (j *journalRepository) Replay() (int, error) {
+	defer helper.TraceTime("replay journal")()
+
+	err := verifyJournal(j.path)
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := j.GetAllEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	replayed, err := replay(entries, j.ids)
+	if err != nil {
+		return replayed, err
+	}
+
+	err = assignUUIDs(j, j.ids)
+	if err != nil {
+		return replayed, err
+	}
+
+	return replayed, sealJournal(j.path)
+}
+
+// assignUUIDs gives a UUID to every user and comment that has none after a replay, when
+// UUIDs are enabled. This happens for records created before USE_UUID was turned on.
+// Every assigned UUID is written to the journal, so the records keep the same UUID on
+// every following start and in every export instead of getting a new random one.
+//
+// Parameters:
+//   - journal: The journal the assigned UUIDs are recorded in
+//   - ids: The ID generator that issues the UUIDs
+//
+// Returns:
+//   - error: An error if an assigned UUID cannot be written to the journal
+func assignUUIDs(journal JournalRepository, ids IDGenerator) error {
+	users := &userRepository{journal: journal, ids: ids}
+	for i := 0; i < global.UserCount; i++ {
+		if global.Users[i].Uuid != "" {
+			continue
+		}
+
+		uuid := ids.NewUUID()
+		if uuid == "" {
+			return nil
+		}
+
+		err := users.setUuid(global.Users[i].Id, uuid)
+		if err != nil {
+			return err
+		}
+	}
+
+	comments := &commentRepository{journal: journal, ids: ids}
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Uuid != "" {
+			continue
+		}
+
+		uuid := ids.NewUUID()
+		if uuid == "" {
+			return nil
+		}
+
+		err := comments.setUuid(global.Comments[i].Id, uuid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Seal writes the checksum manifest of a journal file for its current content.
+// It is used to accept a journal that failed the checksum, e.g. after it was repaired
+// by hand. The file does not have to be the one in use, so a journal that Open refused
+// can be accepted before switching to it.
+//
+// Parameters:
+//   - path: The location of the journal file
+//
+// Returns:
+//   - error: An error if the journal cannot be hashed or the manifest cannot be written
+func (j *journalRepository) Seal(path string) error {
+	return sealJournal(path)
+}
+
+// Restore replaces a journal file by a backup and seals it. The replaced journal is
+// renamed to "<journal>.<time>.rusak" so it can still be inspected; the name does not
+// end in the journal extension, so it is not listed as a profile. Like Seal, the file
+// does not have to be the one in use.
+//
+// Parameters:
+//   - path: The location of the journal file to replace
+//   - backup: The backup file to copy over the journal, see Backup
+//
+// Returns:
+//   - string: The path the replaced journal was moved to, empty if there was no journal
+//   - error: An error if the backup cannot be read or the files cannot be written
+func (j *journalRepository) Restore(path, backup string) (string, error) {
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		return "", fmt.Errorf("failed to read backup: %v", err)
+	}
+
+	moved := path + "." + time.Now().Format("20060102-150405") + ".rusak"
+	err = os.Rename(path, moved)
+	if os.IsNotExist(err) {
+		moved = ""
+	} else if err != nil {
+		return "", fmt.Errorf("failed to move journal: %v", err)
+	}
+
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return moved, fmt.Errorf("failed to restore journal: %v", err)
+	}
+
+	return moved, sealJournal(path)
+}
+
+// replay clears the in-memory store and re-applies journal entries in order, see
+// journalRepository.Replay.
+//
+// Parameters:
+//   - entries: The journal entries in the order they were recorded
+//   - ids: The ID generator that is reset and reused while replaying
+//
+// Returns:
+//   - int: The number of entries that were replayed
+//   - error: An error if an entry cannot be applied
+func replay(entries []model.JournalEntry, ids IDGenerator) (int, error) {
+	var replayed int
+
+	err := holdStore(func() error {
+		var err error
+		replayed, err = applyEntries(entries, ids)
+		return err
+	})
+
+	return replayed, err
+}
+
+// applyEntries clears the in-memory store and applies the entries, see replay.
+// The caller holds the store lock.
+//
+// Parameters:
+//   - entries: The journal entries in the order they were recorded
+//   - ids: The ID generator that is reset and reused while replaying
+//
+// Returns:
+//   - int: The number of entries that were replayed
+//   - error: An error if an entry cannot be applied
+func applyEntries(entries []model.JournalEntry, ids IDGenerator) (int, error) {
+	var err error
+
+	global.Users = [255]model.User{}
+	global.Comments = [255]model.Comment{}
+	global.CommentRevisions = [255]model.CommentRevision{}
+	global.UserCount = 0
+	global.CommentCount = 0
+	global.RevisionCount = 0
+	global.Projects = [255]model.Project{}
+	global.ProjectCount = 0
+	global.ActiveProjectId = 0
+	global.ScheduledComments = [255]model.ScheduledComment{}
+	global.ScheduledCount = 0
+	global.Templates = [255]model.Template{}
+	global.TemplateCount = 0
+	global.Bookmarks = [255]model.Bookmark{}
+	global.BookmarkCount = 0
+	global.LoginAttempts = [255]model.LoginAttempt{}
+	global.LoginAttemptCount = 0
+	commentIndex.rebuild()
+	usernameIndex.rebuild()
+	ids.Reset(0, 0)
+
+	users := &userRepository{ids: ids, replaying: true}
+	comments := &commentRepository{ids: ids, replaying: true}
+	projects := &projectRepository{}
+	schedules := &scheduleRepository{comments: comments}
+	templates := &templateRepository{}
+	bookmarks := &bookmarkRepository{}
+	logins := &loginRepository{}
+
+	for i, entry := range entries {
+		upgradeLegacyVersion(&entry)
+
+		switch entry.Command {
+		case "create_user":
+			err = users.Create(entry.User)
+		case "edit_user":
+			err = users.EditUser(entry.Index, *entry.User)
+		case "delete_user":
+			err = users.DeleteUser(entry.Index)
+		case "mark_user_deleted":
+			err = users.MarkUserDeleted(entry.Index)
+		case "restore_user":
+			err = users.RestoreUser(entry.Index)
+		case "set_user_role":
+			err = users.SetUserRole(entry.Index, entry.User.Role)
+		case "set_user_uuid":
+			err = users.setUuid(entry.Id, entry.User.Uuid)
+		case "create_comment":
+			err = comments.Create(entry.Comment, entry.UserId)
+		case "edit_comment":
+			err = comments.EditComment(entry.Id, *entry.Comment)
+		case "edit_user_comment":
+			err = comments.EditUserComment(entry.Id, entry.UserId, *entry.Comment)
+		case "set_comment_uuid":
+			err = comments.setUuid(entry.Id, entry.Comment.Uuid)
+		case "delete_comment":
+			err = comments.DeleteComment(entry.Id)
+		case "delete_user_comment":
+			err = comments.DeleteUserComment(entry.Id, entry.UserId)
+		case "reassign_comments":
+			_, err = comments.ReassignComments(entry.Id, entry.UserId)
+		case "second_label":
+			err = comments.SetSecondLabel(entry.Id, entry.UserId, entry.Comment.Kategori2)
+		case "set_review":
+			err = comments.SetReview(entry.Id, entry.Comment.Review)
+		case "set_status":
+			err = comments.SetStatus(entry.Id, entry.Comment.Status)
+		case "set_note":
+			err = comments.SetNote(entry.Id, entry.Comment.Catatan)
+		case "set_follow_up":
+			err = comments.SetFollowUp(entry.Id, entry.Comment.TindakLanjut, entry.Comment.Assignee)
+		case "create_project":
+			err = projects.Create(entry.Project)
+		case "schedule_comment":
+			err = schedules.Schedule(entry.Scheduled.Comment, entry.Scheduled.UserId, entry.Scheduled.PublishAt)
+		case "publish_scheduled":
+			err = schedules.remove(entry.Id)
+		case "create_template":
+			err = templates.Create(entry.Template)
+		case "delete_template":
+			err = templates.DeleteTemplate(entry.Id)
+		case "add_bookmark":
+			err = bookmarks.Add(entry.UserId, entry.Id)
+		case "remove_bookmark":
+			err = bookmarks.Remove(entry.UserId, entry.Id)
+		case "record_login":
+			err = logins.Record(entry.Login)
+		case "snapshot_ids", "snapshot_user", "snapshot_comment", "snapshot_revision", "snapshot_project",
+			"snapshot_scheduled", "snapshot_template", "snapshot_bookmark", "snapshot_login":
+			err = applySnapshot(entry, ids)
+		default:
+			err = fmt.Errorf("unknown command %q", entry.Command)
+		}
+
+		if err != nil {
+			return i, fmt.Errorf("failed to replay journal entry %d: %v", i+1, err)
+		}
+
+		// Keep the original edit time on the revision created by the replayed edit and on
+		// the edited comment
+		if (entry.Command == "edit_comment" || entry.Command == "edit_user_comment") && global.RevisionCount > 0 {
+			global.CommentRevisions[global.RevisionCount-1].EditedAt = entry.Timestamp
+
+			for j := 0; j < global.CommentCount; j++ {
+				if global.Comments[j].Id == entry.Id {
+					global.Comments[j].UpdatedAt = entry.Timestamp
+				}
+			}
+		}
+
+		// Keep the original deletion time, the grace period counts from it
+		if entry.Command == "mark_user_deleted" {
+			global.Users[entry.Index].DeletedAt = entry.Timestamp
+		}
+
+		// Keep the original bookmark time on the replayed bookmark
+		if entry.Command == "add_bookmark" {
+			global.Bookmarks[global.BookmarkCount-1].CreatedAt = entry.Timestamp
+		}
+	}
+
+	return len(entries), nil
+}
+
+// Path returns the location of the journal file currently in use.
+//
+// Returns:
+//   - string: The path of the JSON Lines journal file
+func (j *journalRepository) Path() string {
+	return j.path
+}
+
+// Open switches to another journal file and replays it, replacing the in-memory store
+// with the data recorded in that file. A missing file gives an empty store; the file is
+// created by the first mutation.
+//
+// The file is verified before anything is changed, so a journal that fails its checksum
+// leaves the current journal and the store as they are. When the replay fails halfway,
+// the previous journal is opened again so the store keeps matching the file in use.
+//
+// Parameters:
+//   - path: The location of the JSON Lines journal file to switch to
+//
+// Returns:
+//   - int: The number of entries that were replayed
+//   - error: An error wrapping ErrChecksumMismatch if the file is corrupted, or an error
+//     if the journal cannot be read or an entry cannot be applied
+func (j *journalRepository) Open(path string) (int, error) {
+	var replayed int
+
+	err := holdStore(func() error {
+		err := verifyJournal(path)
+		if err != nil {
+			return err
+		}
+
+		previous := j.path
+		j.path = path
+		replayed, err = j.Replay()
+		if err != nil && previous != path {
+			j.path = previous
+			_, _ = j.Replay()
+		}
+
+		return err
+	})
+
+	return replayed, err
+}
+
+// Backup copies the journal file into a directory. The copy is named after the
+// journal with the current time appended, e.g. journal-20240131-150405.jsonl.
+// The directory is created if it does not exist.
+//
+// Parameters:
+//   - dir: The directory the copy is written to
+//
+// Returns:
+//   - string: The path of the copy, empty if there is no journal yet
+//   - error: An error if the journal cannot be read or the copy cannot be written
+func (j *journalRepository) Backup(dir string) (string, error) {
+	data, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read journal: %v", err)
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %v", err)
+	}
+
+	ext := filepath.Ext(j.path)
+	name := strings.TrimSuffix(filepath.Base(j.path), ext) + "-" + time.Now().Format("20060102-150405") + ext
+	path := filepath.Join(dir, name)
+
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to write backup: %v", err)
+	}
+
+	return path, nil
+}
+
+// upgradeLegacyVersion fills in the expected version of edit entries that were
+// recorded before records had a version, so they replay without a conflict.
+//
+// Parameters:
+//   - entry: The journal entry to upgrade in place
+func upgradeLegacyVersion(entry *model.JournalEntry) {
+	if entry.Command == "edit_user" && entry.User != nil && entry.User.Version == 0 {
+		if entry.Index >= 0 && entry.Index < global.UserCount {
+			entry.User.Version = global.Users[entry.Index].Version
+		}
+	}
+
+	if (entry.Command == "edit_comment" || entry.Command == "edit_user_comment") && entry.Comment != nil && entry.Comment.Version == 0 {
+		for i := 0; i < global.CommentCount; i++ {
+			if global.Comments[i].Id == entry.Id {
+				entry.Comment.Version = global.Comments[i].Version
+			}
+		}
+	}
+}
+
+// Compact replaces the journal file by a snapshot of the in-memory store, see
+// snapshotEntries, so the history of edits, deletes and logins no longer makes the
+// journal grow without limit. The snapshot is written to a temporary file and renamed
+// over the journal, which is then sealed and replayed. The active project is kept.
+// The store lock is held throughout, so no change is lost between the snapshot and the
+// replay.
+//
+// Returns:
+//   - model.CompactionResult: The number of entries and bytes before and after
+//   - error: An error if the journal cannot be read, the snapshot cannot be written or
+//     the snapshot cannot be replayed
+func (j *journalRepository) Compact() (model.CompactionResult, error) {
+	var result model.CompactionResult
+
+	err := holdStore(func() error {
+		var err error
+		result, err = j.compact()
+		return err
+	})
+
+	return result, err
+}
+
+// compact replaces the journal by the snapshot while the store lock is held, see Compact.
+//
+// Returns:
+//   - model.CompactionResult: The number of entries and bytes before and after
+//   - error: An error if the journal cannot be compacted
+func (j *journalRepository) compact() (model.CompactionResult, error) {
+	var result model.CompactionResult
+
+	before, err := j.GetAllEntries()
+	if err != nil {
+		return result, err
+	}
+	result.EntriesBefore = len(before)
+
+	result.BytesBefore, err = j.Checkpoint()
+	if err != nil {
+		return result, err
+	}
+
+	var data []byte
+	entries := snapshotEntries(j.ids)
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return result, fmt.Errorf("failed to encode journal entry: %v", err)
+		}
+
+		data = append(append(data, line...), '\n')
+	}
+	result.EntriesAfter = len(entries)
+	result.BytesAfter = int64(len(data))
+
+	tmp := j.path + ".compact"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return result, fmt.Errorf("failed to write journal snapshot: %v", err)
+	}
+
+	err = os.Rename(tmp, j.path)
+	if err != nil {
+		return result, fmt.Errorf("failed to replace journal: %v", err)
+	}
+
+	err = sealJournal(j.path)
+	if err != nil {
+		return result, err
+	}
+
+	active := global.ActiveProjectId
+	_, err = j.Replay()
+	global.ActiveProjectId = active
+
+	return result, err
+}
+
+// Checkpoint returns the current size of the journal file, which marks the end of the
+// journal. A missing journal file has size 0.
+//
+// Returns:
+//   - int64: The checkpoint to pass to Rollback
+//   - error: An error if the journal file cannot be inspected
+func (j *journalRepository) Checkpoint() (int64, error) {
+	info, err := os.Stat(j.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect journal: %v", err)
+	}
+
+	return info.Size(), nil
+}
+
+// Rollback truncates the journal file back to a checkpoint, discarding every
+// entry appended after it, and updates the checksum manifest to the shorter journal.
+//
+// Parameters:
+//   - checkpoint: The value returned by Checkpoint before the entries were appended
+//
+// Returns:
+//   - error: An error if the journal file cannot be truncated, nil otherwise
+func (j *journalRepository) Rollback(checkpoint int64) error {
+	err := os.Truncate(j.path, checkpoint)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back journal: %v", err)
+	}
+
+	return sealJournal(j.path)
+}
+
+// record appends an entry to the given journal.
+// It is a no-op when no journal is attached, which is the case while replaying.
+//
+// Parameters:
+//   - journal: The journal to write to, may be nil
+//   - entry: The journal entry describing the mutation
+//
+// Returns:
+//   - error: An error if the entry cannot be written, nil otherwise
+func record(journal JournalRepository, entry model.JournalEntry) error {
+	if journal == nil {
+		return nil
+	}
+
+	return journal.Append(entry)
+}
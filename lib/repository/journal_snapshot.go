@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// snapshotEntries describes the in-memory store as a journal with one entry per live
+// record, see JournalRepository.Compact. Replaying the entries rebuilds the store as it
+// is now, with the records packed in storage order and every ID kept:
+//   - "snapshot_ids" restores the ID counters, raised to the highest ID in use
+//   - "snapshot_user", "snapshot_comment", "snapshot_project", "snapshot_scheduled",
+//     "snapshot_template" and "snapshot_login" restore one record each
+//   - "snapshot_revision" and "snapshot_bookmark" restore the revisions and bookmarks of
+//     comments that still exist; those of deleted comments are dropped
+//
+// Empty slots are skipped, so the index-based user entries appended after the snapshot
+// refer to the positions the users have after replaying it.
+//
+// Parameters:
+//   - ids: The ID generator whose counters are recorded
+//
+// Returns:
+//   - []model.JournalEntry: The snapshot entries, stamped with the current time
+func snapshotEntries(ids IDGenerator) []model.JournalEntry {
+	now := time.Now()
+
+	entries := []model.JournalEntry{{
+		Command:   "snapshot_ids",
+		UserId:    max(ids.LastUserId(), maxId(userIds())),
+		Id:        max(ids.LastCommentId(), maxId(commentIds())),
+		Timestamp: now,
+	}}
+
+	for i := 0; i < global.UserCount; i++ {
+		if global.Users[i].Id != 0 {
+			user := global.Users[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_user", User: &user, Timestamp: now})
+		}
+	}
+
+	stored := make(map[int]bool)
+	for i := 0; i < global.CommentCount; i++ {
+		if global.Comments[i].Id != 0 {
+			comment := global.Comments[i]
+			stored[comment.Id] = true
+			entries = append(entries, model.JournalEntry{Command: "snapshot_comment", Comment: &comment, Timestamp: now})
+		}
+	}
+
+	for i := 0; i < global.RevisionCount; i++ {
+		if stored[global.CommentRevisions[i].CommentId] {
+			revision := global.CommentRevisions[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_revision", Revision: &revision, Timestamp: now})
+		}
+	}
+
+	for i := 0; i < global.ProjectCount; i++ {
+		if global.Projects[i].Id != 0 {
+			project := global.Projects[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_project", Project: &project, Timestamp: now})
+		}
+	}
+
+	for i := 0; i < global.ScheduledCount; i++ {
+		if global.ScheduledComments[i].Id != 0 {
+			scheduled := global.ScheduledComments[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_scheduled", Scheduled: &scheduled, Timestamp: now})
+		}
+	}
+
+	for i := 0; i < global.TemplateCount; i++ {
+		if global.Templates[i].Id != 0 {
+			template := global.Templates[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_template", Template: &template, Timestamp: now})
+		}
+	}
+
+	for i := 0; i < global.BookmarkCount; i++ {
+		if stored[global.Bookmarks[i].CommentId] {
+			bookmark := global.Bookmarks[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_bookmark", Bookmark: &bookmark, Timestamp: now})
+		}
+	}
+
+	for i := 0; i < global.LoginAttemptCount; i++ {
+		if !global.LoginAttempts[i].At.IsZero() {
+			attempt := global.LoginAttempts[i]
+			entries = append(entries, model.JournalEntry{Command: "snapshot_login", Login: &attempt, Timestamp: now})
+		}
+	}
+
+	return entries
+}
+
+// applySnapshot restores the record of a snapshot entry into the next free slot of its
+// storage array, exactly as it was recorded, see snapshotEntries.
+//
+// Parameters:
+//   - entry: The snapshot entry
+//   - ids: The ID generator restored by "snapshot_ids"
+//
+// Returns:
+//   - error: An error if the entry has no record or the storage array is full
+func applySnapshot(entry model.JournalEntry, ids IDGenerator) error {
+	switch {
+	case entry.Command == "snapshot_ids":
+		ids.Reset(entry.UserId, entry.Id)
+	case entry.Command == "snapshot_user" && entry.User != nil:
+		if global.UserCount >= len(global.Users) {
+			return fmt.Errorf("user storage is full (%d users)", len(global.Users))
+		}
+		global.Users[global.UserCount] = *entry.User
+		usernameIndex.insert(entry.User.Username)
+		global.UserCount++
+	case entry.Command == "snapshot_comment" && entry.Comment != nil:
+		if global.CommentCount >= len(global.Comments) {
+			return fmt.Errorf("comment storage is full (%d comments)", len(global.Comments))
+		}
+		global.Comments[global.CommentCount] = *entry.Comment
+		commentIndex.add(*entry.Comment)
+		global.CommentCount++
+	case entry.Command == "snapshot_revision" && entry.Revision != nil:
+		if global.RevisionCount >= len(global.CommentRevisions) {
+			return fmt.Errorf("revision storage is full (%d revisions)", len(global.CommentRevisions))
+		}
+		global.CommentRevisions[global.RevisionCount] = *entry.Revision
+		global.RevisionCount++
+	case entry.Command == "snapshot_project" && entry.Project != nil:
+		if global.ProjectCount >= len(global.Projects) {
+			return fmt.Errorf("project storage is full (%d projects)", len(global.Projects))
+		}
+		global.Projects[global.ProjectCount] = *entry.Project
+		global.ProjectCount++
+	case entry.Command == "snapshot_scheduled" && entry.Scheduled != nil:
+		if global.ScheduledCount >= len(global.ScheduledComments) {
+			return fmt.Errorf("schedule queue is full (%d comments)", len(global.ScheduledComments))
+		}
+		global.ScheduledComments[global.ScheduledCount] = *entry.Scheduled
+		global.ScheduledCount++
+	case entry.Command == "snapshot_template" && entry.Template != nil:
+		if global.TemplateCount >= len(global.Templates) {
+			return fmt.Errorf("template storage is full (%d templates)", len(global.Templates))
+		}
+		global.Templates[global.TemplateCount] = *entry.Template
+		global.TemplateCount++
+	case entry.Command == "snapshot_bookmark" && entry.Bookmark != nil:
+		if global.BookmarkCount >= len(global.Bookmarks) {
+			return fmt.Errorf("bookmark storage is full (%d bookmarks)", len(global.Bookmarks))
+		}
+		global.Bookmarks[global.BookmarkCount] = *entry.Bookmark
+		global.BookmarkCount++
+	case entry.Command == "snapshot_login" && entry.Login != nil:
+		if global.LoginAttemptCount >= len(global.LoginAttempts) {
+			return fmt.Errorf("login history is full (%d attempts)", len(global.LoginAttempts))
+		}
+		global.LoginAttempts[global.LoginAttemptCount] = *entry.Login
+		global.LoginAttemptCount++
+	default:
+		return fmt.Errorf("snapshot entry %q has no record", entry.Command)
+	}
+
+	return nil
+}
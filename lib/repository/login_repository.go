@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"strings"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// loginRepository implements the LoginRepository interface using an in-memory
+// storage mechanism for the login history.
+type loginRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+}
+
+// LoginRepository defines the interface for login history data operations.
+type LoginRepository interface {
+	// Record adds a login attempt to the history. When the storage is full the
+	// oldest attempt is dropped.
+	Record(attempt *model.LoginAttempt) error
+
+	// GetLoginsByUsername retrieves the login attempts of a username, packed from index 0,
+	// newest first. An empty username retrieves every attempt. It returns the number of attempts.
+	GetLoginsByUsername(username string, attempts *[255]model.LoginAttempt) (int, error)
+}
+
+// NewLoginRepository creates and returns a new LoginRepository implementation.
+//
+// Parameters:
+//   - journal: The journal every mutation is recorded to, may be nil
+//
+// Returns:
+//   - LoginRepository: A new instance of the loginRepository implementation
+func NewLoginRepository(journal JournalRepository) LoginRepository {
+	return &loginRepository{
+		journal: journal,
+	}
+}
+
+// Record stores a login attempt at the end of the history. The history is a log, so
+// instead of failing when the storage is full the oldest attempt is shifted out.
+//
+// Parameters:
+//   - attempt: A pointer to the LoginAttempt to store, At must be set
+//
+// Returns:
+//   - error: An error if the mutation cannot be written to the journal, nil otherwise
+func (l *loginRepository) Record(attempt *model.LoginAttempt) error {
+	defer lockStore()()
+
+	if global.LoginAttemptCount >= len(global.LoginAttempts) {
+		for i := 0; i < global.LoginAttemptCount-1; i++ {
+			global.LoginAttempts[i] = global.LoginAttempts[i+1]
+		}
+		global.LoginAttemptCount--
+	}
+
+	global.LoginAttempts[global.LoginAttemptCount] = *attempt
+	global.LoginAttemptCount++
+
+	return record(l.journal, model.JournalEntry{
+		Command: "record_login",
+		Login:   attempt,
+	})
+}
+
+// GetLoginsByUsername copies the login attempts of a username into the provided array,
+// packed from index 0, newest first. Usernames are compared ignoring case.
+//
+// Parameters:
+//   - username: The username to filter on, empty for every attempt
+//   - attempts: A pointer to an array that will be filled with the attempts
+//
+// Returns:
+//   - int: The number of attempts
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (l *loginRepository) GetLoginsByUsername(username string, attempts *[255]model.LoginAttempt) (int, error) {
+	defer readStore()()
+
+	n := 0
+	for i := global.LoginAttemptCount - 1; i >= 0; i-- {
+		if username == "" || strings.EqualFold(global.LoginAttempts[i].Username, username) {
+			(*attempts)[n] = global.LoginAttempts[i]
+			n++
+		}
+	}
+
+	return n, nil
+}
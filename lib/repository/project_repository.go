@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// projectRepository implements the ProjectRepository interface using an in-memory
+// storage mechanism for project data.
+type projectRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+}
+
+// ProjectRepository defines the interface for project data operations.
+// Comments belong to a project through their ProjectId; the active project scopes the
+// comment read operations of the CommentRepository.
+type ProjectRepository interface {
+	// GetAllProjects retrieves all projects, packed from index 0.
+	// It returns the number of projects.
+	GetAllProjects(projects *[255]model.Project) (int, error)
+
+	// Create adds a new project and writes its ID back to project.Id.
+	// Returns an error if the name is already used or the storage is full.
+	Create(project *model.Project) error
+
+	// FindProjectById retrieves a single project by its ID.
+	FindProjectById(projectId int, project *model.Project) error
+}
+
+// NewProjectRepository creates and returns a new ProjectRepository implementation.
+//
+// Parameters:
+//   - journal: The journal every mutation is recorded to, may be nil
+//
+// Returns:
+//   - ProjectRepository: A new instance of the projectRepository implementation
+func NewProjectRepository(journal JournalRepository) ProjectRepository {
+	return &projectRepository{
+		journal: journal,
+	}
+}
+
+// GetAllProjects copies every project into the provided array, packed from index 0.
+//
+// Parameters:
+//   - projects: A pointer to an array that will be filled with all projects
+//
+// Returns:
+//   - int: The number of projects
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (p *projectRepository) GetAllProjects(projects *[255]model.Project) (int, error) {
+	defer readStore()()
+
+	*projects = global.Projects
+	return global.ProjectCount, nil
+}
+
+// Create adds a new project to the in-memory repository.
+// Projects cannot be deleted, so the ID is simply the position of the project plus one.
+// If the project has no CreatedAt time yet, the current time is used.
+//
+// Parameters:
+//   - project: A pointer to the Project model to be stored
+//
+// Returns:
+//   - error: An error if the name is already used, the storage is full or the mutation
+//     cannot be written to the journal, nil otherwise
+func (p *projectRepository) Create(project *model.Project) error {
+	defer lockStore()()
+
+	if global.ProjectCount >= len(global.Projects) {
+		return fmt.Errorf("project storage is full (%d projects)", len(global.Projects))
+	}
+
+	for i := 0; i < global.ProjectCount; i++ {
+		if global.Projects[i].Nama == project.Nama {
+			return fmt.Errorf("project %q already exists", project.Nama)
+		}
+	}
+
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = time.Now()
+	}
+
+	project.Id = global.ProjectCount + 1
+	global.Projects[global.ProjectCount] = *project
+	global.ProjectCount++
+
+	return record(p.journal, model.JournalEntry{
+		Command: "create_project",
+		Project: &model.Project{Nama: project.Nama, CreatedAt: project.CreatedAt},
+	})
+}
+
+// FindProjectById retrieves a single project by its ID.
+//
+// Parameters:
+//   - projectId: The ID of the project to find
+//   - project: A pointer to a Project model that will be populated with the found project's data
+//
+// Returns:
+//   - error: An error if the project is not found, nil on success
+func (p *projectRepository) FindProjectById(projectId int, project *model.Project) error {
+	defer readStore()()
+
+	for i := 0; i < global.ProjectCount; i++ {
+		if global.Projects[i].Id == projectId {
+			*project = global.Projects[i]
+			return nil
+		}
+	}
+
+	return fmt.Errorf("project with ID %d not found", projectId)
+}
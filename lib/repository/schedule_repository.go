@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// scheduleRepository implements the ScheduleRepository interface using an in-memory
+// queue of scheduled comments.
+type scheduleRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+
+	// comments stores the comments once they are published
+	comments *commentRepository
+}
+
+// ScheduleRepository defines the interface for the queue of scheduled comments.
+// A scheduled comment is invisible until it is published into the CommentRepository.
+type ScheduleRepository interface {
+	// Schedule queues a comment to be published at the given time.
+	Schedule(comment model.Comment, userId int, publishAt time.Time) error
+
+	// GetScheduledByUserId retrieves the queued comments of a user, packed from index 0.
+	// It returns the number of comments found.
+	GetScheduledByUserId(userId int, scheduled *[255]model.ScheduledComment) (int, error)
+
+	// PublishDue moves every queued comment whose time has come into the comment store.
+	// It returns the number of comments published.
+	PublishDue(now time.Time) (int, error)
+}
+
+// NewScheduleRepository creates and returns a new ScheduleRepository implementation.
+//
+// Parameters:
+//   - journal: The journal every mutation is recorded to, may be nil
+//   - ids: The ID generator that issues the IDs of the published comments
+//
+// Returns:
+//   - ScheduleRepository: A new instance of the scheduleRepository implementation
+func NewScheduleRepository(journal JournalRepository, ids IDGenerator) ScheduleRepository {
+	return &scheduleRepository{
+		journal:  journal,
+		comments: &commentRepository{journal: journal, ids: ids},
+	}
+}
+
+// Schedule queues a comment to be published at the given time.
+// The queued comment gets the highest ID in the queue plus one. If the comment has no
+// project yet it is added to the active project, so it is published to the project it
+// was written for.
+//
+// Parameters:
+//   - comment: The comment to publish
+//   - userId: The ID of the user who wrote the comment
+//   - publishAt: The time the comment becomes visible
+//
+// Returns:
+//   - error: An error if the queue is full or the mutation cannot be written to the journal
+func (s *scheduleRepository) Schedule(comment model.Comment, userId int, publishAt time.Time) error {
+	defer lockStore()()
+
+	if global.ScheduledCount >= len(global.ScheduledComments) {
+		return fmt.Errorf("schedule queue is full (%d comments)", len(global.ScheduledComments))
+	}
+
+	if comment.ProjectId == 0 {
+		comment.ProjectId = global.ActiveProjectId
+	}
+
+	id := 1
+	for i := 0; i < global.ScheduledCount; i++ {
+		if global.ScheduledComments[i].Id >= id {
+			id = global.ScheduledComments[i].Id + 1
+		}
+	}
+
+	scheduled := model.ScheduledComment{
+		Id:        id,
+		UserId:    userId,
+		Comment:   comment,
+		PublishAt: publishAt,
+	}
+	global.ScheduledComments[global.ScheduledCount] = scheduled
+	global.ScheduledCount++
+
+	return record(s.journal, model.JournalEntry{
+		Command:   "schedule_comment",
+		Scheduled: &scheduled,
+	})
+}
+
+// GetScheduledByUserId copies the queued comments of a user into the provided array,
+// packed from index 0, in the order they were scheduled.
+//
+// Parameters:
+//   - userId: The ID of the user whose queued comments to retrieve
+//   - scheduled: A pointer to an array that will be filled with the queued comments
+//
+// Returns:
+//   - int: The number of queued comments of the user
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (s *scheduleRepository) GetScheduledByUserId(userId int, scheduled *[255]model.ScheduledComment) (int, error) {
+	defer readStore()()
+
+	n := 0
+	for i := 0; i < global.ScheduledCount; i++ {
+		if global.ScheduledComments[i].UserId == userId {
+			(*scheduled)[n] = global.ScheduledComments[i]
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// PublishDue moves every queued comment whose publish time is not after now into the
+// comment store. The published comment is created with its publish time as CreatedAt.
+// Each comment is first created, which records its own "create_comment" entry, and only
+// then removed from the queue and recorded as "publish_scheduled". A comment that cannot
+// be created, e.g. because the storage is full, stays queued for the next run.
+//
+// PublishDue runs in the background, so it holds the store lock for the whole run and
+// never runs in the middle of a transaction, see holdStore.
+//
+// Parameters:
+//   - now: The current time
+//
+// Returns:
+//   - int: The number of comments published
+//   - error: An error if a comment cannot be created or removed from the queue
+func (s *scheduleRepository) PublishDue(now time.Time) (int, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	published := 0
+
+	for i := 0; i < global.ScheduledCount; {
+		scheduled := global.ScheduledComments[i]
+		if scheduled.PublishAt.After(now) {
+			i++
+			continue
+		}
+
+		comment := scheduled.Comment
+		comment.CreatedAt = scheduled.PublishAt
+		err := s.comments.create(&comment, scheduled.UserId)
+		if err != nil {
+			return published, err
+		}
+
+		err = s.remove(scheduled.Id)
+		if err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// remove takes a queued comment out of the queue by shifting the following entries up.
+// The caller holds the store lock.
+//
+// Parameters:
+//   - id: The ID of the queued comment
+//
+// Returns:
+//   - error: An error if the comment is not queued or the mutation cannot be written to the journal
+func (s *scheduleRepository) remove(id int) error {
+	for i := 0; i < global.ScheduledCount; i++ {
+		if global.ScheduledComments[i].Id == id {
+			for j := i; j < global.ScheduledCount-1; j++ {
+				global.ScheduledComments[j] = global.ScheduledComments[j+1]
+			}
+			global.ScheduledCount--
+			global.ScheduledComments[global.ScheduledCount] = model.ScheduledComment{}
+
+			return record(s.journal, model.JournalEntry{
+				Command: "publish_scheduled",
+				Id:      id,
+			})
+		}
+	}
+
+	return fmt.Errorf("scheduled comment with ID %d not found", id)
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// invertedIndex maps every word of the stored comments to the IDs of the comments
+// that contain it, so a keyword search only has to look up the words of the query
+// instead of scanning the text of every comment.
+//
+// The index is kept up to date by the comment repository on create, edit and delete,
+// and rebuilt from the storage whenever the storage is replaced as a whole (journal
+// replay, transaction rollback, integrity repair and compaction).
+type invertedIndex struct {
+	mu    sync.RWMutex
+	words map[string]map[int]bool
+	terms map[int][]string
+}
+
+// commentIndex is the inverted index of global.Comments.
+var commentIndex = &invertedIndex{
+	words: make(map[string]map[int]bool),
+	terms: make(map[int][]string),
+}
+
+// indexWords splits a text into lowercase words of letters and digits.
+//
+// Parameters:
+//   - text: The text to split
+//
+// Returns:
+//   - []string: The words in the order they appear, duplicates included
+func indexWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// add indexes the words of a comment, replacing the words indexed for it before.
+//
+// Parameters:
+//   - comment: The stored comment
+func (x *invertedIndex) add(comment model.Comment) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.unlink(comment.Id)
+
+	words := indexWords(comment.Komentar)
+	for _, word := range words {
+		if x.words[word] == nil {
+			x.words[word] = make(map[int]bool)
+		}
+		x.words[word][comment.Id] = true
+	}
+	x.terms[comment.Id] = words
+}
+
+// remove drops a comment from the index.
+//
+// Parameters:
+//   - commentId: The ID of the deleted comment
+func (x *invertedIndex) remove(commentId int) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.unlink(commentId)
+}
+
+// unlink removes the postings of a comment. The caller must hold the write lock.
+//
+// Parameters:
+//   - commentId: The ID of the comment
+func (x *invertedIndex) unlink(commentId int) {
+	for _, word := range x.terms[commentId] {
+		delete(x.words[word], commentId)
+		if len(x.words[word]) == 0 {
+			delete(x.words, word)
+		}
+	}
+	delete(x.terms, commentId)
+}
+
+// rebuild discards the index and indexes every comment in global.Comments again.
+func (x *invertedIndex) rebuild() {
+	x.mu.Lock()
+	x.words = make(map[string]map[int]bool)
+	x.terms = make(map[int][]string)
+	x.mu.Unlock()
+
+	for i := 0; i < global.CommentCount; i++ {
+		x.add(global.Comments[i])
+	}
+}
+
+// match returns the IDs of the comments that contain every word of the query.
+// A query word also matches the words it is a prefix of, so "lamb" finds "lambat".
+//
+// Parameters:
+//   - query: The search text, split into words like the comments
+//
+// Returns:
+//   - map[int]bool: The matching comment IDs, nil when the query has no words
+func (x *invertedIndex) match(query string) map[int]bool {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var result map[int]bool
+	for _, term := range indexWords(query) {
+		hits := make(map[int]bool)
+		for word, ids := range x.words {
+			if !strings.HasPrefix(word, term) {
+				continue
+			}
+			for id := range ids {
+				if result == nil || result[id] {
+					hits[id] = true
+				}
+			}
+		}
+
+		result = hits
+		if len(result) == 0 {
+			break
+		}
+	}
+
+	return result
+}
+
+// RankSearch searches the comments of the active project and ranks the matches.
+//
+// A comment matches when every search word occurs in it. Each search word scores 3 when
+// it equals a word of the comment, 2 when it starts a word and 1 when it only occurs
+// inside a word. The relevance is the average score of the search words plus a recency
+// boost of 1/(1+days/30), so of two equally good matches the newer one comes first.
+// A search without words matches every comment and is ordered by recency only.
+//
+// Unlike SearchComments the matches inside words are found too, so every comment is
+// scanned instead of looking the words up in the inverted index.
+//
+// Parameters:
+//   - search: The words to search for
+//   - results: A pointer to an array that will be filled with the results, packed from index 0
+//
+// Returns:
+//   - int: The number of results
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (c *commentRepository) RankSearch(search string, results *[255]model.SearchResult) (int, error) {
+	defer readStore()()
+
+	terms := indexWords(search)
+	now := time.Now()
+
+	var matches []model.SearchResult
+	for i := 0; i < global.CommentCount; i++ {
+		comment := global.Comments[i]
+		if !inActiveProject(comment) {
+			continue
+		}
+
+		words := indexWords(comment.Komentar)
+
+		total := 0
+		for _, term := range terms {
+			score := matchScore(term, words)
+			if score == 0 {
+				total = -1
+				break
+			}
+			total += score
+		}
+		if total < 0 {
+			continue
+		}
+
+		relevance := 0.0
+		if len(terms) > 0 {
+			relevance = float64(total) / float64(len(terms))
+		}
+
+		days := math.Max(0, now.Sub(comment.CreatedAt).Hours()/24)
+		relevance += 1 / (1 + days/30)
+
+		matches = append(matches, model.SearchResult{Comment: comment, Relevance: relevance})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Relevance > matches[j].Relevance
+	})
+
+	n := copy(results[:], matches)
+
+	return n, nil
+}
+
+// matchScore scores how well a search word matches the words of a comment.
+//
+// Parameters:
+//   - term: The lowercase search word
+//   - words: The lowercase words of the comment
+//
+// Returns:
+//   - int: 3 for an exact word, 2 for the start of a word, 1 for a match inside a word, 0 for no match
+func matchScore(term string, words []string) int {
+	best := 0
+	for _, word := range words {
+		switch {
+		case word == term:
+			return 3
+		case strings.HasPrefix(word, term):
+			best = max(best, 2)
+		case strings.Contains(word, term):
+			best = max(best, 1)
+		}
+	}
+
+	return best
+}
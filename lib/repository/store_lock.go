@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// storeMu serializes every change to the in-memory store and the journal, so a
+// background job such as the publisher of scheduled comments never interleaves with
+// the changes made from the menu or the API. The getters take it for reading while
+// they copy out of the store, see readStore.
+var storeMu sync.RWMutex
+
+// storeHeld is set while a unit of work holds storeMu, see holdStore.
+var storeHeld atomic.Bool
+
+// lockStore takes the store lock for a single mutation and returns the function that
+// releases it, so a mutation starts with defer lockStore()().
+//
+// Inside a unit of work the lock is already held for the whole unit, so the mutation
+// runs without taking it again. Units of work only run in the foreground, and the
+// background jobs take storeMu directly and never call a locking mutation while they
+// hold it, so a mutation that finds the lock held always belongs to the running unit.
+//
+// Returns:
+//   - func(): Releases the lock, a no-op inside a unit of work
+func lockStore() func() {
+	if storeHeld.Load() {
+		return func() {}
+	}
+
+	storeMu.Lock()
+
+	return storeMu.Unlock
+}
+
+// readStore takes the store lock for reading for a getter that copies out of the store,
+// and returns the function that releases it, so a getter starts with
+// defer readStore()().
+//
+// Like lockStore it is a no-op inside a unit of work. A getter never calls another
+// getter or a callback while it holds the lock: a second read lock would wait behind a
+// background job that waits for the lock, and a callback may change the store, so
+// ForEachUser and ForEachComment copy the records first and call fn without the lock.
+//
+// Returns:
+//   - func(): Releases the lock, a no-op inside a unit of work
+func readStore() func() {
+	if storeHeld.Load() {
+		return func() {}
+	}
+
+	storeMu.RLock()
+
+	return storeMu.RUnlock
+}
+
+// holdStore runs fn as a unit of work that holds the store lock from start to end, so
+// no background job changes the store between its steps. This is used by transactions,
+// replays and compactions, which read the store as a whole. A unit started inside
+// another one simply runs as part of it.
+//
+// Parameters:
+//   - fn: The unit of work; the mutations it makes do not take the lock again
+//
+// Returns:
+//   - error: The error returned by fn
+func holdStore(fn func() error) error {
+	if storeHeld.Load() {
+		return fn()
+	}
+
+	storeMu.Lock()
+	storeHeld.Store(true)
+	defer func() {
+		storeHeld.Store(false)
+		storeMu.Unlock()
+	}()
+
+	return fn()
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"fmt"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/model"
+)
+
+// templateRepository implements the TemplateRepository interface using an in-memory
+// storage mechanism for comment templates.
+type templateRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+}
+
+// TemplateRepository defines the interface for comment template data operations.
+type TemplateRepository interface {
+	// GetAllTemplates retrieves all templates, packed from index 0.
+	// It returns the number of templates.
+	GetAllTemplates(templates *[255]model.Template) (int, error)
+
+	// Create adds a new template and writes its ID back to template.Id.
+	// Returns an error if the name is already used or the storage is full.
+	Create(template *model.Template) error
+
+	// DeleteTemplate removes the template with the specified ID.
+	DeleteTemplate(templateId int) error
+}
+
+// NewTemplateRepository creates and returns a new TemplateRepository implementation.
+//
+// Parameters:
+//   - journal: The journal every mutation is recorded to, may be nil
+//
+// Returns:
+//   - TemplateRepository: A new instance of the templateRepository implementation
+func NewTemplateRepository(journal JournalRepository) TemplateRepository {
+	return &templateRepository{
+		journal: journal,
+	}
+}
+
+// GetAllTemplates copies every template into the provided array, packed from index 0.
+//
+// Parameters:
+//   - templates: A pointer to an array that will be filled with all templates
+//
+// Returns:
+//   - int: The number of templates
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (t *templateRepository) GetAllTemplates(templates *[255]model.Template) (int, error) {
+	defer readStore()()
+
+	*templates = global.Templates
+	return global.TemplateCount, nil
+}
+
+// Create adds a new template to the in-memory repository.
+// The template gets the highest existing template ID plus one.
+//
+// Parameters:
+//   - template: A pointer to the Template model to be stored
+//
+// Returns:
+//   - error: An error if the name is already used, the storage is full or the mutation
+//     cannot be written to the journal, nil otherwise
+func (t *templateRepository) Create(template *model.Template) error {
+	defer lockStore()()
+
+	if global.TemplateCount >= len(global.Templates) {
+		return fmt.Errorf("template storage is full (%d templates)", len(global.Templates))
+	}
+
+	id := 1
+	for i := 0; i < global.TemplateCount; i++ {
+		if global.Templates[i].Nama == template.Nama {
+			return fmt.Errorf("template %q already exists", template.Nama)
+		}
+
+		if global.Templates[i].Id >= id {
+			id = global.Templates[i].Id + 1
+		}
+	}
+
+	template.Id = id
+	global.Templates[global.TemplateCount] = *template
+	global.TemplateCount++
+
+	return record(t.journal, model.JournalEntry{
+		Command:  "create_template",
+		Template: &model.Template{Nama: template.Nama, Komentar: template.Komentar, Kategori: template.Kategori},
+	})
+}
+
+// DeleteTemplate removes a template by shifting all subsequent templates up by one
+// position in the array and decrementing the template count.
+//
+// Parameters:
+//   - templateId: The ID of the template to delete
+//
+// Returns:
+//   - error: An error if the template is not found, nil on success
+func (t *templateRepository) DeleteTemplate(templateId int) error {
+	defer lockStore()()
+
+	for i := 0; i < global.TemplateCount; i++ {
+		if global.Templates[i].Id == templateId {
+			for j := i; j < global.TemplateCount-1; j++ {
+				global.Templates[j] = global.Templates[j+1]
+			}
+			global.TemplateCount--
+			global.Templates[global.TemplateCount] = model.Template{}
+
+			return record(t.journal, model.JournalEntry{
+				Command: "delete_template",
+				Id:      templateId,
+			})
+		}
+	}
+
+	return fmt.Errorf("template with ID %d not found", templateId)
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"fmt"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+)
+
+// transactionRepository implements the TransactionRepository interface for the
+// in-memory store by taking a snapshot of the store and a checkpoint of the journal.
+type transactionRepository struct {
+	journal JournalRepository
+	ids     IDGenerator
+}
+
+// TransactionRepository defines the interface for running multi-step operations as a
+// single unit of work. Either every step is applied or, when a step fails, the store
+// and the journal are rolled back to the state they had before the operation started.
+type TransactionRepository interface {
+	// Run executes fn as a single unit of work.
+	// If fn returns an error every change made by fn is rolled back and the error is returned.
+	Run(fn func() error) error
+}
+
+// storeSnapshot holds a copy of the complete in-memory store.
+type storeSnapshot struct {
+	users          [255]model.User
+	comments       [255]model.Comment
+	revisions      [255]model.CommentRevision
+	projects       [255]model.Project
+	projectCount   int
+	scheduled      [255]model.ScheduledComment
+	scheduledCount int
+	templates      [255]model.Template
+	templateCount  int
+	bookmarks      [255]model.Bookmark
+	bookmarkCount  int
+	logins         [255]model.LoginAttempt
+	loginCount     int
+	userCount      int
+	commentCount   int
+	revisionCount  int
+	lastUserId     int
+	lastCommentId  int
+}
+
+// NewTransactionRepository creates and returns a new TransactionRepository implementation.
+//
+// Parameters:
+//   - journal: The journal whose entries are rolled back together with the store, may be nil
+//   - ids: The ID generator whose counters are rolled back together with the store
+//
+// Returns:
+//   - TransactionRepository: A new instance of the transactionRepository implementation
+func NewTransactionRepository(journal JournalRepository, ids IDGenerator) TransactionRepository {
+	return &transactionRepository{
+		journal: journal,
+		ids:     ids,
+	}
+}
+
+// Run executes fn as a single unit of work.
+//
+// Before fn runs, the in-memory store is copied and the current end of the journal is
+// remembered. When fn returns an error the copy is restored and the journal is truncated
+// back to the checkpoint, so neither the store nor a later replay sees a partial operation.
+// The same happens when fn panics, after which the panic continues.
+// The transaction holds the store lock until it ends, see holdStore, so transactions run
+// one at a time and a background job cannot change the store in between; a rollback
+// therefore never undoes a change that was not made by fn.
+//
+// Parameters:
+//   - fn: The operation to run; it performs its steps through the normal repositories
+//
+// Returns:
+//   - error: The error returned by fn, or an error if the journal cannot be checkpointed
+//     or rolled back, nil when every step was applied
+func (t *transactionRepository) Run(fn func() error) error {
+	return holdStore(func() error {
+		return t.run(fn)
+	})
+}
+
+// run executes fn as a single unit of work while the store lock is held, see Run.
+//
+// Parameters:
+//   - fn: The operation to run
+//
+// Returns:
+//   - error: The error returned by fn, or an error if the journal cannot be checkpointed
+//     or rolled back, nil when every step was applied
+func (t *transactionRepository) run(fn func() error) error {
+	defer helper.TraceTime("transaksi")()
+
+	snapshot := takeSnapshot(t.ids)
+
+	var checkpoint int64
+	if t.journal != nil {
+		var err error
+		checkpoint, err = t.journal.Checkpoint()
+		if err != nil {
+			return err
+		}
+	}
+
+	// A panic inside fn rolls back like an error before it continues to unwind
+	defer func() {
+		if r := recover(); r != nil {
+			snapshot.restore(t.ids)
+			if t.journal != nil {
+				t.journal.Rollback(checkpoint)
+			}
+			panic(r)
+		}
+	}()
+
+	err := fn()
+	if err == nil {
+		return nil
+	}
+
+	snapshot.restore(t.ids)
+	helper.Trace("transaksi dibatalkan: %v", err)
+
+	if t.journal != nil {
+		rollbackErr := t.journal.Rollback(checkpoint)
+		if rollbackErr != nil {
+			return fmt.Errorf("%v (rollback failed: %v)", err, rollbackErr)
+		}
+	}
+
+	return err
+}
+
+// takeSnapshot copies the complete in-memory store.
+//
+// Parameters:
+//   - ids: The ID generator whose counters are part of the snapshot
+//
+// Returns:
+//   - storeSnapshot: The copy of the store
+func takeSnapshot(ids IDGenerator) storeSnapshot {
+	return storeSnapshot{
+		users:          global.Users,
+		comments:       global.Comments,
+		revisions:      global.CommentRevisions,
+		projects:       global.Projects,
+		projectCount:   global.ProjectCount,
+		scheduled:      global.ScheduledComments,
+		scheduledCount: global.ScheduledCount,
+		templates:      global.Templates,
+		templateCount:  global.TemplateCount,
+		bookmarks:      global.Bookmarks,
+		bookmarkCount:  global.BookmarkCount,
+		logins:         global.LoginAttempts,
+		loginCount:     global.LoginAttemptCount,
+		userCount:      global.UserCount,
+		commentCount:   global.CommentCount,
+		revisionCount:  global.RevisionCount,
+		lastUserId:     ids.LastUserId(),
+		lastCommentId:  ids.LastCommentId(),
+	}
+}
+
+// restore writes the snapshot back to the in-memory store and the ID generator.
+func (s storeSnapshot) restore(ids IDGenerator) {
+	global.Users = s.users
+	global.Comments = s.comments
+	global.CommentRevisions = s.revisions
+	global.Projects = s.projects
+	global.ProjectCount = s.projectCount
+	global.ScheduledComments = s.scheduled
+	global.ScheduledCount = s.scheduledCount
+	global.Templates = s.templates
+	global.TemplateCount = s.templateCount
+	global.Bookmarks = s.bookmarks
+	global.BookmarkCount = s.bookmarkCount
+	global.LoginAttempts = s.logins
+	global.LoginAttemptCount = s.loginCount
+	global.UserCount = s.userCount
+	global.CommentCount = s.commentCount
+	global.RevisionCount = s.revisionCount
+	ids.Reset(s.lastUserId, s.lastCommentId)
+	commentIndex.rebuild()
+	usernameIndex.rebuild()
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"fmt"
 	"strings"
+	"time"
+
 	"tugas-besar/lib/global"
 	"tugas-besar/lib/model"
 )
@@ -10,6 +12,15 @@ import (
 // userRepository implements the UserRepository interface using an in-memory
 // storage mechanism for user data.
 type userRepository struct {
+	// journal records every mutation so the store can be replayed, may be nil
+	journal JournalRepository
+
+	// ids issues the IDs of new users
+	ids IDGenerator
+
+	// replaying is set while journal entries are applied. The UUIDs recorded in the
+	// journal are kept as they are instead of generating new ones, see assignUUIDs.
+	replaying bool
 }
 
 // UserRepository defines the interface for user data operations.
@@ -33,6 +44,17 @@ type UserRepository interface {
 	// currently stored in the system.
 	GetAllUsers(users *[255]model.User) error
 
+	// CompleteUsername returns up to limit usernames that start with prefix, ignoring case.
+	CompleteUsername(prefix string, limit int) []string
+
+	// GetUsers retrieves one page of the users, packed from index 0,
+	// and returns the number of users copied.
+	GetUsers(offset int, limit int, users *[255]model.User) (int, error)
+
+	// ForEachUser calls fn for every user, in storage order, until fn returns false.
+	// Unlike GetAllUsers no fixed-size array is filled.
+	ForEachUser(fn func(user model.User) bool) error
+
 	// SearchUsers finds users whose usernames contain the specified search string.
 	// It performs a case-insensitive substring search on all usernames and
 	// populates the provided array with matching user records.
@@ -47,38 +69,85 @@ type UserRepository interface {
 	// It deletes the user at the specified index and shifts all subsequent users
 	// to maintain contiguous storage, then decrements the global user count.
 	DeleteUser(id int) error
+
+	// MarkUserDeleted marks the user at the specified index as pending deletion.
+	// The user stays in storage but is hidden and cannot log in until restored.
+	MarkUserDeleted(index int) error
+
+	// RestoreUser clears the pending deletion of the user at the specified index.
+	RestoreUser(index int) error
+
+	// SetUserRole changes the admin role of the user at the specified index.
+	// An empty role makes the user a regular user again.
+	SetUserRole(index int, role string) error
 }
 
 // NewUserRepository creates and returns a new UserRepository implementation.
 //
+// Parameters:
+//   - journal: The journal that records every mutation, nil disables journaling
+//   - ids: The generator that issues the IDs of new users
+//
 // Returns:
 //   - UserRepository: A new instance of the userRepository implementation
-func NewUserRepository() UserRepository {
-	return &userRepository{}
+func NewUserRepository(journal JournalRepository, ids IDGenerator) UserRepository {
+	return &userRepository{
+		journal: journal,
+		ids:     ids,
+	}
 }
 
 // Create adds a new user to the in-memory repository.
-// The user is assigned the next available index in the global user storage.
+// The user is assigned the next available index in the global user storage and a
+// new ID from the ID generator, which is also written back to user.Id. When UUIDs are
+// enabled and the user has no UUID yet, a new one is generated, except while replaying.
+// If the user has no CreatedAt time yet, the current time is used.
 //
 // Parameters:
 //   - user: A pointer to the User model to be stored
 //
 // Returns:
-//   - error: Always returns nil as this implementation doesn't have failure cases
+//   - error: An error if the storage is full or the mutation cannot be written to the journal, nil otherwise
 func (repo *userRepository) Create(user *model.User) error {
+	defer lockStore()()
+
+	if global.UserCount >= len(global.Users) {
+		return fmt.Errorf("user storage is full (%d users)", len(global.Users))
+	}
+
+	createdAt := user.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	user.Id = repo.ids.NextUserId()
+	if user.Uuid == "" && !repo.replaying {
+		user.Uuid = repo.ids.NewUUID()
+	}
+
 	global.Users[global.UserCount] = model.User{
-		Id:       global.IdUserIncrement + 1,
-		Username: user.Username,
-		Password: user.Password,
+		Id:        user.Id,
+		Uuid:      user.Uuid,
+		Username:  user.Username,
+		Password:  user.Password,
+		CreatedAt: createdAt,
+		Version:   1,
+
+		MustChangePassword: user.MustChangePassword,
+		Role:               user.Role,
 	}
+	usernameIndex.insert(global.Users[global.UserCount].Username)
 	global.UserCount++
-	global.IdUserIncrement++
 
-	return nil
+	return record(repo.journal, model.JournalEntry{
+		Command: "create_user",
+		User:    &model.User{Uuid: user.Uuid, Username: user.Username, Password: user.Password, CreatedAt: createdAt, MustChangePassword: user.MustChangePassword, Role: user.Role},
+	})
 }
 
 // FindUserByUsername searches for a user by their username in the repository.
 // If found, it populates the provided user model with the user's data.
+// Users pending deletion are not found, so they cannot log in.
 //
 // Parameters:
 //   - username: The username to search for
@@ -87,8 +156,10 @@ func (repo *userRepository) Create(user *model.User) error {
 // Returns:
 //   - error: An error with a descriptive message if the user is not found, nil otherwise
 func (repo *userRepository) FindUserByUsername(username string, user *model.User) error {
+	defer readStore()()
+
 	for i := 0; i < global.UserCount; i++ {
-		if global.Users[i].Username == username {
+		if global.Users[i].Username == username && global.Users[i].DeletedAt.IsZero() {
 			*user = global.Users[i]
 			return nil
 		}
@@ -99,6 +170,8 @@ func (repo *userRepository) FindUserByUsername(username string, user *model.User
 
 // IsUserExists checks if a user with the specified username exists in the repository.
 // It iterates through all users in the global storage and compares usernames.
+// Users pending deletion are counted, so their username stays reserved until they are
+// removed permanently.
 //
 // Parameters:
 //   - username: The username to search for
@@ -106,6 +179,8 @@ func (repo *userRepository) FindUserByUsername(username string, user *model.User
 // Returns:
 //   - bool: true if a user with the given username exists, false otherwise
 func (repo *userRepository) IsUserExists(username string, exceptId int) bool {
+	defer readStore()()
+
 	for i := 0; i < global.UserCount; i++ {
 		if global.Users[i].Username == username && i != exceptId {
 			return true
@@ -126,11 +201,78 @@ func (repo *userRepository) IsUserExists(username string, exceptId int) bool {
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (repo *userRepository) GetAllUsers(users *[255]model.User) error {
+	defer readStore()()
+
 	*users = global.Users
 
 	return nil
 }
 
+// CompleteUsername autocompletes a username from the prefix tree of the usernames,
+// so a few typed characters are enough to find the user.
+//
+// Parameters:
+//   - prefix: The typed start of the username, case is ignored
+//   - limit: The maximum number of usernames to return
+//
+// Returns:
+//   - []string: The matching usernames in alphabetical order
+func (repo *userRepository) CompleteUsername(prefix string, limit int) []string {
+	defer readStore()()
+
+	return usernameIndex.complete(prefix, limit)
+}
+
+// GetUsers copies one page of the users into the provided array, packed from index 0,
+// so a table or an API response only receives the rows it shows.
+//
+// Parameters:
+//   - offset: The number of users to skip
+//   - limit: The maximum number of users to copy, at most the size of the array
+//   - users: A pointer to an array that will be filled with the page
+//
+// Returns:
+//   - int: The number of users copied, 0 when offset is past the last user
+//   - error: An error if offset or limit is negative, nil otherwise
+func (repo *userRepository) GetUsers(offset int, limit int, users *[255]model.User) (int, error) {
+	defer readStore()()
+
+	if offset < 0 || limit < 0 {
+		return 0, fmt.Errorf("invalid page: offset %d, limit %d", offset, limit)
+	}
+
+	n := 0
+	for i := offset; i < global.UserCount && n < min(limit, len(users)); i++ {
+		(*users)[n] = global.Users[i]
+		n++
+	}
+
+	return n, nil
+}
+
+// ForEachUser streams every user to fn, in storage order.
+// The iteration stops early when fn returns false. fn is called on a copy of the users,
+// without the store lock, so it may use the repository, see readStore.
+//
+// Parameters:
+//   - fn: The function called with each user, returning false stops the iteration
+//
+// Returns:
+//   - error: Always returns nil as this implementation doesn't have failure cases
+func (repo *userRepository) ForEachUser(fn func(user model.User) bool) error {
+	release := readStore()
+	users, count := global.Users, global.UserCount
+	release()
+
+	for i := 0; i < count; i++ {
+		if !fn(users[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
 // SearchUsers finds users whose usernames contain the specified search string.
 //
 // This implementation performs a manual case-insensitive substring search on usernames.
@@ -141,6 +283,7 @@ func (repo *userRepository) GetAllUsers(users *[255]model.User) error {
 //
 // The function uses a character-by-character comparison rather than built-in string
 // functions like strings.Contains() to implement the substring search.
+// Users pending deletion are skipped.
 //
 // Parameters:
 //   - search: The substring to search for within usernames
@@ -149,9 +292,15 @@ func (repo *userRepository) GetAllUsers(users *[255]model.User) error {
 // Returns:
 //   - error: Always returns nil as this implementation doesn't have failure cases
 func (repo *userRepository) SearchUsers(search string, users *[255]model.User) error {
+	defer readStore()()
+
 	searchLower := strings.ToLower(search)
 
 	for i := 0; i < global.UserCount; i++ {
+		if !global.Users[i].DeletedAt.IsZero() {
+			continue
+		}
+
 		usernameLower := strings.ToLower(global.Users[i].Username)
 
 		for j := 0; j <= len(usernameLower)-len(searchLower); j++ {
@@ -178,30 +327,50 @@ func (repo *userRepository) SearchUsers(search string, users *[255]model.User) e
 //
 // This implementation performs a partial update of the user data at the given index.
 // Only non-empty fields in the data parameter will overwrite existing values.
-// Currently, only Username and Password fields can be updated.
+// Currently, only Username and Password fields can be updated. A new password also
+// replaces MustChangePassword, so a password set by the admin can force another change
+// and a password chosen by the user clears it.
 //
 // Parameters:
 //   - index: The array index of the user to be updated
 //   - data: A User model containing the fields to update (empty fields are ignored)
+//     and the version that was read
 //
 // Returns:
-//   - error: An error if the index is out of bounds, nil on success
+//   - error: An error if the index is out of bounds or the user was modified since it
+//     was read (wrapping ErrVersionConflict), nil on success
 func (repo *userRepository) EditUser(index int, data model.User) error {
+	defer lockStore()()
+
 	if index < 0 || index >= global.UserCount {
 		return fmt.Errorf("index %d out of bounds", index)
 	}
 
 	user := &global.Users[index]
 
+	err := checkVersion("user", user.Id, data.Version, user.Version)
+	if err != nil {
+		return err
+	}
+
+	user.Version++
+
 	if data.Username != "" {
+		usernameIndex.delete(user.Username)
 		user.Username = data.Username
+		usernameIndex.insert(user.Username)
 	}
 
 	if data.Password != "" {
 		user.Password = data.Password
+		user.MustChangePassword = data.MustChangePassword
 	}
 
-	return nil
+	return record(repo.journal, model.JournalEntry{
+		Command: "edit_user",
+		Index:   index,
+		User:    &data,
+	})
 }
 
 // DeleteUser removes a user from the repository.
@@ -216,10 +385,14 @@ func (repo *userRepository) EditUser(index int, data model.User) error {
 // Returns:
 //   - error: An error if the id is out of bounds, nil on success
 func (repo *userRepository) DeleteUser(id int) error {
+	defer lockStore()()
+
 	if id < 0 || id >= global.UserCount {
 		return fmt.Errorf("id %d out of bounds", id)
 	}
 
+	usernameIndex.delete(global.Users[id].Username)
+
 	for i := id; i < global.UserCount-1; i++ {
 		global.Users[i] = global.Users[i+1]
 	}
@@ -228,5 +401,122 @@ func (repo *userRepository) DeleteUser(id int) error {
 
 	global.UserCount--
 
-	return nil
+	return record(repo.journal, model.JournalEntry{
+		Command: "delete_user",
+		Index:   id,
+	})
+}
+
+// MarkUserDeleted marks a user as pending deletion.
+//
+// The user keeps its place in storage so it can be restored, but it is taken out of the
+// username completion, is no longer found by FindUserByUsername and SearchUsers, and
+// is hidden by the user tables. The permanent removal is done with DeleteUser.
+//
+// Parameters:
+//   - index: The array index of the user to mark
+//
+// Returns:
+//   - error: An error if the index is out of bounds or the user is already pending
+//     deletion, nil on success
+func (repo *userRepository) MarkUserDeleted(index int) error {
+	defer lockStore()()
+
+	if index < 0 || index >= global.UserCount {
+		return fmt.Errorf("index %d out of bounds", index)
+	}
+
+	user := &global.Users[index]
+	if !user.DeletedAt.IsZero() {
+		return fmt.Errorf("user %s is already deleted", user.Username)
+	}
+
+	user.DeletedAt = time.Now()
+	usernameIndex.delete(user.Username)
+
+	return record(repo.journal, model.JournalEntry{
+		Command: "mark_user_deleted",
+		Index:   index,
+	})
+}
+
+// RestoreUser restores a user that is pending deletion, undoing MarkUserDeleted.
+//
+// Parameters:
+//   - index: The array index of the user to restore
+//
+// Returns:
+//   - error: An error if the index is out of bounds or the user is not pending
+//     deletion, nil on success
+func (repo *userRepository) RestoreUser(index int) error {
+	defer lockStore()()
+
+	if index < 0 || index >= global.UserCount {
+		return fmt.Errorf("index %d out of bounds", index)
+	}
+
+	user := &global.Users[index]
+	if user.DeletedAt.IsZero() {
+		return fmt.Errorf("user %s is not deleted", user.Username)
+	}
+
+	user.DeletedAt = time.Time{}
+	usernameIndex.insert(user.Username)
+
+	return record(repo.journal, model.JournalEntry{
+		Command: "restore_user",
+		Index:   index,
+	})
+}
+
+// setUuid assigns a UUID to a user that has none, see assignUUIDs. The user is looked up
+// by ID because its index changes when users before it are deleted.
+//
+// Parameters:
+//   - userId: The ID of the user
+//   - uuid: The UUID to assign
+//
+// Returns:
+//   - error: An error if the user is not found or the journal entry cannot be written
+func (repo *userRepository) setUuid(userId int, uuid string) error {
+	defer lockStore()()
+
+	for i := 0; i < global.UserCount; i++ {
+		if global.Users[i].Id == userId {
+			global.Users[i].Uuid = uuid
+
+			return record(repo.journal, model.JournalEntry{
+				Command: "set_user_uuid",
+				Id:      userId,
+				User:    &model.User{Uuid: uuid},
+			})
+		}
+	}
+
+	return fmt.Errorf("user with ID %d not found", userId)
+}
+
+// SetUserRole changes the admin role of a user. The role is not validated here, the
+// services decide which roles exist.
+//
+// Parameters:
+//   - index: The array index of the user
+//   - role: The new role, empty for a regular user
+//
+// Returns:
+//   - error: An error if the index is out of bounds, nil on success
+func (repo *userRepository) SetUserRole(index int, role string) error {
+	defer lockStore()()
+
+	if index < 0 || index >= global.UserCount {
+		return fmt.Errorf("index %d out of bounds", index)
+	}
+
+	global.Users[index].Role = role
+
+	return record(repo.journal, model.JournalEntry{
+		Command: "set_user_role",
+		Index:   index,
+		User:    &model.User{Role: role},
+	})
 }
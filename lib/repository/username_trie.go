@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"tugas-besar/lib/global"
+)
+
+// trieNode is one character of the usernames stored in a usernameTrie.
+type trieNode struct {
+	children map[rune]*trieNode
+
+	// username is the stored username that ends at this node, empty if none does
+	username string
+}
+
+// usernameTrie is a prefix tree over the usernames, used to autocomplete a username
+// after a few characters without scanning every user.
+//
+// Lookups ignore case. The trie is kept up to date by the user repository on create,
+// edit and delete, and rebuilt from the storage whenever the storage is replaced as a
+// whole (journal replay, transaction rollback, integrity repair and compaction).
+type usernameTrie struct {
+	mu   sync.RWMutex
+	root *trieNode
+}
+
+// usernameIndex is the prefix tree of the usernames in global.Users.
+var usernameIndex = &usernameTrie{root: &trieNode{}}
+
+// insert adds a username to the trie.
+//
+// Parameters:
+//   - username: The username to add
+func (t *usernameTrie) insert(username string) {
+	if username == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	node := t.root
+	for _, r := range strings.ToLower(username) {
+		if node.children == nil {
+			node.children = make(map[rune]*trieNode)
+		}
+		if node.children[r] == nil {
+			node.children[r] = &trieNode{}
+		}
+		node = node.children[r]
+	}
+	node.username = username
+}
+
+// delete removes a username from the trie. Branches that no longer lead to a
+// username are left in place; they are dropped on the next rebuild.
+//
+// Parameters:
+//   - username: The username to remove
+func (t *usernameTrie) delete(username string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	node := t.find(strings.ToLower(username))
+	if node != nil {
+		node.username = ""
+	}
+}
+
+// rebuild discards the trie and inserts every username in global.Users again, except
+// the users pending deletion.
+func (t *usernameTrie) rebuild() {
+	t.mu.Lock()
+	t.root = &trieNode{}
+	t.mu.Unlock()
+
+	for i := 0; i < global.UserCount; i++ {
+		if global.Users[i].DeletedAt.IsZero() {
+			t.insert(global.Users[i].Username)
+		}
+	}
+}
+
+// complete returns the usernames that start with a prefix, in alphabetical order.
+//
+// Parameters:
+//   - prefix: The typed start of the username, case is ignored
+//   - limit: The maximum number of usernames to return
+//
+// Returns:
+//   - []string: The matching usernames, empty when none match
+func (t *usernameTrie) complete(prefix string, limit int) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var usernames []string
+
+	node := t.find(strings.ToLower(prefix))
+	if node == nil || limit < 1 {
+		return usernames
+	}
+
+	collect(node, &usernames)
+	sort.Slice(usernames, func(i, j int) bool {
+		return strings.ToLower(usernames[i]) < strings.ToLower(usernames[j])
+	})
+
+	return usernames[:min(limit, len(usernames))]
+}
+
+// find walks the trie along a lowercase key. The caller must hold a lock.
+//
+// Parameters:
+//   - key: The lowercase characters to follow
+//
+// Returns:
+//   - *trieNode: The node of the last character, nil if the key is not in the trie
+func (t *usernameTrie) find(key string) *trieNode {
+	node := t.root
+	for _, r := range key {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+// collect appends every username stored in a subtree.
+//
+// Parameters:
+//   - node: The root of the subtree
+//   - usernames: A pointer to the list the usernames are appended to
+func collect(node *trieNode, usernames *[]string) {
+	if node.username != "" {
+		*usernames = append(*usernames, node.username)
+	}
+
+	for _, child := range node.children {
+		collect(child, usernames)
+	}
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrVersionConflict is returned by edit operations when the record was modified
+// after the caller read it. Callers can detect it with errors.Is.
+var ErrVersionConflict = errors.New("version conflict")
+
+// checkVersion compares the version the caller expects with the stored version.
+//
+// Parameters:
+//   - kind: The record type used in the error text ("user" or "comment")
+//   - id: The ID of the record
+//   - expected: The version the caller read before editing
+//   - current: The version currently stored
+//
+// Returns:
+//   - error: An error wrapping ErrVersionConflict if the versions differ, nil otherwise
+func checkVersion(kind string, id int, expected int, current int) error {
+	if expected == current {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s %d was modified by someone else (expected version %d, current version %d), reload and try again", ErrVersionConflict, kind, id, expected, current)
+}
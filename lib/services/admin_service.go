@@ -1,9 +1,12 @@
 package services
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -24,8 +27,9 @@ type AdminService interface {
 	// AdminMenu displays the main admin menu and captures the user's selection.
 	AdminMenu(result *string) error
 
-	// AdminPassword validates the admin password for authentication.
-	AdminPassword() error
+	// AdminLogin logs in to the admin menu with a stored account that has the admin or
+	// moderator role and stores the account that logged in.
+	AdminLogin(user *model.User) error
 
 	// LihatUser displays the user management menu and captures the user's selection.
 	LihatUser(result *string) error
@@ -42,6 +46,32 @@ type AdminService interface {
 	// DeleteUser handles the user deletion process.
 	DeleteUser() error
 
+	// MergeUser merges two user accounts into one.
+	// It previews the comments of the account that is merged away, reassigns them to the
+	// account that is kept and then deletes the merged account.
+	MergeUser() error
+
+	// Impersonasi asks which user the admin wants to act as and whether changes are allowed,
+	// and marks the user menu as impersonated. SelesaiImpersonasi ends it.
+	Impersonasi(user *model.User) error
+
+	// SelesaiImpersonasi ends the impersonation started by Impersonasi.
+	SelesaiImpersonasi()
+
+	// Unlock asks the password of the logged-in admin account again to unlock the idle lock.
+	// Returns an error if the password could not be asked, e.g. after Ctrl+C.
+	Unlock(user model.User) (bool, error)
+
+	// RiwayatLogin shows the successful and failed login attempts of a user, or of every user.
+	RiwayatLogin() error
+
+	// UserDihapus lists the users pending deletion and restores them or removes them
+	// permanently.
+	UserDihapus() error
+
+	// UbahRole gives a user the admin or moderator role or makes them a regular user again.
+	UbahRole() error
+
 	// LihatComment displays the comment management menu and captures the user's selection.
 	// It clears the screen, displays a formatted header for the comment data view,
 	// shows the current comment table, and presents an interactive menu with comment
@@ -74,8 +104,8 @@ type AdminService interface {
 
 	// Grafik displays statistics and data visualization about comments and users.
 	// It shows a summary screen with counts of total users, total comments, and comments
-	// categorized by sentiment (positive, neutral, negative). The data is retrieved
-	// from the comment repository and presented in a formatted display.
+	// categorized by sentiment (positive, neutral, negative). The comments can be filtered
+	// by period, user, source and project before they are counted.
 	Grafik() error
 
 	// SortingKomentar handles the comment sorting functionality in the admin interface.
@@ -83,6 +113,49 @@ type AdminService interface {
 	// and sorting mode (ascending or descending). After user selection, it retrieves
 	// sorted comments from the repository and displays them in a table format.
 	SortingKomentar() error
+
+	// BulkComment handles bulk deletion and bulk re-categorization of comments.
+	// It filters comments by category and keyword, shows a dry-run preview of exactly
+	// which comments would change, and only modifies storage after the admin confirms.
+	BulkComment() error
+
+	// Journal displays the operation journal and lets the admin replay it onto an empty store.
+	// Replaying rebuilds users and comments purely from the recorded commands, which is
+	// how the application recovers its data after a crash or restart.
+	Journal() error
+
+	// DetailComment displays the detail screen of a single comment.
+	// It shows the comment's current data together with its edit history and lets
+	// the admin pick a previous revision to view as a colored diff against the current text.
+	DetailComment() error
+
+	// TerakhirDilihat lists the comments most recently opened in the detail screen and
+	// opens the detail screen of the chosen one again.
+	TerakhirDilihat() error
+
+	// Favorit lists the comments the admin bookmarked and lets the admin add or remove a bookmark.
+	Favorit() error
+
+	// ReviewKomentar walks through the comments whose category was flagged as uncertain
+	// ("ragu") and lets the admin confirm or correct each category, showing how much of
+	// the review queue has been finished.
+	ReviewKomentar() error
+
+	// SaranLabel lists the comments the sentiment analyzer is least confident about, so
+	// manual labeling effort goes where it helps the classifier most, and can queue them
+	// for review.
+	SaranLabel() error
+
+	// TindakLanjut shows the board of negative comments that need follow-up and lets the
+	// admin escalate a comment or change its status and assignee.
+	TindakLanjut() error
+
+	// Catatan lets the admin write, change or remove the internal note of a comment.
+	Catatan() error
+
+	// StatusKomentar shows how many comments are in each processing status, lists the
+	// comments with a chosen status and moves a comment to its next status.
+	StatusKomentar() error
 }
 
 // adminService implements the AdminService interface and provides
@@ -92,68 +165,96 @@ type adminService struct {
 	userService    UserService
 	commentService CommentService
 	commentRepo    repository.CommentRepository
+	journal        repository.JournalRepository
+	tx             repository.TransactionRepository
+	sentiment      SentimentService
+	audit          AuditService
+
+	// userPage is the page of the user table that is shown, starting at 0
+	userPage int
+
+	// recent holds the IDs of the comments opened in the detail screen, most recent first
+	recent []int
+
+	// grafik is the filter of the Grafik screen
+	grafik grafikFilter
 }
 
 // NewAdminService creates and returns a new AdminService implementation.
 //
 // Parameters:
 //   - userService: The UserService implementation used to perform user-related operations
+//   - commentService: The CommentService implementation used to render and edit comments
+//   - commentRepo: The CommentRepository implementation used for direct comment queries
+//   - journal: The JournalRepository implementation that records every mutation
+//   - tx: The TransactionRepository implementation used for multi-step operations
+//   - sentiment: The SentimentService implementation used to rank comments by prediction confidence
+//   - audit: The AuditService implementation every change of a user or comment is recorded with
 //
 // Returns:
 //   - AdminService: A new AdminService implementation backed by the provided UserService
-func NewAdminService(userService UserService, commentService CommentService, commentRepo repository.CommentRepository) AdminService {
+func NewAdminService(userService UserService, commentService CommentService, commentRepo repository.CommentRepository, journal repository.JournalRepository, tx repository.TransactionRepository, sentiment SentimentService, audit AuditService) AdminService {
 	return &adminService{
 		userService:    userService,
 		commentService: commentService,
 		commentRepo:    commentRepo,
+		journal:        journal,
+		tx:             tx,
+		sentiment:      sentiment,
+		audit:          audit,
 	}
 }
 
-// AdminPassword validates the admin password for authentication.
+// AdminLogin logs in to the admin menu with a stored account that has an admin role.
 //
-// It retrieves the admin password from environment variables and prompts the user
-// to enter the password for validation. If no password is set in the environment,
-// authentication is skipped. The function handles different scenarios:
+// The function workflow:
+//  1. Clears the screen and displays the admin header
+//  2. When no user has the admin role yet, the admin menu is opened as admin so the first
+//     admin can be chosen under Lihat User > Role; if ADMIN_PASS is set it is asked first,
+//     see setupLogin
+//  3. Prompts for the username and password, offering completions of a partial username
+//  4. Checks the password and the role of the account and records the login attempt
+//     - If the account is unknown, the password is wrong or the role is missing: Offers
+//     the user to try again
+//     - If user chooses to try again: Returns helper.ErrContinue
+//     - If user chooses not to try again: Returns helper.ErrBack
 //
-// - When password matches: Displays success message and returns nil
-// - When password doesn't match: Offers the user to try again
-//   - If user chooses to try again: Returns "continue" error
-//   - If user chooses not to try again: Returns "back" error
+// Parameters:
+//   - user: Pointer to store the account that logged in; its Role is RoleAdmin or RoleModerator
 //
 // Returns:
-//   - nil: When authentication succeeds or no password is required
-//   - error: Authentication errors or user navigation commands ("back", "continue")
-func (a *adminService) AdminPassword() error {
-	var password = helper.GetEnv("ADMIN_PASS", "")
-
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu")
-	color.Yellow("========================================")
-	color.Yellow("=              ADMIN MENU              =")
-	color.Yellow("========================================")
-
-	if password == "" {
-		return nil
-	}
+//   - nil: When authentication succeeds or no admin account exists yet
+//   - error: Authentication errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) AdminLogin(user *model.User) error {
+	helper.Header("MENU > ADMIN", "ADMIN MENU")
 
-	prompt := promptui.Prompt{
-		Label: "Masukkan Password Admin",
-		Mask:  '*',
+	if a.userService.AdminCount() == 0 {
+		return a.setupLogin(user)
 	}
 
-	result, err := prompt.Run()
+	var username, password string
+	err := loginForm(a.userService, &username, &password)
 	if err != nil {
-		return err
-	}
-
-	if result == password {
-		color.Green("Password matched successfully!")
-		fmt.Scanln()
+		return helper.ErrBack
+	}
+
+	var account model.User
+	err = a.userService.FindUserByUsername(username, &account)
+	switch {
+	case err != nil || !helper.CheckPasswordHash(password, account.Password):
+		a.userService.RecordLogin(username, account.Id, false)
+		color.Red("Username atau password salah")
+	case !IsAdminRole(account.Role):
+		a.userService.RecordLogin(username, account.Id, false)
+		color.Red("User %s tidak memiliki role admin atau moderator", username)
+	default:
+		a.userService.RecordLogin(account.Username, account.Id, true)
+		*user = account
+		color.Green("Login berhasil! Masuk sebagai %s.", account.Role)
+		helper.Pause()
 		return nil
 	}
 
-	color.Red("Passwords do not match")
-
 	askPrompt := promptui.Prompt{
 		Label:     "Apakah Anda ingin mencoba lagi?",
 		IsConfirm: true,
@@ -161,17 +262,100 @@ func (a *adminService) AdminPassword() error {
 
 	_, err = askPrompt.Run()
 	if err != nil {
-		return fmt.Errorf("back")
+		return helper.ErrBack
+	}
+
+	return helper.ErrContinue
+}
+
+// setupLogin opens the admin menu while no account has the admin role yet. The
+// ADMIN_PASS environment variable, when set, protects this first login; without it the
+// menu opens at once.
+//
+// Parameters:
+//   - user: Pointer to store the session account, which has no ID and RoleAdmin
+//
+// Returns:
+//   - nil: When the menu may be opened
+//   - error: User navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) setupLogin(user *model.User) error {
+	password := helper.GetEnv("ADMIN_PASS", "")
+
+	if password != "" {
+		prompt := promptui.Prompt{
+			Label: "Masukkan Password Admin",
+			Mask:  '*',
+		}
+
+		result, err := prompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		if result != password {
+			color.Red("Passwords do not match")
+
+			askPrompt := promptui.Prompt{
+				Label:     "Apakah Anda ingin mencoba lagi?",
+				IsConfirm: true,
+			}
+
+			_, err = askPrompt.Run()
+			if err != nil {
+				return helper.ErrBack
+			}
+
+			return helper.ErrContinue
+		}
+	}
+
+	*user = model.User{Username: RoleAdmin, Role: RoleAdmin}
+	color.Yellow("Belum ada akun admin. Pilih admin pertama di Lihat User > Role.")
+	helper.Pause()
+
+	return nil
+}
+
+// Unlock asks the password of the logged-in admin account again after the screen was
+// locked for inactivity, see helper.LockSession. A session opened by setupLogin asks
+// ADMIN_PASS, or nothing when it is not set.
+//
+// Parameters:
+//   - user: The account that logged in to the admin menu
+//
+// Returns:
+//   - bool: true if the password of the account was entered
+//   - error: The error of the prompt, e.g. promptui.ErrInterrupt on Ctrl+C or io.EOF
+//     when the input ended, so it is not mistaken for a wrong password
+func (a *adminService) Unlock(user model.User) (bool, error) {
+	password := helper.GetEnv("ADMIN_PASS", "")
+	if user.Id == 0 && password == "" {
+		return true, nil
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Password",
+		Mask:  '*',
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		return false, err
 	}
 
-	return fmt.Errorf("continue")
+	if user.Id == 0 {
+		return result == password, nil
+	}
+
+	return helper.CheckPasswordHash(result, user.Password), nil
 }
 
 // AdminMenu displays the main admin menu and captures the user's selection.
 //
-// It clears the screen, displays a formatted menu header, and presents
+// It clears the screen, displays a formatted menu header followed by 7-day sparklines
+// of new comments and new users, and presents
 // a selection interface with various admin options (Lihat Komentar, Lihat User,
-// Lihat Grafik, Exit). The function uses promptui to create an interactive
+// Lihat Grafik, Laporan, Import Komentar, Maintenance, Journal, Proyek, Template, Pengaturan, Lihat Audit Log, Exit). The function uses promptui to create an interactive
 // selection interface with custom styling for menu items.
 //
 // Parameters:
@@ -180,15 +364,19 @@ func (a *adminService) AdminPassword() error {
 // Returns:
 //   - error: Any error encountered during menu display or selection process
 func (a *adminService) AdminMenu(result *string) error {
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu")
-	color.Yellow("========================================")
-	color.Yellow("=              ADMIN MENU              =")
-	color.Yellow("========================================")
+	helper.Header("MENU > ADMIN", "ADMIN MENU")
+
+	err := a.showActivity()
+	if err != nil {
+		return err
+	}
+
+	labels, keys := helper.MenuItems("admin", []string{"Lihat Komentar", "Lihat User", "Lihat Grafik", "Laporan", "Import Komentar", "Maintenance", "Journal", "Proyek", "Template", "Pengaturan", "Lihat Audit Log", "Exit"})
 
 	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Lihat Komentar", "Lihat User", "Lihat Grafik", "Exit"},
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("admin", keys),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -197,44 +385,108 @@ func (a *adminService) AdminMenu(result *string) error {
 		},
 	}
 
-	_, resultInput, err := prompt.Run()
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, result) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*result = keys[index]
+	helper.RememberMenu("admin", keys[index])
+
+	return nil
+}
+
+// showActivity prints 7-day sparklines of new comments and new users.
+//
+// The series are computed from the CreatedAt timestamps of the stored comments and users,
+// one character per day from six days ago up to today, followed by the 7-day total.
+//
+// Returns:
+//   - error: Any error encountered during data retrieval
+func (a *adminService) showActivity() error {
+	var users [255]model.User
+	var comments [255]model.Comment
+
+	err := a.userService.GetAllUsers(&users)
+	if err != nil {
+		return err
+	}
+
+	err = a.commentRepo.GetAllComments(&comments)
 	if err != nil {
 		return err
 	}
 
-	*result = resultInput
+	var commentTimes []time.Time
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar != "" {
+			commentTimes = append(commentTimes, comments[i].CreatedAt)
+		}
+	}
+
+	userTimes := make([]time.Time, global.UserCount)
+	for i := 0; i < global.UserCount; i++ {
+		userTimes[i] = users[i].CreatedAt
+	}
+
+	now := time.Now()
+	commentDays := helper.DailyCounts(commentTimes, 7, now)
+	userDays := helper.DailyCounts(userTimes, 7, now)
+
+	color.Cyan("Komentar baru (7 hari): %s %d", helper.Sparkline(commentDays), sum(commentDays))
+	color.Cyan("User baru (7 hari):     %s %d", helper.Sparkline(userDays), sum(userDays))
 
 	return nil
 }
 
+// sum adds up all values of a series.
+//
+// Parameters:
+//   - values: The values to add up
+//
+// Returns:
+//   - int: The total of all values
+func sum(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+
+	return total
+}
+
 // LihatUser displays the user management menu and captures the user's selection.
 //
 // It clears the screen, displays a formatted header for the user data view,
 // shows the current user table by calling ShowUserTable(), and presents an
-// interactive menu with user management options (Search, Add, Edit, Delete, Exit).
+// interactive menu with user management options (Search, Add, Edit, Delete, Dihapus, Role, Merge, Exit).
 // The function uses promptui to create an interactive selection interface with
-// custom styling for menu items.
+// custom styling for menu items. When the users do not fit on one page the menu also
+// offers "Halaman Berikutnya" and "Halaman Sebelumnya" to move the table to another page.
 //
 // Parameters:
 //   - result: Pointer to store the selected menu option as a string
 //
 // Returns:
 //   - error: Any error encountered during displaying the user table or menu selection
-func (a adminService) LihatUser(result *string) error {
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu > Lihat User")
-	color.Yellow("========================================")
-	color.Yellow("=              DATA USER               =")
-	color.Yellow("========================================")
+func (a *adminService) LihatUser(result *string) error {
+	helper.Header("MENU > ADMIN > LIHAT USER", "DATA USER")
 
 	err := a.ShowUserTable()
 	if err != nil {
 		return err
 	}
 
+	labels, keys := helper.MenuItems("admin_user", pageItems([]string{"Search", "Add", "Edit", "Delete", "Dihapus", "Role", "Merge", "Impersonasi", "Riwayat Login", "Import", "Export", "Exit"}, a.userPage, pageCount(global.UserCount)))
+
 	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Search", "Add", "Edit", "Delete", "Exit"},
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("admin_user", keys),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -243,12 +495,18 @@ func (a adminService) LihatUser(result *string) error {
 		},
 	}
 
-	_, resultPrompt, err := prompt.Run()
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, result) {
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
 
-	*result = resultPrompt
+	*result = keys[index]
+	helper.RememberMenu("admin_user", keys[index])
+	turnPage(*result, &a.userPage)
 
 	return nil
 }
@@ -266,17 +524,13 @@ func (a adminService) LihatUser(result *string) error {
 // 3. Execute the search via userService.SearchUsers
 // 4. Display results in a table via ShowUserTable
 // 5. Ask if user wants to search again
-//   - If yes: Return "continue" error to loop back to search
-//   - If no: Return "back" error to go back to previous menu
+//   - If yes: Return helper.ErrContinue to loop back to search
+//   - If no: Return helper.ErrBack to go back to previous menu
 //
 // Returns:
-//   - error: Search errors or user navigation commands ("back", "continue")
+//   - error: Search errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
 func (a *adminService) SearchUsers() error {
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu > Lihat User > Search")
-	color.Yellow("========================================")
-	color.Yellow("=              DATA USER               =")
-	color.Yellow("========================================")
+	helper.Header("MENU > ADMIN > LIHAT USER > SEARCH", "DATA USER")
 
 	prompt := promptui.Prompt{
 		Label: "Masukkan Username yang ingin dicari",
@@ -292,20 +546,20 @@ func (a *adminService) SearchUsers() error {
 		return err
 	}
 
+	search, err = completeUsername(a.userService.CompleteUsername, search)
+	if err != nil {
+		return err
+	}
+
 	var users [255]model.User
 	err = a.userService.SearchUsers(search, &users)
 	if err != nil {
 		return err
 	}
 
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu > Lihat User > Search")
-	color.Yellow("========================================")
-	color.Yellow("=              DATA USER               =")
-	color.Yellow("========================================")
+	helper.Header("MENU > ADMIN > LIHAT USER > SEARCH", "DATA USER")
 
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t := helper.NewTable()
 	t.AppendHeader(table.Row{"#", "Username"})
 	var j int
 	for i := 0; i < global.UserCount; i++ {
@@ -314,15 +568,14 @@ func (a *adminService) SearchUsers() error {
 			t.AppendRow(table.Row{j, users[i].Username})
 		}
 	}
-	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 
 	_, err = askPrompt.Run()
 	if err != nil {
-		return fmt.Errorf("back")
+		return helper.ErrBack
 	}
 
-	return fmt.Errorf("continue")
+	return helper.ErrContinue
 }
 
 // CreateUser handles the user creation process.
@@ -338,19 +591,15 @@ func (a *adminService) SearchUsers() error {
 // 4. If validation fails:
 //   - Display appropriate error message
 //   - Prompt admin to try again
-//   - Return "continue" to retry or "back" to return to previous menu
+//   - Return helper.ErrContinue to retry or helper.ErrBack to return to previous menu
 //
 // 5. If validation passes, create the user via userService.CreateUser
 //
 // Returns:
 //   - nil: When user creation succeeds
-//   - error: Creation errors or user navigation commands ("back", "continue")
+//   - error: Creation errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
 func (a *adminService) CreateUser() error {
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu > Lihat User > Add")
-	color.Yellow("========================================")
-	color.Yellow("=              DATA USER               =")
-	color.Yellow("========================================")
+	helper.Header("MENU > ADMIN > LIHAT USER > ADD", "DATA USER")
 
 	var username, password, confirmPassword string
 
@@ -368,30 +617,36 @@ func (a *adminService) CreateUser() error {
 		color.Red("User %s already exists", username)
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	if password != confirmPassword {
 		color.Red("Password does not match")
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
-	err = a.userService.CreateUser(&model.User{
+	user := model.User{
 		Username: username,
 		Password: password,
-	})
+
+		MustChangePassword: true,
+	}
+
+	err = a.userService.CreateUser(&user)
 	if err != nil {
 		return err
 	}
 
+	a.audit.Record("create_user", user.Id, nil, user)
+
 	return nil
 }
 
@@ -453,19 +708,15 @@ func createUserForm(username, password, confirmPassword *string) error {
 // 6. If validation fails:
 //   - Display appropriate error message
 //   - Prompt admin to try again
-//   - Return "continue" to retry or "back" to return to previous menu
+//   - Return helper.ErrContinue to retry or helper.ErrBack to return to previous menu
 //
 // 7. If validation passes, update the user via userService.EditUser
 //
 // Returns:
 //   - nil: When user editing succeeds
-//   - error: Editing errors or user navigation commands ("back", "continue")
+//   - error: Editing errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
 func (a *adminService) EditUser() error {
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu > Lihat User > Edit")
-	color.Yellow("========================================")
-	color.Yellow("=              DATA USER               =")
-	color.Yellow("========================================")
+	helper.Header("MENU > ADMIN > LIHAT USER > EDIT", "DATA USER")
 
 	err := a.ShowUserTable()
 	if err != nil {
@@ -473,19 +724,8 @@ func (a *adminService) EditUser() error {
 	}
 
 	prompt := promptui.Prompt{
-		Label: "Masukkan Nomor User yang ingin diubah",
-		Validate: func(input string) error {
-			if input == "" {
-				return fmt.Errorf("input cannot be empty")
-			}
-
-			index, err := strconv.Atoi(input)
-			if err != nil || index < 1 || index > global.UserCount {
-				return fmt.Errorf("invalid user number")
-			}
-
-			return nil
-		},
+		Label:    "Masukkan Nomor User yang ingin diubah",
+		Validate: validateUserNumber,
 	}
 
 	askPrompt := promptui.Prompt{
@@ -499,10 +739,10 @@ func (a *adminService) EditUser() error {
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	index, err := strconv.Atoi(indexInput)
@@ -511,14 +751,22 @@ func (a *adminService) EditUser() error {
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	index--
 
+	var users [255]model.User
+	err = a.userService.GetAllUsers(&users)
+	if err != nil {
+		return err
+	}
+
+	version := users[index].Version
+
 	var username, password, confirmPassword string
 	err = editUserForm(&username, &password, &confirmPassword)
 	if err != nil {
@@ -530,10 +778,10 @@ func (a *adminService) EditUser() error {
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	if password != "" && password != confirmPassword {
@@ -541,20 +789,25 @@ func (a *adminService) EditUser() error {
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	err = a.userService.EditUser(index, model.User{
 		Username: username,
 		Password: password,
+		Version:  version,
+
+		MustChangePassword: true,
 	})
 	if err != nil {
 		return err
 	}
 
+	a.audit.Record("edit_user", users[index].Id, users[index], global.Users[index])
+
 	return nil
 }
 
@@ -613,20 +866,20 @@ func editUserForm(username, password, confirmPassword *string) error {
 // 4. If validation fails:
 //   - Display appropriate error message
 //   - Prompt admin to try again
-//   - Return "continue" to retry or "back" to return to previous menu
+//   - Return helper.ErrContinue to retry or helper.ErrBack to return to previous menu
+//
+// 5. Show the selected username and ask the admin to confirm the deletion
+//   - If the admin declines: Return helper.ErrBack to return to previous menu
 //
-// 5. If validation passes, delete the user via userService.DeleteUser
-// 6. Display success message
+// 6. If confirmed, mark the user as pending deletion via userService.MarkUserDeleted,
+// restorable on the Dihapus screen until UserDeleteGrace has passed
+// 7. Display success message
 //
 // Returns:
 //   - nil: When user deletion succeeds
-//   - error: Deletion errors or user navigation commands ("back", "continue")
+//   - error: Deletion errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
 func (a *adminService) DeleteUser() error {
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Admin Menu > Lihat User > Delete")
-	color.Yellow("========================================")
-	color.Yellow("=              DATA USER               =")
-	color.Yellow("========================================")
+	helper.Header("MENU > ADMIN > LIHAT USER > DELETE", "DATA USER")
 
 	err := a.ShowUserTable()
 	if err != nil {
@@ -634,19 +887,8 @@ func (a *adminService) DeleteUser() error {
 	}
 
 	prompt := promptui.Prompt{
-		Label: "Masukkan Nomor User yang ingin dihapus",
-		Validate: func(input string) error {
-			if input == "" {
-				return fmt.Errorf("input cannot be empty")
-			}
-
-			index, err := strconv.Atoi(input)
-			if err != nil || index < 1 || index > global.UserCount {
-				return fmt.Errorf("invalid user number")
-			}
-
-			return nil
-		},
+		Label:    "Masukkan Nomor User yang ingin dihapus",
+		Validate: validateUserNumber,
 	}
 
 	askPrompt := promptui.Prompt{
@@ -660,10 +902,10 @@ func (a *adminService) DeleteUser() error {
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	index, err := strconv.Atoi(indexInput)
@@ -672,431 +914,414 @@ func (a *adminService) DeleteUser() error {
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	index--
 
-	err = a.userService.DeleteUser(index)
+	var users [255]model.User
+	err = a.userService.GetAllUsers(&users)
 	if err != nil {
 		return err
 	}
 
-	color.Green("User deleted successfully")
-	return nil
-}
-
-// ShowUserTable displays a formatted table of all users in the system.
-//
-// It retrieves all users from the userService and renders them as a table
-// to standard output using the go-pretty/table package. The table includes
-// row numbers and usernames with colored formatting for better readability.
-//
-// Returns:
-//   - error: Any error encountered during user data retrieval
-func (a *adminService) ShowUserTable() error {
-	var users [255]model.User
+	if a.lastAdmin(users[index]) {
+		color.Red("User %s adalah admin terakhir dan tidak dapat dihapus", users[index].Username)
+		helper.Pause()
+		return helper.ErrBack
+	}
 
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Username"})
+	if !helper.ConfirmDelete(users[index].Username) {
+		return helper.ErrBack
+	}
 
-	err := a.userService.GetAllUsers(&users)
+	err = a.userService.MarkUserDeleted(index)
 	if err != nil {
 		return err
 	}
 
-	for i := 0; i < global.UserCount; i++ {
-		t.AppendRow(table.Row{i + 1, users[i].Username})
-	}
-
-	t.SetStyle(table.StyleColoredBright)
-	t.Render()
+	a.audit.Record("delete_user", users[index].Id, users[index], global.Users[index])
 
+	color.Yellow("User %s dapat dipulihkan lewat menu Dihapus selama %s", users[index].Username, formatGrace(UserDeleteGrace()))
 	return nil
 }
 
-// LihatComment displays the comment management menu and captures the user's selection.
+// Impersonasi prepares opening the user menu as another user, to reproduce what a user
+// reports without knowing their password.
 //
-// It clears the screen, displays a formatted header for the comment data view,
-// shows the current comment table, and presents an interactive menu with comment
-// management options (Search, Sorting, Add, Edit, Delete, Exit).
+// The function workflow:
+//  1. Clears the screen, displays the header and the user table
+//  2. Prompts for the username, offering completions of a partial username
+//     - If the user does not exist: Prompt admin to try again
+//     - Return helper.ErrContinue to retry or helper.ErrBack to return to previous menu
+//  3. Asks whether changes are allowed; the impersonation is read-only unless the admin confirms
+//  4. Marks the user menu as impersonated, see UserService.Impersonate
 //
 // Parameters:
-//   - result: Pointer to store the selected menu option as a string
+//   - user: Pointer to store the impersonated user
 //
 // Returns:
-//   - error: Any error encountered during displaying the comment table or menu selection
-func (a *adminService) LihatComment(result *string) error {
-	helper.ClearScreen()
-	color.Yellow("* MAIN MENU > ADMIN > LIHAT KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=            DATA KOMENTAR             =")
-	color.Yellow("========================================")
+//   - nil: When the user menu can be opened as the user
+//   - error: Lookup errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) Impersonasi(user *model.User) error {
+	helper.Header("MENU > ADMIN > LIHAT USER > IMPERSONASI", "LIHAT SEBAGAI USER")
 
-	err := a.commentService.ShowTable()
+	err := a.ShowUserTable()
 	if err != nil {
 		return err
 	}
 
-	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Search", "Sorting", "Add", "Edit", "Delete", "Exit"},
-		Templates: &promptui.SelectTemplates{
-			Label:    "{{ . | blue }}:",
-			Active:   "\u27A1 {{ . | cyan }}",
-			Inactive: "  {{ . | cyan }}",
-			Selected: "\u2705 {{ . | blue | cyan }}",
-		},
+	prompt := promptui.Prompt{
+		Label: "Masukkan Username",
+	}
+
+	askPrompt := promptui.Prompt{
+		Label:     "Try Again?",
+		IsConfirm: true,
+	}
+
+	writePrompt := promptui.Prompt{
+		Label:     "Izinkan perubahan data atas nama user ini",
+		IsConfirm: true,
 	}
 
-	_, resultInput, err := prompt.Run()
+	username, err := prompt.Run()
 	if err != nil {
-		return err
+		return helper.ErrBack
+	}
+
+	username, err = completeUsername(a.userService.CompleteUsername, username)
+	if err != nil {
+		return helper.ErrBack
 	}
 
-	*result = resultInput
+	err = a.userService.FindUserByUsername(username, user)
+	if err != nil {
+		color.Red(err.Error())
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		return helper.ErrContinue
+	}
+
+	_, err = writePrompt.Run()
+	readOnly := err != nil
+
+	helper.Trace("impersonate: user=%q readOnly=%v", user.Username, readOnly)
+	a.userService.Impersonate(user.Username, readOnly)
 
 	return nil
 }
 
-// SearchAdminComment handles the comment search functionality in the admin interface.
-//
-// It displays a search interface that prompts the user to enter a keyword to search for,
-// performs the search using the comment repository, and displays the filtered results
-// in a table format. The function follows this workflow:
+// SelesaiImpersonasi ends the impersonation started by Impersonasi.
+func (a *adminService) SelesaiImpersonasi() {
+	helper.Trace("impersonate: end")
+	a.userService.Impersonate("", false)
+}
+
+// RiwayatLogin displays the login history.
 //
-// 1. Clears the screen and displays the search interface header
-// 2. Prompts user to enter a search keyword
-// 3. Searches comments via commentRepo.SearchComments
-// 4. Displays matching results in a formatted table
-// 5. Asks if user wants to search again
-//   - If yes: Returns "continue" error to loop back to search
-//   - If no: Returns "back" error to go back to previous menu
+// The function workflow:
+//  1. Clears the screen and displays the header
+//  2. Prompts for a username, offering completions of a partial username; an empty username shows every account
+//  3. Displays the login attempts, newest first, with the time and whether the login succeeded
+//  4. Asks whether the admin wants to look up another username
+//     - If yes: Returns helper.ErrContinue to show the history screen again
+//     - If no: Returns helper.ErrBack to go back to previous menu
 //
 // Returns:
-//   - error: Search errors or user navigation commands ("back", "continue")
-func (a *adminService) SearchAdminComment() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > CARI KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           CARI KOMENTAR              =")
-	color.Yellow("========================================")
+//   - error: History errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) RiwayatLogin() error {
+	helper.Header("MENU > ADMIN > LIHAT USER > RIWAYAT LOGIN", "RIWAYAT LOGIN")
 
-	searchPrompt := promptui.Prompt{
-		Label: "Masukkan kata kunci untuk mencari komentar",
+	prompt := promptui.Prompt{
+		Label: "Masukkan Username (kosongkan untuk semua)",
 	}
 
-	searchInput, err := searchPrompt.Run()
+	askPrompt := promptui.Prompt{
+		Label:     "Lihat Lagi?",
+		IsConfirm: true,
+	}
+
+	username, err := prompt.Run()
 	if err != nil {
-		return err
+		return helper.ErrBack
 	}
 
-	var comments [255]model.Comment
-	err = a.commentRepo.SearchComments(searchInput, &comments)
+	username, err = completeUsername(a.userService.CompleteUsername, username)
 	if err != nil {
-		return err
+		return helper.ErrBack
 	}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > CARI KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           CARI KOMENTAR              =")
-	color.Yellow("========================================")
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
-	var j int
-	for i := 0; i < global.CommentCount; i++ {
-		if comments[i].Komentar != "" {
-			j++
-			t.AppendRow(table.Row{
-				j,
-				comments[i].Komentar,
-				comments[i].Kategori,
-			})
-		}
+	var attempts [255]model.LoginAttempt
+	n, err := a.userService.LoginHistory(username, &attempts)
+	if err != nil {
+		return err
 	}
-	t.SetStyle(table.StyleColoredBright)
-	t.Render()
 
-	askPrompt := promptui.Prompt{
-		Label:     "Search Again?",
-		IsConfirm: true,
+	if n == 0 {
+		color.Cyan("Belum ada percobaan login")
+	} else {
+		t := helper.NewTable()
+		t.AppendHeader(table.Row{"#", "Waktu", "Username", "Status"})
+		for i := 0; i < n; i++ {
+			status := color.GreenString("Berhasil")
+			if !attempts[i].Success {
+				status = color.RedString("Gagal")
+			}
+
+			t.AppendRow(table.Row{i + 1, attempts[i].At.Format("2006-01-02 15:04:05"), attempts[i].Username, status})
+		}
+		t.Render()
 	}
 
 	_, err = askPrompt.Run()
 	if err != nil {
-		return fmt.Errorf("back")
+		return helper.ErrBack
 	}
 
-	return fmt.Errorf("continue")
+	return helper.ErrContinue
 }
 
-// AddComment handles the comment creation process in the admin interface.
-//
-// It displays a comment creation interface where admins can add new comments to the system.
-// The function follows this workflow:
-// 1. Clears the screen and displays the comment creation interface header
-// 2. Collects comment text and category through CreateCommentForm
-// 3. Creates a new comment record using the comment repository
+// MergeUser merges two user accounts into one.
 //
-// Error handling:
-//   - Form errors: Displays the error message in red text and offers to try again
-//   - If user chooses to try again: Returns "continue" error to restart the process
-//   - If user chooses not to try again: Returns "back" error to go to previous menu
-//   - Creation errors: Follows the same error handling pattern as form errors
+// The function workflow:
+//  1. Clears the screen, displays the header and the user table
+//  2. Prompts for the number of the user to keep and the user to merge away
+//     - If either input is invalid or both are the same user: Prompt admin to try again
+//     - Return helper.ErrContinue to retry or helper.ErrBack to return to previous menu
+//  3. Shows a preview table of the comments that will be moved
+//  4. Asks the admin to confirm the merge
+//     - If the admin declines: Return helper.ErrBack to return to previous menu
+//  5. Reassigns the comments via commentRepo.ReassignComments and deletes the merged user
+//  6. Displays success message
 //
 // Returns:
-//   - nil: When comment creation succeeds
-//   - error: Creation errors or user navigation commands ("back", "continue")
-func (a *adminService) AddComment() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > TAMBAH KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           TAMBAH KOMENTAR            =")
-	color.Yellow("========================================")
+//   - nil: When the merge succeeds
+//   - error: Merge errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) MergeUser() error {
+	helper.Header("MENU > ADMIN > LIHAT USER > MERGE", "MERGE USER")
 
-	var komentar, kategori string
+	err := a.ShowUserTable()
+	if err != nil {
+		return err
+	}
+
+	keepPrompt := promptui.Prompt{
+		Label:    "Masukkan Nomor User yang dipertahankan",
+		Validate: validateUserNumber,
+	}
+
+	mergePrompt := promptui.Prompt{
+		Label:    "Masukkan Nomor User yang digabungkan (akan dihapus)",
+		Validate: validateUserNumber,
+	}
 
 	askPrompt := promptui.Prompt{
 		Label:     "Try Again?",
 		IsConfirm: true,
 	}
 
-	err := a.commentService.CreateCommentForm(&komentar, &kategori)
+	keepInput, err := keepPrompt.Run()
 	if err != nil {
-		color.Red(err.Error())
+		return helper.ErrBack
+	}
+
+	mergeInput, err := mergePrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	keepIndex, _ := strconv.Atoi(keepInput)
+	mergeIndex, _ := strconv.Atoi(mergeInput)
+	keepIndex--
+	mergeIndex--
+
+	if keepIndex == mergeIndex {
+		color.Red("User yang digabungkan harus berbeda")
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
-	err = a.commentRepo.Create(&model.Comment{
-		Komentar: komentar,
-		Kategori: kategori,
-	}, 0)
+	var users [255]model.User
+	err = a.userService.GetAllUsers(&users)
 	if err != nil {
-		color.Red(err.Error())
+		return err
+	}
+
+	keep := users[keepIndex]
+	merged := users[mergeIndex]
+
+	if a.lastAdmin(merged) {
+		color.Red("User %s adalah admin terakhir dan tidak dapat digabungkan", merged.Username)
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
-	return nil
-}
-
-// EditComment handles the comment editing process in the admin interface.
-//
-// It displays the comment editing interface where admins can modify existing comments.
-// The function follows this workflow:
-// 1. Clears the screen and displays the edit interface header
-// 2. Shows the current comment table via commentService.ShowTable
-// 3. Prompts admin to select a comment by ID with input validation:
-//   - Ensures input is not empty
-//   - Verifies input is a valid number within the range of existing comments
-//
-// 4. Collects updated information (comment text and category) via EditForm
-// 5. Updates the comment via commentService.EditComment
-// 6. Asks if admin wants to try editing again
-//   - If yes: Returns "continue" error to restart the process
-//   - If no: Returns "back" error to go back to previous menu
-//
-// Returns:
-//   - error: Editing errors or user navigation commands ("back", "continue")
-func (a *adminService) EditComment() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > EDIT KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=            EDIT KOMENTAR             =")
-	color.Yellow("========================================")
-
-	err := a.commentService.ShowTable()
+	var comments [255]model.Comment
+	err = a.commentRepo.GetCommentByUserId(merged.Id, &comments)
 	if err != nil {
 		return err
 	}
 
-	prompt := promptui.Prompt{
-		Label: "Masukkan Id Komentar yang ingin diubah",
-		Validate: func(input string) error {
-			if input == "" {
-				return fmt.Errorf("input tidak boleh kosong")
-			}
+	t := helper.NewTable()
+	t.SetTitle(fmt.Sprintf("Komentar %s yang akan dipindahkan ke %s", merged.Username, keep.Username))
+	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
 
-			id, err := strconv.Atoi(input)
-			if err != nil || id < 1 || id > global.IdCommentIncrement {
-				return fmt.Errorf("id komentar tidak valid")
-			}
+	count := 0
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Id == 0 {
+			continue
+		}
 
-			return nil
-		},
+		count++
+		t.AppendRow(table.Row{count, comments[i].Komentar, comments[i].Kategori})
 	}
 
-	idInput, err := prompt.Run()
-	if err != nil {
-		return err
-	}
+	t.AppendFooter(table.Row{"", "Total", count})
+	t.Render()
 
-	id, err := strconv.Atoi(idInput)
-	if err != nil {
-		return err
+	confirmPrompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Gabungkan %s ke %s dan hapus %s", merged.Username, keep.Username, merged.Username),
+		IsConfirm: true,
 	}
 
-	var komentar, kategori string
-
-	err = a.commentService.EditForm(&komentar, &kategori)
+	_, err = confirmPrompt.Run()
 	if err != nil {
-		return err
+		return helper.ErrBack
 	}
 
-	err = a.commentService.EditComment(id, model.Comment{
-		Komentar: komentar,
-		Kategori: kategori,
+	moved := 0
+	err = a.tx.Run(func() error {
+		var err error
+		moved, err = a.commentRepo.ReassignComments(merged.Id, keep.Id)
+		if err != nil {
+			return err
+		}
+
+		return a.userService.DeleteUser(mergeIndex)
 	})
 	if err != nil {
 		return err
 	}
 
-	askPrompt := promptui.Prompt{
-		Label:     "Try Again?",
-		IsConfirm: true,
-	}
-
-	_, err = askPrompt.Run()
-	if err != nil {
-		return fmt.Errorf("back")
-	}
+	a.audit.Record("merge_user", merged.Id, merged, map[string]any{"merged_into": keep.Id, "comments_moved": moved})
 
-	return fmt.Errorf("continue")
+	color.Green("User merged successfully (%d komentar dipindahkan ke %s)", moved, keep.Username)
+	return nil
 }
 
-// DeleteComment handles the comment deletion process in the admin interface.
-//
-// It displays the comment deletion interface where admins can remove existing comments.
-// The function follows this workflow:
-// 1. Clears the screen and displays the deletion interface header
-// 2. Shows the current comment table via commentService.ShowTable
-// 3. Prompts admin to select a comment by ID with input validation:
-//   - Ensures input is not empty
-//   - Verifies input is a valid number within the range of existing comments
+// ShowUserTable displays a formatted table of all users in the system.
 //
-// 4. Deletes the selected comment using the comment repository
-// 5. If deletion fails:
-//   - Displays the error message in red text
-//   - Asks if admin wants to try again
-//   - Returns "continue" to retry or "back" to return to previous menu
+// It retrieves the current page (PAGE_SIZE rows) of users from the userService and
+// renders them as a table to standard output using the go-pretty/table package. The table
+// includes row numbers and usernames with colored formatting for better readability,
+// followed by the page number when there is more than one page. The Role column shows
+// the admin role of the user, "-" for regular users. Users pending deletion are left
+// out; they are listed by UserDihapus.
 //
 // Returns:
-//   - nil: When comment deletion succeeds
-//   - error: Deletion errors or user navigation commands ("back", "continue")
-func (a *adminService) DeleteComment() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > DELETE KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           DELETE KOMENTAR            =")
-	color.Yellow("========================================")
+//   - error: Any error encountered during user data retrieval
+func (a *adminService) ShowUserTable() error {
+	var users [255]model.User
 
-	err := a.commentService.ShowTable()
+	pages := pageCount(global.UserCount)
+	a.userPage = min(a.userPage, pages-1)
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Username", "Role"})
+
+	offset := a.userPage * PageSize()
+	n, err := a.userService.GetUsers(offset, PageSize(), &users)
 	if err != nil {
 		return err
 	}
 
-	prompt := promptui.Prompt{
-		Label: "Masukkan Id Komentar yang ingin dihapus",
-		Validate: func(input string) error {
-			if input == "" {
-				return fmt.Errorf("input tidak boleh kosong")
-			}
+	for i := 0; i < n; i++ {
+		if !users[i].DeletedAt.IsZero() {
+			continue
+		}
 
-			id, err := strconv.Atoi(input)
-			if err != nil || id < 1 || id > global.IdCommentIncrement {
-				return fmt.Errorf("id komentar tidak valid")
-			}
+		role := users[i].Role
+		if role == "" {
+			role = "-"
+		}
 
-			return nil
-		},
+		t.AppendRow(table.Row{offset + i + 1, users[i].Username, role})
 	}
 
-	idInput, err := prompt.Run()
-	if err != nil {
-		return err
+	t.Render()
+
+	if pages > 1 {
+		color.Cyan("Halaman %d dari %d", a.userPage+1, pages)
 	}
 
-	id, err := strconv.Atoi(idInput)
+	return nil
+}
+
+// UbahRole changes the admin role of a user.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and the user table with the roles
+//  2. Prompts for the number of the user
+//  3. Lets the admin choose RoleAdmin, RoleModerator or "user" for a regular user, starting
+//     at the current role
+//     - If the last admin would lose the role: Shows an error instead, nobody could log
+//     in to the admin menu anymore
+//  4. Changes the role via userService.SetUserRole and displays a success message
+//
+// Returns:
+//   - error: Storage errors or user navigation commands (helper.ErrBack)
+func (a *adminService) UbahRole() error {
+	helper.Header("MENU > ADMIN > LIHAT USER > ROLE", "ROLE USER")
+
+	err := a.ShowUserTable()
 	if err != nil {
 		return err
 	}
 
-	askPrompt := promptui.Prompt{
-		Label:     "Try Again?",
-		IsConfirm: true,
+	prompt := promptui.Prompt{
+		Label:    "Masukkan Nomor User",
+		Validate: validateUserNumber,
 	}
 
-	err = a.commentRepo.DeleteComment(id)
+	input, err := prompt.Run()
 	if err != nil {
-		color.Red(err.Error())
-
-		_, err = askPrompt.Run()
-		if err != nil {
-			return fmt.Errorf("back")
-		}
-
-		return fmt.Errorf("continue")
+		return helper.ErrBack
 	}
 
-	return nil
-}
-
-// SortingKomentar handles the comment sorting functionality in the admin interface.
-//
-// It displays a sorting interface where admins can select sorting criteria and order.
-// The function follows this workflow:
-// 1. Clears the screen and displays the sorting interface header
-// 2. Presents two selection menus to the admin:
-//   - First menu: Select sorting criteria (by comment text "Komentar" or by category "Kategori")
-//   - Second menu: Select sorting order (Ascending or Descending)
-//
-// 3. Based on the selections, calls the appropriate sorting method:
-//   - sortCommentByKomentar: Sorts comments by their text content
-//   - sortCommentByKategori: Sorts comments by their category
-//
-// The sorting mode is converted to an integer (0 for Ascending, 1 for Descending)
-// before being passed to the sorting functions.
-//
-// Returns:
-//   - error: Any error encountered during the sorting process or menu navigation
-func (a *adminService) SortingKomentar() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > SORTING")
-	color.Yellow("========================================")
-	color.Yellow("=               SORTING                =")
-	color.Yellow("========================================")
+	index, _ := strconv.Atoi(input)
+	index--
+	user := global.Users[index]
 
-	prompt := promptui.Select{
-		Label: "Pilih Berdasarkan",
-		Items: []string{"Komentar", "Kategori"},
-		Templates: &promptui.SelectTemplates{
-			Label:    "{{ . | blue }}:",
-			Active:   "\u27A1 {{ . | cyan }}",
-			Inactive: "  {{ . | cyan }}",
-			Selected: "\u2705 {{ . | blue | cyan }}",
-		},
+	roles := []string{"user", RoleModerator, RoleAdmin}
+	cursor := 0
+	for i, role := range roles {
+		if role == user.Role {
+			cursor = i
+		}
 	}
 
-	promptMode := promptui.Select{
-		Label: "Pilih Mode",
-		Items: []string{"Ascending", "Descending"},
+	rolePrompt := promptui.Select{
+		Label:     fmt.Sprintf("Role %s", user.Username),
+		Items:     roles,
+		CursorPos: cursor,
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -1105,190 +1330,2269 @@ func (a *adminService) SortingKomentar() error {
 		},
 	}
 
-	_, sortBy, err := prompt.Run()
+	_, label, err := rolePrompt.Run()
 	if err != nil {
-		return err
+		return helper.ErrBack
 	}
 
-	_, sortMode, err := promptMode.Run()
-	if err != nil {
-		return err
+	role := label
+	if role == "user" {
+		role = ""
 	}
 
-	modeInt := 0
-	if sortMode == "Descending" {
-		modeInt = 1
+	if role != RoleAdmin && a.lastAdmin(user) {
+		color.Red("User %s adalah admin terakhir, tunjuk admin lain terlebih dahulu", user.Username)
+		helper.Pause()
+		return helper.ErrBack
 	}
 
-	switch sortBy {
-	case "Komentar":
-		err = a.sortCommentByKomentar(modeInt)
-	case "Kategori":
-		err = a.sortCommentByKategori(modeInt)
-	}
+	err = a.userService.SetUserRole(index, role)
 	if err != nil {
 		return err
 	}
 
+	a.audit.Record("set_role_user", user.Id, user, global.Users[index])
+
+	color.Green("Role %s diubah menjadi %s", user.Username, label)
+	helper.Pause()
+
 	return nil
 }
 
-// sortCommentByKomentar sorts and displays comments based on their text content.
-//
-// This method sorts the comments using the comment repository's SortCommentsByComment
-// function, then displays the results in a formatted table. The sorting direction
-// is determined by the mode parameter.
+// lastAdmin reports whether a user is the only account left with the admin role.
 //
 // Parameters:
-//   - mode: Integer determining sort order (0 for ascending, 1 for descending)
-//
-// The function workflow:
-// 1. Retrieves sorted comments from the repository
-// 2. Clears the screen and displays sorting interface header
-// 3. Creates and populates a table with the sorted comments
-// 4. Renders the table to standard output
-// 5. Waits for user input (via Scanln) before returning
+//   - user: The user to check
 //
 // Returns:
-//   - error: Any error encountered during the sorting process or display
-func (a *adminService) sortCommentByKomentar(mode int) error {
-	var comments [255]model.Comment
+//   - bool: true if removing the user or its role would leave no admin account
+func (a *adminService) lastAdmin(user model.User) bool {
+	return user.Role == RoleAdmin && a.userService.AdminCount() <= 1
+}
 
-	err := a.commentRepo.SortCommentsByComment(&comments, mode)
-	if err != nil {
-		return err
+// validateUserNumber validates the number of a user typed into a prompt, as shown by
+// ShowUserTable.
+//
+// Parameters:
+//   - input: The typed number
+//
+// Returns:
+//   - error: An error if the input is empty, not a user number or the number of a user
+//     pending deletion, nil otherwise
+func validateUserNumber(input string) error {
+	if input == "" {
+		return fmt.Errorf("input cannot be empty")
 	}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > SORTING")
-	color.Yellow("========================================")
-	color.Yellow("=               SORTING                =")
-	color.Yellow("========================================")
-
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
-	j := 0
-	for i := 0; i < global.CommentCount; i++ {
-		j++
-		t.AppendRow(table.Row{
-			j,
-			comments[i].Komentar,
-			comments[i].Kategori,
-		})
+	index, err := strconv.Atoi(input)
+	if err != nil || index < 1 || index > global.UserCount || !global.Users[index-1].DeletedAt.IsZero() {
+		return fmt.Errorf("invalid user number")
 	}
-	t.SetStyle(table.StyleColoredBright)
-	t.Render()
-
-	fmt.Scanln()
 
 	return nil
 }
 
-// sortCommentByKategori sorts and displays comments based on their category.
-//
-// This method sorts the comments using the comment repository's SortCommentsByKategori
-// function, then displays the results in a formatted table. The sorting direction
-// is determined by the mode parameter.
-//
-// Parameters:
-//   - mode: Integer determining sort order (0 for ascending, 1 for descending)
+// UserDihapus displays the users pending deletion and lets the admin restore them or
+// remove them permanently before their grace period has passed.
 //
 // The function workflow:
-// 1. Retrieves sorted comments from the repository
-// 2. Clears the screen and displays sorting interface header
-// 3. Creates and populates a table with the sorted comments
-// 4. Renders the table to standard output
-// 5. Waits for user input (via Scanln) before returning
+//  1. Removes the users whose grace period has passed via userService.PurgeDeletedUsers
+//  2. Clears the screen and displays the pending users with the time they were deleted
+//     and the time left before they are removed permanently
+//     - If there are none: Waits for the user to press Enter and returns helper.ErrBack
+//  3. Asks whether to restore a user, remove a user permanently or go back
+//  4. Prompts for the number of the user and restores it via userService.RestoreUser, or
+//     removes it via userService.DeleteUser after ConfirmDelete
+//  5. Returns helper.ErrContinue so the list is shown again
+//
+// Returns:
+//   - error: Storage errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) UserDihapus() error {
+	purged, err := a.userService.PurgeDeletedUsers()
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LIHAT USER > DIHAPUS", "USER DIHAPUS")
+
+	if purged > 0 {
+		color.Cyan("%d user melewati masa pulih dan dihapus permanen", purged)
+	}
+
+	grace := UserDeleteGrace()
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Username", "Dihapus", "Hapus Permanen", "Sisa"})
+
+	pending := 0
+	for i := 0; i < global.UserCount; i++ {
+		user := global.Users[i]
+		if user.DeletedAt.IsZero() {
+			continue
+		}
+
+		pending++
+		removeAt := user.DeletedAt.Add(grace)
+		t.AppendRow(table.Row{i + 1, user.Username, user.DeletedAt.Format("2006-01-02 15:04"), removeAt.Format("2006-01-02 15:04"), formatGrace(time.Until(removeAt))})
+	}
+
+	if pending == 0 {
+		color.Cyan("Tidak ada user yang menunggu dihapus permanen")
+		helper.Pause()
+		return helper.ErrBack
+	}
+
+	t.Render()
+
+	actionPrompt := promptui.Select{
+		Label: "Pilih Aksi",
+		Items: []string{"Pulihkan", "Hapus Permanen", "Kembali"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, action, err := actionPrompt.Run()
+	if err != nil || action == "Kembali" {
+		return helper.ErrBack
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Masukkan Nomor User",
+		Validate: func(input string) error {
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 1 || index > global.UserCount || global.Users[index-1].DeletedAt.IsZero() {
+				return fmt.Errorf("invalid user number")
+			}
+
+			return nil
+		},
+	}
+
+	input, err := prompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	index, _ := strconv.Atoi(input)
+	index--
+	user := global.Users[index]
+	username := user.Username
+
+	if action == "Pulihkan" {
+		err = a.userService.RestoreUser(index)
+		if err != nil {
+			return err
+		}
+
+		a.audit.Record("restore_user", user.Id, user, global.Users[index])
+
+		color.Green("User %s dipulihkan", username)
+		helper.Pause()
+		return helper.ErrContinue
+	}
+
+	if !helper.ConfirmDelete(username) {
+		return helper.ErrContinue
+	}
+
+	err = a.userService.DeleteUser(index)
+	if err != nil {
+		return err
+	}
+
+	a.audit.Record("purge_user", user.Id, user, nil)
+
+	color.Green("User %s dihapus permanen", username)
+	helper.Pause()
+	return helper.ErrContinue
+}
+
+// formatGrace formats a grace period or the time left of one in hours and minutes.
+//
+// Parameters:
+//   - d: The duration to format, negative durations are shown as 0 minutes
+//
+// Returns:
+//   - string: The duration, e.g. "23 jam 5 menit"
+func formatGrace(d time.Duration) string {
+	d = max(d, 0).Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	if hours == 0 {
+		return fmt.Sprintf("%d menit", minutes)
+	}
+
+	return fmt.Sprintf("%d jam %d menit", hours, minutes)
+}
+
+// LihatComment displays the comment management menu and captures the user's selection.
+//
+// It clears the screen, displays a formatted header for the comment data view,
+// shows the current comment table, and presents an interactive menu with comment
+// management options (Search, Sorting, Detail, Add, Edit, Delete, Bulk, Review, Saran Label, Status, Tindak Lanjut, Catatan, Terakhir Dilihat, Favorit, Export, Exit).
+//
+// Parameters:
+//   - result: Pointer to store the selected menu option as a string
+//
+// Returns:
+//   - error: Any error encountered during displaying the comment table or menu selection
+func (a *adminService) LihatComment(result *string) error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR", "DATA KOMENTAR")
+
+	err := a.commentService.ShowTable()
+	if err != nil {
+		return err
+	}
+
+	labels, keys := helper.MenuItems("admin_komentar", a.commentService.PageKeys([]string{"Search", "Sorting", "Detail", "Add", "Edit", "Delete", "Bulk", "Review", "Saran Label", "Status", "Tindak Lanjut", "Catatan", "Terakhir Dilihat", "Favorit", "Export", "Exit"}))
+
+	prompt := promptui.Select{
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("admin_komentar", keys),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, result) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*result = keys[index]
+	helper.RememberMenu("admin_komentar", keys[index])
+	a.commentService.TurnPage(*result)
+
+	return nil
+}
+
+// SearchAdminComment handles the comment search functionality in the admin interface.
+//
+// It displays a search interface that prompts the user to enter a keyword to search for,
+// performs the search using the comment repository, and displays the filtered results
+// in a table format. The function follows this workflow:
+//
+// 1. Clears the screen and displays the search interface header
+// 2. Prompts user to enter a search keyword
+// 3. Searches comments via commentRepo.RankSearch
+// 4. Displays matching results in a formatted table with a relevance column, the most relevant first
+// 5. Asks if user wants to search again
+//   - If yes: Returns helper.ErrContinue to loop back to search
+//   - If no: Returns helper.ErrBack to go back to previous menu
+//
+// Returns:
+//   - error: Search errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) SearchAdminComment() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > CARI KOMENTAR", "CARI KOMENTAR")
+
+	searchPrompt := promptui.Prompt{
+		Label: "Masukkan kata kunci untuk mencari komentar",
+	}
+
+	searchInput, err := searchPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	var results [255]model.SearchResult
+	n, err := a.commentRepo.RankSearch(searchInput, &results)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > CARI KOMENTAR", "CARI KOMENTAR")
+	renderSearchResults(results, n)
+
+	askPrompt := promptui.Prompt{
+		Label:     "Search Again?",
+		IsConfirm: true,
+	}
+
+	_, err = askPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	return helper.ErrContinue
+}
+
+// AddComment handles the comment creation process in the admin interface.
+//
+// It displays a comment creation interface where admins can add new comments to the system.
+// The function follows this workflow:
+// 1. Clears the screen and displays the comment creation interface header
+// 2. Collects comment text and category through CreateCommentForm
+// 3. Creates a new comment record using the comment repository
+//
+// Error handling:
+//   - Form errors: Displays the error message in red text and offers to try again
+//   - If user chooses to try again: Returns helper.ErrContinue to restart the process
+//   - If user chooses not to try again: Returns helper.ErrBack to go to previous menu
+//   - Creation errors: Follows the same error handling pattern as form errors
+//
+// Returns:
+//   - nil: When comment creation succeeds
+//   - error: Creation errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) AddComment() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > TAMBAH KOMENTAR", "TAMBAH KOMENTAR")
+
+	var komentar, kategori string
+
+	askPrompt := promptui.Prompt{
+		Label:     "Try Again?",
+		IsConfirm: true,
+	}
+
+	err := a.commentService.CreateCommentForm(&komentar, &kategori)
+	if err != nil {
+		color.Red(err.Error())
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		return helper.ErrContinue
+	}
+
+	comment := model.Comment{
+		Komentar: komentar,
+		Kategori: kategori,
+	}
+
+	err = a.commentRepo.Create(&comment, 0)
+	if err != nil {
+		color.Red(err.Error())
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		return helper.ErrContinue
+	}
+
+	a.commentRepo.FindCommentById(comment.Id, &comment)
+	a.audit.Record("create_comment", comment.Id, nil, comment)
+
+	return nil
+}
+
+// EditComment handles the comment editing process in the admin interface.
+//
+// It displays the comment editing interface where admins can modify existing comments.
+// The function follows this workflow:
+// 1. Clears the screen and displays the edit interface header
+// 2. Shows the current comment table via commentService.ShowTable
+// 3. Prompts admin to select a comment by ID with input validation:
+//   - Ensures input is not empty
+//   - Verifies input is a valid number within the range of existing comments
+//
+// 4. Collects updated information (comment text and category) via EditForm
+// 5. Updates the comment via commentService.EditComment
+// 6. Asks if admin wants to try editing again
+//   - If yes: Returns helper.ErrContinue to restart the process
+//   - If no: Returns helper.ErrBack to go back to previous menu
+//
+// Returns:
+//   - error: Editing errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) EditComment() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > EDIT KOMENTAR", "EDIT KOMENTAR")
+
+	err := a.commentService.ShowTable()
+	if err != nil {
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar yang ingin diubah",
+		Validate: func(input string) error {
+			if input == "" {
+				return fmt.Errorf("input tidak boleh kosong")
+			}
+
+			id, err := strconv.Atoi(input)
+			if err != nil || id < 1 {
+				return fmt.Errorf("id komentar tidak valid")
+			}
+
+			return nil
+		},
+	}
+
+	idInput, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(idInput)
+	if err != nil {
+		return err
+	}
+
+	var current model.Comment
+	err = a.commentRepo.FindCommentById(id, &current)
+	if err != nil {
+		return err
+	}
+
+	var komentar, kategori string
+
+	err = a.commentService.EditForm(&komentar, &kategori)
+	if err != nil {
+		return err
+	}
+
+	err = a.commentService.EditComment(id, model.Comment{
+		Komentar: komentar,
+		Kategori: kategori,
+		Version:  current.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	a.recordComment("edit_comment", current)
+
+	askPrompt := promptui.Prompt{
+		Label:     "Try Again?",
+		IsConfirm: true,
+	}
+
+	_, err = askPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	return helper.ErrContinue
+}
+
+// recordComment records a change of a comment in the audit log, with the comment as it
+// is stored after the change as the new value.
+//
+// Parameters:
+//   - action: The change, e.g. "edit_comment"
+//   - before: The comment before the change
+func (a *adminService) recordComment(action string, before model.Comment) {
+	var after model.Comment
+	err := a.commentRepo.FindCommentById(before.Id, &after)
+	if err != nil {
+		a.audit.Record(action, before.Id, before, nil)
+		return
+	}
+
+	a.audit.Record(action, before.Id, before, after)
+}
+
+// DeleteComment handles the comment deletion process in the admin interface.
+//
+// It displays the comment deletion interface where admins can remove existing comments.
+// The function follows this workflow:
+// 1. Clears the screen and displays the deletion interface header
+// 2. Shows the current comment table via commentService.ShowTable
+// 3. Prompts admin to select a comment by ID with input validation:
+//   - Ensures input is not empty
+//   - Verifies input is a valid number within the range of existing comments
+//
+// 4. Shows the comment text and asks the admin to confirm the deletion
+//   - If the admin declines: Returns helper.ErrBack to go back to previous menu
+//
+// 5. Deletes the selected comment using the comment repository
+// 6. If deletion fails:
+//   - Displays the error message in red text
+//   - Asks if admin wants to try again
+//   - Returns helper.ErrContinue to retry or helper.ErrBack to return to previous menu
+//
+// Returns:
+//   - nil: When comment deletion succeeds
+//   - error: Deletion errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) DeleteComment() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > DELETE KOMENTAR", "DELETE KOMENTAR")
+
+	err := a.commentService.ShowTable()
+	if err != nil {
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar yang ingin dihapus",
+		Validate: func(input string) error {
+			if input == "" {
+				return fmt.Errorf("input tidak boleh kosong")
+			}
+
+			id, err := strconv.Atoi(input)
+			if err != nil || id < 1 {
+				return fmt.Errorf("id komentar tidak valid")
+			}
+
+			return nil
+		},
+	}
+
+	idInput, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(idInput)
+	if err != nil {
+		return err
+	}
+
+	askPrompt := promptui.Prompt{
+		Label:     "Try Again?",
+		IsConfirm: true,
+	}
+
+	var comment model.Comment
+	err = a.commentRepo.FindCommentById(id, &comment)
+	if err == nil && !helper.ConfirmDelete(comment.Komentar) {
+		return helper.ErrBack
+	}
+
+	err = a.commentRepo.DeleteComment(id)
+	if err != nil {
+		color.Red(err.Error())
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		return helper.ErrContinue
+	}
+
+	a.audit.Record("delete_comment", id, comment, nil)
+
+	return nil
+}
+
+// SortingKomentar handles the comment sorting functionality in the admin interface.
+//
+// It displays a sorting interface where admins can select sorting criteria and order.
+// The function follows this workflow:
+// 1. Clears the screen and displays the sorting interface header
+// 2. Presents two selection menus to the admin:
+//   - First menu: Select sorting criteria (by comment text "Komentar" or by category "Kategori")
+//   - Second menu: Select sorting order (Ascending or Descending)
+//
+// 3. Based on the selections, calls the appropriate sorting method:
+//   - sortCommentByKomentar: Sorts comments by their text content
+//   - sortCommentByKategori: Sorts comments by their category
+//
+// The sorting mode is converted to an integer (0 for Ascending, 1 for Descending)
+// before being passed to the sorting functions.
+//
+// Returns:
+//   - error: Any error encountered during the sorting process or menu navigation
+func (a *adminService) SortingKomentar() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > SORTING", "SORTING")
+
+	prompt := promptui.Select{
+		Label:     "Pilih Berdasarkan",
+		Items:     []string{"Komentar", "Kategori"},
+		CursorPos: helper.MenuCursor("admin_sorting", []string{"Komentar", "Kategori"}),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	promptMode := promptui.Select{
+		Label:     "Pilih Mode",
+		Items:     []string{"Ascending", "Descending"},
+		CursorPos: helper.MenuCursor("admin_sorting_mode", []string{"Ascending", "Descending"}),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, sortBy, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	helper.RememberMenu("admin_sorting", sortBy)
+
+	_, sortMode, err := promptMode.Run()
+	if err != nil {
+		return err
+	}
+	helper.RememberMenu("admin_sorting_mode", sortMode)
+
+	modeInt := 0
+	if sortMode == "Descending" {
+		modeInt = 1
+	}
+
+	switch sortBy {
+	case "Komentar":
+		err = a.sortCommentByKomentar(modeInt)
+	case "Kategori":
+		err = a.sortCommentByKategori(modeInt)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sortCommentByKomentar sorts and displays comments based on their text content.
+//
+// This method sorts the comments using the comment repository's SortCommentsByComment
+// function, then displays the results in a formatted table. The sorting direction
+// is determined by the mode parameter.
+//
+// Parameters:
+//   - mode: Integer determining sort order (0 for ascending, 1 for descending)
+//
+// The function workflow:
+// 1. Retrieves sorted comments from the repository
+// 2. Clears the screen and displays sorting interface header
+// 3. Creates and populates a table with the sorted comments
+// 4. Renders the table to standard output
+// 5. Waits for user input (via helper.Pause) before returning
+//
+// Returns:
+//   - error: Any error encountered during the sorting process or display
+func (a *adminService) sortCommentByKomentar(mode int) error {
+	var comments [255]model.Comment
+
+	err := a.commentRepo.SortCommentsByComment(&comments, mode)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > SORTING", "SORTING")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
+	j := 0
+	n := a.commentRepo.CountComments()
+	for i := 0; i < n; i++ {
+		j++
+		t.AppendRow(table.Row{
+			j,
+			comments[i].Komentar,
+			comments[i].Kategori,
+		})
+	}
+	t.Render()
+
+	helper.Pause()
+
+	return nil
+}
+
+// sortCommentByKategori sorts and displays comments based on their category.
+//
+// This method sorts the comments using the comment repository's SortCommentsByKategori
+// function, then displays the results in a formatted table. The sorting direction
+// is determined by the mode parameter.
+//
+// Parameters:
+//   - mode: Integer determining sort order (0 for ascending, 1 for descending)
+//
+// The function workflow:
+// 1. Retrieves sorted comments from the repository
+// 2. Clears the screen and displays sorting interface header
+// 3. Creates and populates a table with the sorted comments
+// 4. Renders the table to standard output
+// 5. Waits for user input (via helper.Pause) before returning
 //
 // Returns:
 //   - error: Any error encountered during the sorting process or display
 func (a *adminService) sortCommentByKategori(mode int) error {
 	var comments [255]model.Comment
 
-	err := a.commentRepo.SortCommentsByKategori(&comments, mode)
+	err := a.commentRepo.SortCommentsByKategori(&comments, mode)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > SORTING", "SORTING")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
+	j := 0
+	n := a.commentRepo.CountComments()
+	for i := 0; i < n; i++ {
+		j++
+		t.AppendRow(table.Row{
+			j,
+			comments[i].Komentar,
+			comments[i].Kategori,
+		})
+	}
+	t.Render()
+
+	helper.Pause()
+
+	return nil
+}
+
+// grafikFilter holds the criteria the Grafik screen counts the comments with. The zero
+// value counts every comment of the active project.
+type grafikFilter struct {
+	// From and To are the period the comments were created in, zero for an open side
+	From time.Time
+	To   time.Time
+
+	// Periode describes the period, empty for every comment
+	Periode string
+
+	// UserId is the author to count, only used when Username is set
+	UserId   int
+	Username string
+
+	// Sumber is the source to count, only used when BySumber is set
+	Sumber   string
+	BySumber bool
+
+	// ProjectId is the project to count instead of the active project, only used when
+	// Proyek is set
+	ProjectId int
+	Proyek    string
+}
+
+// query applies the criteria of the filter to a comment query.
+//
+// Parameters:
+//   - q: The query to restrict
+//
+// Returns:
+//   - repository.CommentQuery: The restricted query
+func (f grafikFilter) query(q repository.CommentQuery) repository.CommentQuery {
+	q = q.CreatedBetween(f.From, f.To)
+
+	if f.Username != "" {
+		q = q.WhereUser(f.UserId)
+	}
+
+	if f.BySumber {
+		q = q.WhereSumber(f.Sumber)
+	}
+
+	if f.Proyek != "" {
+		q = q.WhereProject(f.ProjectId)
+	}
+
+	return q
+}
+
+// String describes the criteria of the filter for the Grafik screen.
+//
+// Returns:
+//   - string: The criteria separated by commas, "Tanpa filter" when nothing is filtered
+func (f grafikFilter) String() string {
+	var parts []string
+
+	if f.Periode != "" {
+		parts = append(parts, "Periode: "+f.Periode)
+	}
+
+	if f.Username != "" {
+		parts = append(parts, "User: "+f.Username)
+	}
+
+	if f.BySumber {
+		parts = append(parts, "Sumber: "+sumberLabel(f.Sumber))
+	}
+
+	if f.Proyek != "" {
+		parts = append(parts, "Proyek: "+f.Proyek)
+	}
+
+	if len(parts) == 0 {
+		return "Tanpa filter"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// sumberLabel returns the label of a comment source in the Grafik filter.
+//
+// Parameters:
+//   - sumber: The source of a comment
+//
+// Returns:
+//   - string: The source, or "Aplikasi" for the comments entered in the app
+func sumberLabel(sumber string) string {
+	if sumber == "" {
+		return "Aplikasi"
+	}
+
+	return sumber
+}
+
+// Grafik displays statistics and data visualization about comments and users.
+//
+// This method displays a statistical summary of the application data as a table with
+// the columns Kategori, Jumlah, Persentase and Bar:
+// - One row per sentiment category (Positif, Netral, Negatif)
+// - A totals row with the total number of comments
+// - A title with the total number of users in the system
+//
+// Below it a second table compares the number of users with the number of comments as
+// two bars scaled to the larger of both, with the average number of comments per user.
+//
+// The comments are counted with the filter of the screen, which is kept while the admin
+// session lasts, so a question like "the sentiment of the Instagram comments of the last
+// week" is answered by setting the source and the period.
+//
+// The function workflow:
+// 1. Clears the screen and displays the statistics interface header and the active filter
+// 2. Counts the comments of each sentiment category that match the filter
+// 3. Computes the percentage of each category against the number of matching comments
+// 4. Renders the table with a proportional bar per category, colored by sentiment
+// 5. Renders the user versus comment bars and the comment lengths per category, see
+// repository.CommentQuery.LengthStats
+// 6. Asks which criterion to change: Periode, User, Sumber, Proyek or Reset Filter
+//   - The criterion is asked with grafikPeriode, grafikUser, grafikSumber or grafikProyek
+//   - Kembali returns helper.ErrBack
+//
+// If any error occurs during data retrieval, the function immediately returns the error.
+//
+// Returns:
+//   - error: Any error encountered during data retrieval, helper.ErrContinue to show the
+//     statistics again with the changed filter, or helper.ErrBack to leave the screen
+func (a *adminService) Grafik() error {
+	var comments [255]model.Comment
+
+	helper.Header("MENU > ADMIN > GRAFIK", "GRAFIK")
+	color.Cyan("Filter: %s", a.grafik)
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+	counts := make([]int, len(categories))
+
+	for i, kategori := range categories {
+		count, err := a.grafik.query(a.commentRepo.Comments()).WhereKategori(kategori).Find(&comments)
+		if err != nil {
+			return err
+		}
+		counts[i] = count
+	}
+
+	total, err := a.grafik.query(a.commentRepo.Comments()).Find(&comments)
+	if err != nil {
+		return err
+	}
+
+	barColors := map[string]*color.Color{
+		"Positif": color.New(color.FgGreen),
+		"Netral":  color.New(color.FgYellow),
+		"Negatif": color.New(color.FgRed),
+	}
+
+	t := helper.NewTable()
+	t.SetTitle("Jumlah User: %d", global.UserCount)
+	t.AppendHeader(table.Row{"Kategori", "Jumlah", "Persentase", "Bar"})
+	for i, kategori := range categories {
+		t.AppendRow(table.Row{
+			kategori,
+			counts[i],
+			fmt.Sprintf("%.1f%%", percentage(counts[i], total)),
+			barColors[kategori].Sprint(bar(counts[i], total, 20)),
+		})
+	}
+	t.AppendFooter(table.Row{
+		"Total",
+		total,
+		fmt.Sprintf("%.1f%%", percentage(total, total)),
+		"",
+	})
+	t.Render()
+
+	scale := max(global.UserCount, total)
+
+	u := helper.NewTable()
+	u.SetTitle("User vs Komentar")
+	u.AppendHeader(table.Row{"Data", "Jumlah", "Bar"})
+	u.AppendRow(table.Row{"User", global.UserCount, color.CyanString(bar(global.UserCount, scale, 20))})
+	u.AppendRow(table.Row{"Komentar", total, color.BlueString(bar(total, scale, 20))})
+	if global.UserCount > 0 {
+		u.AppendFooter(table.Row{"Komentar per User", fmt.Sprintf("%.1f", float64(total)/float64(global.UserCount)), ""})
+	}
+	u.Render()
+
+	lengths, err := a.grafik.query(a.commentRepo.Comments()).LengthStats()
+	if err != nil {
+		return err
+	}
+
+	l := helper.NewTable()
+	l.SetTitle("Panjang Komentar (karakter)")
+	l.AppendHeader(table.Row{"Kategori", "Jumlah", "Rata-rata", "Median", "Terpendek", "Terpanjang"})
+	for _, stats := range lengths {
+		row := table.Row{stats.Kategori, stats.Count, fmt.Sprintf("%.1f", stats.Average), fmt.Sprintf("%.1f", stats.Median), stats.Shortest, stats.Longest}
+		if stats.Kategori == "Semua" {
+			l.AppendFooter(row)
+			continue
+		}
+
+		l.AppendRow(row)
+	}
+	l.Render()
+
+	prompt := promptui.Select{
+		Label: "Filter Grafik",
+		Items: []string{"Periode", "User", "Sumber", "Proyek", "Reset Filter", "Kembali"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, result, err := prompt.Run()
+	if err != nil || result == "Kembali" {
+		return helper.ErrBack
+	}
+
+	switch result {
+	case "Periode":
+		err = a.grafikPeriode()
+	case "User":
+		err = a.grafikUser()
+	case "Sumber":
+		err = a.grafikSumber()
+	case "Proyek":
+		err = a.grafikProyek()
+	case "Reset Filter":
+		a.grafik = grafikFilter{}
+	}
+
+	if err != nil && !errors.Is(err, helper.ErrBack) {
+		color.Red(err.Error())
+		helper.Pause()
+	}
+
+	return helper.ErrContinue
+}
+
+// grafikPeriode asks for the period of the Grafik filter: every comment, today, the last
+// 7 or 30 days, this month, or a range of dates entered as YYYY-MM-DD where either side
+// may be left empty. The end date of a range is included.
+//
+// Returns:
+//   - error: helper.ErrBack when a prompt is cancelled, nil when the period was set
+func (a *adminService) grafikPeriode() error {
+	prompt := promptui.Select{
+		Label: "Periode",
+		Items: []string{"Semua", "Hari Ini", "7 Hari Terakhir", "30 Hari Terakhir", "Bulan Ini", "Rentang Tanggal"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, periode, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	var from, to time.Time
+	switch periode {
+	case "Semua":
+		periode = ""
+	case "Hari Ini":
+		from = today
+	case "7 Hari Terakhir":
+		from = today.AddDate(0, 0, -6)
+	case "30 Hari Terakhir":
+		from = today.AddDate(0, 0, -29)
+	case "Bulan Ini":
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	case "Rentang Tanggal":
+		validate := func(input string) error {
+			if input == "" {
+				return nil
+			}
+
+			_, err := time.ParseInLocation("2006-01-02", input, time.Local)
+			if err != nil {
+				return fmt.Errorf("format tanggal: YYYY-MM-DD")
+			}
+
+			return nil
+		}
+
+		fromPrompt := promptui.Prompt{Label: "Dari (YYYY-MM-DD, kosong = awal)", Validate: validate}
+		start, err := fromPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		toPrompt := promptui.Prompt{Label: "Sampai (YYYY-MM-DD, kosong = sekarang)", Validate: validate}
+		end, err := toPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		if start != "" {
+			from, _ = time.ParseInLocation("2006-01-02", start, time.Local)
+		}
+		if end != "" {
+			to, _ = time.ParseInLocation("2006-01-02", end, time.Local)
+			to = to.AddDate(0, 0, 1)
+		}
+
+		if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+			return fmt.Errorf("tanggal awal harus sebelum tanggal akhir")
+		}
+
+		if start == "" {
+			start = "awal"
+		}
+		if end == "" {
+			end = "sekarang"
+		}
+
+		periode = fmt.Sprintf("%s s/d %s", start, end)
+	}
+
+	a.grafik.From = from
+	a.grafik.To = to
+	a.grafik.Periode = periode
+
+	return nil
+}
+
+// grafikUser asks for the author of the Grafik filter. The username is completed with the
+// stored usernames; an empty username counts the comments of every user.
+//
+// Returns:
+//   - error: helper.ErrBack when a prompt is cancelled, or an error for an unknown username
+func (a *adminService) grafikUser() error {
+	prompt := promptui.Prompt{
+		Label: "Username (kosong = semua)",
+	}
+
+	input, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	username, err := completeUsername(a.userService.CompleteUsername, strings.TrimSpace(input))
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	if username == "" {
+		a.grafik.UserId = 0
+		a.grafik.Username = ""
+		return nil
+	}
+
+	var user model.User
+	err = a.userService.FindUserByUsername(username, &user)
+	if err != nil {
+		return err
+	}
+
+	a.grafik.UserId = user.Id
+	a.grafik.Username = user.Username
+
+	return nil
+}
+
+// grafikSumber asks for the source of the Grafik filter, chosen from the sources of the
+// stored comments. "Aplikasi" stands for the comments entered in the app.
+//
+// Returns:
+//   - error: helper.ErrBack when the prompt is cancelled, or an error if the comments cannot be read
+func (a *adminService) grafikSumber() error {
+	seen := map[string]bool{}
+	var sources []string
+
+	err := a.commentRepo.ForEachComment(func(comment model.Comment) bool {
+		key := strings.ToLower(comment.Sumber)
+		if !seen[key] {
+			seen[key] = true
+			sources = append(sources, comment.Sumber)
+		}
+
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(sources)
+
+	labels := []string{"Semua"}
+	for _, sumber := range sources {
+		labels = append(labels, sumberLabel(sumber))
+	}
+
+	prompt := promptui.Select{
+		Label: "Sumber",
+		Items: labels,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	i, _, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	if i == 0 {
+		a.grafik.Sumber = ""
+		a.grafik.BySumber = false
+		return nil
+	}
+
+	a.grafik.Sumber = sources[i-1]
+	a.grafik.BySumber = true
+
+	return nil
+}
+
+// grafikProyek asks for the project of the Grafik filter. "Proyek Aktif" counts the
+// comments of the active project again, "Tanpa Proyek" the comments without a project.
+//
+// Returns:
+//   - error: helper.ErrBack when the prompt is cancelled, nil when the project was set
+func (a *adminService) grafikProyek() error {
+	labels := []string{"Proyek Aktif", "Tanpa Proyek"}
+	for i := 0; i < global.ProjectCount; i++ {
+		labels = append(labels, global.Projects[i].Nama)
+	}
+
+	prompt := promptui.Select{
+		Label: "Proyek",
+		Items: labels,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	i, proyek, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	switch i {
+	case 0:
+		a.grafik.ProjectId = 0
+		a.grafik.Proyek = ""
+	case 1:
+		a.grafik.ProjectId = 0
+		a.grafik.Proyek = proyek
+	default:
+		a.grafik.ProjectId = global.Projects[i-2].Id
+		a.grafik.Proyek = proyek
+	}
+
+	return nil
+}
+
+// percentage calculates what percentage part is of total.
+//
+// Parameters:
+//   - part: The partial count
+//   - total: The total count
+//
+// Returns:
+//   - float64: The percentage in the range 0-100, or 0 when total is zero
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(part) * 100 / float64(total)
+}
+
+// bar renders a horizontal bar whose length is proportional to part/total.
+//
+// Parameters:
+//   - part: The value represented by the bar
+//   - total: The value that corresponds to a full-width bar
+//   - width: The number of characters of a full-width bar
+//
+// Returns:
+//   - string: The bar made of block characters, empty when total is zero
+func bar(part, total, width int) string {
+	if total == 0 {
+		return ""
+	}
+
+	return strings.Repeat("\u2588", part*width/total)
+}
+
+// BulkComment handles bulk deletion and bulk re-categorization of comments.
+//
+// The operation always runs as a dry-run first so the admin can see exactly what
+// would change before anything is written. The function follows this workflow:
+// 1. Clears the screen and displays the bulk operation header
+// 2. Asks for the action (Hapus or Ubah Kategori)
+// 3. Asks for the filters: source category ("Semua" for any) and an optional keyword
+// 4. For Ubah Kategori, asks for the target category
+// 5. Displays a preview table listing every matching comment and the planned change
+// 6. Asks whether the changes should be applied
+//   - If yes: Applies the change to each listed comment through the comment repository
+//   - If no: Returns helper.ErrBack without modifying storage
+//
+// Returns:
+//   - nil: When the bulk operation has been applied
+//   - error: Repository errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) BulkComment() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > BULK", "BULK KOMENTAR")
+
+	templates := &promptui.SelectTemplates{
+		Label:    "{{ . | blue }}:",
+		Active:   "\u27A1 {{ . | cyan }}",
+		Inactive: "  {{ . | cyan }}",
+		Selected: "\u2705 {{ . | blue | cyan }}",
+	}
+
+	actionPrompt := promptui.Select{
+		Label:     "Pilih Aksi",
+		Items:     []string{"Hapus", "Ubah Kategori"},
+		Templates: templates,
+	}
+
+	filterPrompt := promptui.Select{
+		Label:     "Filter Kategori",
+		Items:     []string{"Semua", "Positif", "Netral", "Negatif"},
+		Templates: templates,
+	}
+
+	keywordPrompt := promptui.Prompt{
+		Label: "Kata kunci (kosongkan untuk semua)",
+	}
+
+	_, action, err := actionPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	_, filter, err := filterPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	keyword, err := keywordPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	var target string
+	if action == "Ubah Kategori" {
+		targetPrompt := promptui.Select{
+			Label:     "Kategori Baru",
+			Items:     []string{"Positif", "Netral", "Negatif"},
+			Templates: templates,
+		}
+
+		_, target, err = targetPrompt.Run()
+		if err != nil {
+			return err
+		}
+	}
+
+	query := a.commentRepo.Comments().Contains(keyword)
+	if filter != "Semua" {
+		query = query.WhereKategori(filter)
+	}
+
+	var comments [255]model.Comment
+	found, err := query.Find(&comments)
+	if err != nil {
+		return err
+	}
+
+	var ids [255]int
+	var versions [255]int
+	var before [255]model.Comment
+	var n int
+
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > BULK > PRATINJAU", "PRATINJAU (DRY-RUN)")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori", "Perubahan"})
+	for i := 0; i < found; i++ {
+		if action == "Ubah Kategori" && comments[i].Kategori == target {
+			continue
+		}
+
+		change := "Dihapus"
+		if action == "Ubah Kategori" {
+			change = comments[i].Kategori + " -> " + target
+		}
+
+		ids[n] = comments[i].Id
+		versions[n] = comments[i].Version
+		before[n] = comments[i]
+		n++
+		t.AppendRow(table.Row{n, comments[i].Id, comments[i].Komentar, comments[i].Kategori, change})
+	}
+	t.Render()
+
+	if n == 0 {
+		color.Cyan("Tidak ada komentar yang cocok dengan filter.")
+		helper.Pause()
+		return helper.ErrBack
+	}
+
+	color.Cyan("%d komentar akan diubah. Belum ada data yang dimodifikasi.", n)
+
+	applyPrompt := promptui.Prompt{
+		Label:     "Terapkan perubahan?",
+		IsConfirm: true,
+	}
+
+	_, err = applyPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	err = a.tx.Run(func() error {
+		for i := 0; i < n; i++ {
+			var err error
+			if action == "Hapus" {
+				err = a.commentRepo.DeleteComment(ids[i])
+			} else {
+				err = a.commentRepo.EditComment(ids[i], model.Comment{Kategori: target, Version: versions[i]})
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if action == "Hapus" {
+			a.audit.Record("delete_comment", ids[i], before[i], nil)
+		} else {
+			a.recordComment("edit_comment", before[i])
+		}
+	}
+
+	return nil
+}
+
+// Journal displays the operation journal and lets the admin replay it onto an empty store.
+//
+// The function workflow:
+// 1. Clears the screen and displays the journal interface header
+// 2. Reads every recorded command from the journal repository
+// 3. Renders the commands in a table (time, command, target ID, user ID)
+// 4. Asks whether the journal should be replayed
+//   - If yes: Clears the store, replays every command and reports the totals
+//   - If no: Returns without modifying storage
+//
+// Returns:
+//   - error: Any error encountered while reading or replaying the journal
+func (a *adminService) Journal() error {
+	helper.Header("MENU > ADMIN > JOURNAL", "JOURNAL")
+
+	entries, err := a.journal.GetAllEntries()
+	if err != nil {
+		return err
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Waktu", "Command", "Id", "User Id"})
+	for i, entry := range entries {
+		t.AppendRow(table.Row{
+			i + 1,
+			entry.Timestamp.Format("2006-01-02 15:04:05"),
+			entry.Command,
+			entry.Id,
+			entry.UserId,
+		})
+	}
+	t.Render()
+
+	replayPrompt := promptui.Prompt{
+		Label:     "Replay journal ke store kosong?",
+		IsConfirm: true,
+	}
+
+	_, err = replayPrompt.Run()
+	if err != nil {
+		return nil
+	}
+
+	replayed, err := a.journal.Replay()
+	if err != nil {
+		return err
+	}
+
+	color.Green("%d command berhasil di-replay. Jumlah User: %d, Jumlah Komentar: %d", replayed, global.UserCount, global.CommentCount)
+	helper.Pause()
+
+	return nil
+}
+
+// DetailComment displays the detail screen of a single comment.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and the current comment table
+//  2. Prompts the admin to enter the ID of the comment to inspect
+//  3. Displays the comment's data, the admin note and its edit history (oldest revision first) and
+//     remembers the comment for the "Terakhir Dilihat" list
+//  4. Lets the admin select a revision and prints a colored word diff between
+//     that revision and the current text (removed words in red, added words in green)
+//  5. Asks whether the admin wants to compare another revision
+//     - If yes: Returns helper.ErrContinue to show the detail screen again
+//     - If no: Returns helper.ErrBack to go back to previous menu
+//
+// Returns:
+//   - error: Lookup errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) DetailComment() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > DETAIL KOMENTAR", "DETAIL KOMENTAR")
+
+	err := a.commentService.ShowTable()
+	if err != nil {
+		return err
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar yang ingin dilihat",
+		Validate: func(input string) error {
+			if input == "" {
+				return fmt.Errorf("input tidak boleh kosong")
+			}
+
+			_, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("id komentar harus berupa angka")
+			}
+
+			return nil
+		},
+	}
+
+	askPrompt := promptui.Prompt{
+		Label:     "Lihat Lagi?",
+		IsConfirm: true,
+	}
+
+	idInput, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(idInput)
+	if err != nil {
+		return err
+	}
+
+	var comment model.Comment
+	err = a.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		color.Red(err.Error())
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		return helper.ErrContinue
+	}
+
+	return a.showDetail(comment, askPrompt)
+}
+
+// Favorit displays the favorite comments of the admin, which are stored under UserId 0.
+// See CommentService.Favorit.
+//
+// Returns:
+//   - error: Lookup or repository errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) Favorit() error {
+	return a.commentService.Favorit(model.User{})
+}
+
+// TerakhirDilihat displays the comments most recently opened in the detail screen.
+//
+// The function workflow:
+//  1. Clears the screen and displays the header
+//  2. Lists the remembered comments, most recent first, in a selection menu.
+//     Comments that have been deleted since are left out
+//  3. Opens the detail screen of the chosen comment, see DetailComment
+//
+// Returns:
+//   - error: Lookup errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) TerakhirDilihat() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > TERAKHIR DILIHAT", "TERAKHIR DILIHAT")
+
+	var comments []model.Comment
+	var items []string
+	for _, id := range a.recent {
+		var comment model.Comment
+		if a.commentRepo.FindCommentById(id, &comment) != nil {
+			continue
+		}
+
+		comments = append(comments, comment)
+		items = append(items, fmt.Sprintf("#%d %s", comment.Id, helper.Truncate(comment.Komentar, 50)))
+	}
+
+	if len(comments) == 0 {
+		color.Cyan("Belum ada komentar yang dibuka di Detail.")
+		helper.Pause()
+		return helper.ErrBack
+	}
+
+	prompt := promptui.Select{
+		Label: "Buka Komentar",
+		Items: append(items, "Kembali"),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if err != nil || index == len(comments) {
+		return helper.ErrBack
+	}
+
+	askPrompt := promptui.Prompt{
+		Label:     "Lihat Lagi?",
+		IsConfirm: true,
+	}
+
+	return a.showDetail(comments[index], askPrompt)
+}
+
+// showDetail remembers a comment as recently viewed and displays its data, its edit
+// history and a diff against a chosen revision. See DetailComment for the workflow.
+//
+// Parameters:
+//   - comment: The comment to display
+//   - askPrompt: The prompt asking whether to view another comment
+//
+// Returns:
+//   - error: Lookup errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) showDetail(comment model.Comment, askPrompt promptui.Prompt) error {
+	a.remember(comment.Id)
+
+	id := comment.Id
+
+	var revisions [255]model.CommentRevision
+	n, err := a.commentRepo.GetCommentRevisions(id, &revisions)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > DETAIL KOMENTAR", "DETAIL KOMENTAR")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Id", "User Id", "Komentar", "Kategori", "Status", "Jumlah Revisi"})
+	t.AppendRow(table.Row{comment.Id, comment.UserId, comment.Komentar, comment.Kategori, repository.StatusOf(comment), n})
+	t.Render()
+
+	if comment.Catatan != "" {
+		color.Magenta("Catatan admin: %s", comment.Catatan)
+	}
+
+	if n == 0 {
+		color.Cyan("Komentar ini belum pernah diedit.")
+		helper.Pause()
+		return helper.ErrBack
+	}
+
+	h := helper.NewTable()
+	h.AppendHeader(table.Row{"Revisi", "Diedit Pada", "Komentar", "Kategori"})
+	items := make([]string, n)
+	for i := 0; i < n; i++ {
+		editedAt := revisions[i].EditedAt.Format("2006-01-02 15:04:05")
+		h.AppendRow(table.Row{i + 1, editedAt, revisions[i].Komentar, revisions[i].Kategori})
+		items[i] = fmt.Sprintf("Revisi %d (%s)", i+1, editedAt)
+	}
+	h.Render()
+
+	revisionPrompt := promptui.Select{
+		Label: "Bandingkan dengan versi sekarang",
+		Items: items,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := revisionPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	revision := revisions[index]
+	color.Cyan("Revisi %d -> Sekarang", index+1)
+	fmt.Println("Komentar:", helper.WordDiff(revision.Komentar, comment.Komentar))
+	if revision.Kategori != comment.Kategori {
+		fmt.Println("Kategori:", color.RedString("-"+revision.Kategori), color.GreenString("+"+comment.Kategori))
+	} else {
+		fmt.Println("Kategori:", comment.Kategori)
+	}
+
+	_, err = askPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	return helper.ErrContinue
+}
+
+// remember puts a comment at the front of the recently viewed list, which keeps the 10
+// most recent comments without duplicates.
+//
+// Parameters:
+//   - id: The ID of the viewed comment
+func (a *adminService) remember(id int) {
+	recent := []int{id}
+	for _, other := range a.recent {
+		if other != id && len(recent) < 10 {
+			recent = append(recent, other)
+		}
+	}
+
+	a.recent = recent
+}
+
+// ReviewKomentar handles the review queue of comments flagged as uncertain.
+//
+// A comment enters the queue when its category is typed with a trailing "?" during
+// rapid entry or second labeling. The function workflow:
+// 1. Clears the screen and displays the review header with the progress of the queue
+// 2. Shows every queued comment one at a time with its first and second label
+// 3. Asks the admin what to do with the comment:
+//   - Konfirmasi: Keeps the current category and marks the comment as reviewed
+//   - Positif/Netral/Negatif: Changes the category and marks the comment as reviewed
+//   - Lewati: Leaves the comment in the queue and moves on to the next one
+//   - Selesai: Stops reviewing and returns to the previous menu
+//
+// Returns:
+//   - error: Storage errors, or helper.ErrBack when the queue is finished or the admin stops
+func (a *adminService) ReviewKomentar() error {
+	var comments [255]model.Comment
+
+	err := a.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	var queue [255]model.Comment
+	var n, total, done int
+
+	for i := 0; i < global.CommentCount; i++ {
+		switch comments[i].Review {
+		case "ragu":
+			queue[n] = comments[i]
+			n++
+			total++
+		case "ditinjau":
+			total++
+			done++
+		}
+	}
+
+	prompt := promptui.Select{
+		Label: "Kategori Benar",
+		Items: []string{"Konfirmasi", "Positif", "Netral", "Negatif", "Lewati", "Selesai"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	for i := 0; i < n; i++ {
+		helper.Header("MENU > ADMIN > LIHAT KOMENTAR > REVIEW", "REVIEW KOMENTAR")
+		color.Cyan("Ditinjau %d dari %d (%.1f%%) %s", done, total, percentage(done, total), bar(done, total, 20))
+
+		t := helper.NewTable()
+		t.AppendHeader(table.Row{"Id", "Komentar", "Kategori", "Kategori 2"})
+		t.AppendRow(table.Row{queue[i].Id, queue[i].Komentar, queue[i].Kategori, queue[i].Kategori2})
+		t.Render()
+
+		_, choice, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case "Selesai":
+			return helper.ErrBack
+		case "Lewati":
+			continue
+		}
+
+		comment := queue[i]
+		err = a.tx.Run(func() error {
+			if choice != "Konfirmasi" && choice != comment.Kategori {
+				err := a.commentService.EditComment(comment.Id, model.Comment{Kategori: choice, Version: comment.Version})
+				if err != nil {
+					return err
+				}
+			}
+
+			return a.commentRepo.SetReview(comment.Id, "ditinjau")
+		})
+		if err != nil {
+			return err
+		}
+
+		a.recordComment("review_comment", comment)
+		done++
+	}
+
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > REVIEW", "REVIEW KOMENTAR")
+	color.Cyan("Ditinjau %d dari %d (%.1f%%) %s", done, total, percentage(done, total), bar(done, total, 20))
+
+	if n == 0 {
+		color.Cyan("Tidak ada komentar yang perlu ditinjau.")
+	}
+
+	helper.Pause()
+
+	return helper.ErrBack
+}
+
+// SaranLabel suggests which comments to label manually next (active learning).
+//
+// The function workflow:
+//  1. Prompts for the number of suggestions N (default 10)
+//  2. Ranks the comments of the active project that are not queued or reviewed yet by the
+//     confidence of the sentiment analyzer, least confident first
+//  3. Renders the first N comments with their category, the predicted category and the confidence
+//  4. Asks whether the suggestions should be added to the review queue
+//     - If yes: Flags every suggested comment as "ragu" so it shows up in ReviewKomentar
+//     - If no: Returns without modifying storage
+//
+// Returns:
+//   - error: Storage errors, or helper.ErrBack when there is nothing to suggest or nothing is queued
+func (a *adminService) SaranLabel() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > SARAN LABEL", "SARAN LABEL")
+
+	sizePrompt := promptui.Prompt{
+		Label:   "Jumlah saran",
+		Default: "10",
+		Validate: func(input string) error {
+			n, err := strconv.Atoi(input)
+			if err != nil || n < 1 {
+				return fmt.Errorf("jumlah harus angka lebih dari 0")
+			}
+
+			return nil
+		},
+	}
+
+	input, err := sizePrompt.Run()
 	if err != nil {
-		return err
+		return helper.ErrBack
 	}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > LIHAT KOMENTAR > SORTING")
-	color.Yellow("========================================")
-	color.Yellow("=               SORTING                =")
-	color.Yellow("========================================")
+	size, _ := strconv.Atoi(input)
 
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
-	j := 0
+	var comments [255]model.Comment
+	err = a.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	var candidates []model.Comment
+	confidence := map[int]float64{}
 	for i := 0; i < global.CommentCount; i++ {
-		j++
-		t.AppendRow(table.Row{
-			j,
-			comments[i].Komentar,
-			comments[i].Kategori,
-		})
+		if comments[i].Komentar == "" || comments[i].Review != "" {
+			continue
+		}
+
+		candidates = append(candidates, comments[i])
+		confidence[comments[i].Id] = a.sentiment.Confidence(comments[i].Komentar)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return confidence[candidates[i].Id] < confidence[candidates[j].Id]
+	})
+
+	if len(candidates) > size {
+		candidates = candidates[:size]
+	}
+
+	if len(candidates) == 0 {
+		color.Cyan("Tidak ada komentar yang bisa disarankan.")
+		helper.Pause()
+		return helper.ErrBack
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori", "Prediksi", "Keyakinan"})
+	for i, comment := range candidates {
+		predicted, _ := a.sentiment.Analyze(comment.Komentar)
+		t.AppendRow(table.Row{i + 1, comment.Id, comment.Komentar, comment.Kategori, predicted, fmt.Sprintf("%.2f", confidence[comment.Id])})
 	}
-	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 
-	fmt.Scanln()
+	queuePrompt := promptui.Prompt{
+		Label:     "Masukkan ke antrian review?",
+		IsConfirm: true,
+	}
+
+	_, err = queuePrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	err = a.tx.Run(func() error {
+		for _, comment := range candidates {
+			err := a.commentRepo.SetReview(comment.Id, "ragu")
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, comment := range candidates {
+		a.recordComment("review_comment", comment)
+	}
 
 	return nil
 }
 
-// Grafik displays statistics and data visualization about comments and users.
-//
-// This method displays a statistical summary of the application data, including:
-// - Total number of users in the system
-// - Total number of comments across all categories
-// - Comment distribution by sentiment categories (positive, neutral, negative)
+// followUpStatuses are the statuses of a comment that needs follow-up, in board order.
+var followUpStatuses = []string{"Open", "In Progress", "Closed"}
+
+// TindakLanjut displays the follow-up board of negative comments marked as
+// "perlu tindak lanjut".
 //
 // The function workflow:
-// 1. Clears the screen and displays the statistics interface header
-// 2. Shows the total user and comment counts from global variables
-// 3. Retrieves and displays comment counts for each sentiment category:
-//   - Positive comments via commentRepo.GetCommentByKategori("positif")
-//   - Neutral comments via commentRepo.GetCommentByKategori("netral")
-//   - Negative comments via commentRepo.GetCommentByKategori("negatif")
+//  1. Clears the screen and displays the number of items per status
+//  2. Renders the items that are not closed, Open before In Progress, oldest first
+//  3. Asks the admin what to do next:
+//     - Ubah: Prompts for the ID of a negative comment, its status and the assignee
+//     (an existing user, "admin" or "moderator", empty to unassign) and stores them.
+//     Returns helper.ErrContinue to show the board again
+//     - Exit: Returns helper.ErrBack to go back to the previous menu
 //
-// 4. Waits for user input (via Scanln) before returning
+// Returns:
+//   - error: Storage errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) TindakLanjut() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > TINDAK LANJUT", "TINDAK LANJUT")
+
+	var comments [255]model.Comment
+	err := a.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	var open []model.Comment
+	counts := map[string]int{}
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].TindakLanjut == "" {
+			continue
+		}
+
+		counts[comments[i].TindakLanjut]++
+		if comments[i].TindakLanjut != "Closed" {
+			open = append(open, comments[i])
+		}
+	}
+
+	sort.SliceStable(open, func(i, j int) bool {
+		if open[i].TindakLanjut != open[j].TindakLanjut {
+			return open[i].TindakLanjut == "Open"
+		}
+
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+
+	color.Cyan("Open: %d | In Progress: %d | Closed: %d", counts["Open"], counts["In Progress"], counts["Closed"])
+
+	if len(open) == 0 {
+		color.Cyan("Tidak ada komentar yang perlu ditindaklanjuti.")
+	} else {
+		t := helper.NewTable()
+		t.AppendHeader(table.Row{"Id", "Komentar", "Status", "Assignee", "Dibuat"})
+		for _, comment := range open {
+			assignee := comment.Assignee
+			if assignee == "" {
+				assignee = "-"
+			}
+
+			t.AppendRow(table.Row{comment.Id, helper.Truncate(comment.Komentar, 50), comment.TindakLanjut, assignee, comment.CreatedAt.Format("2006-01-02")})
+		}
+		t.Render()
+	}
+
+	menuPrompt := promptui.Select{
+		Label: "Pilih Menu",
+		Items: []string{"Ubah", "Exit"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, choice, err := menuPrompt.Run()
+	if err != nil || choice == "Exit" {
+		return helper.ErrBack
+	}
+
+	idPrompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar Negatif",
+		Validate: func(input string) error {
+			_, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("id komentar harus berupa angka")
+			}
+
+			return nil
+		},
+	}
+
+	idInput, err := idPrompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	id, _ := strconv.Atoi(idInput)
+
+	var comment model.Comment
+	err = a.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+		return helper.ErrContinue
+	}
+
+	if comment.Kategori != "Negatif" {
+		color.Red("Hanya komentar negatif yang bisa ditindaklanjuti")
+		helper.Pause()
+		return helper.ErrContinue
+	}
+
+	cursor := 0
+	for i, status := range followUpStatuses {
+		if status == comment.TindakLanjut {
+			cursor = i
+		}
+	}
+
+	statusPrompt := promptui.Select{
+		Label:     "Status",
+		Items:     followUpStatuses,
+		CursorPos: cursor,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, status, err := statusPrompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	assigneePrompt := promptui.Prompt{
+		Label:   "Assignee (kosongkan jika belum ada)",
+		Default: comment.Assignee,
+	}
+
+	assignee, err := assigneePrompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	assignee = strings.TrimSpace(assignee)
+	if assignee != RoleAdmin && assignee != RoleModerator {
+		assignee, err = completeUsername(a.userService.CompleteUsername, assignee)
+		if err != nil {
+			return helper.ErrContinue
+		}
+
+		if assignee != "" && !a.userService.IsUserExists(assignee, -1) {
+			color.Red("User %s tidak ditemukan", assignee)
+			helper.Pause()
+			return helper.ErrContinue
+		}
+	}
+
+	err = a.commentRepo.SetFollowUp(comment.Id, status, assignee)
+	if err != nil {
+		return err
+	}
+
+	a.recordComment("follow_up_comment", comment)
+
+	color.Green("Tindak lanjut komentar #%d disimpan: %s", comment.Id, status)
+	helper.Pause()
+
+	return helper.ErrContinue
+}
+
+// Catatan handles the internal admin note of a comment.
 //
-// Each count is displayed in cyan text for visual clarity. If any error occurs
-// during data retrieval, the function immediately returns the error.
+// The function workflow:
+//  1. Clears the screen, displays the header and the current comment table
+//  2. Prompts for the ID of the comment and shows its current note
+//  3. Prompts for the new note, prefilled with the current one. An empty note removes it
+//  4. Stores the note and asks whether the admin wants to write another note
+//     - If yes: Returns helper.ErrContinue to show the screen again
+//     - If no: Returns helper.ErrBack to go back to previous menu
 //
 // Returns:
-//   - error: Any error encountered during data retrieval or display
-func (a *adminService) Grafik() error {
-	var comments [255]model.Comment
+//   - error: Storage errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) Catatan() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > CATATAN", "CATATAN ADMIN")
+
+	err := a.commentService.ShowTable()
+	if err != nil {
+		return err
+	}
+
+	idPrompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar",
+		Validate: func(input string) error {
+			_, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("id komentar harus berupa angka")
+			}
+
+			return nil
+		},
+	}
+
+	askPrompt := promptui.Prompt{
+		Label:     "Tulis catatan lain?",
+		IsConfirm: true,
+	}
+
+	idInput, err := idPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	id, _ := strconv.Atoi(idInput)
+
+	var comment model.Comment
+	err = a.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		color.Red(err.Error())
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > ADMIN > GRAFIK")
-	color.Yellow("========================================")
-	color.Yellow("=                GRAFIK                =")
-	color.Yellow("========================================")
-	color.Cyan("Jumlah User: %d", global.UserCount)
-	color.Cyan("Jumlah Komentar: %d", global.CommentCount)
+		return helper.ErrContinue
+	}
+
+	if comment.Catatan != "" {
+		color.Magenta("Catatan sekarang: %s", comment.Catatan)
+	}
+
+	notePrompt := promptui.Prompt{
+		Label:   "Catatan (kosongkan untuk menghapus)",
+		Default: comment.Catatan,
+	}
+
+	note, err := notePrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
 
-	positif, err := a.commentRepo.GetCommentByKategori("Positif", &comments)
+	err = a.commentRepo.SetNote(comment.Id, strings.TrimSpace(note))
 	if err != nil {
 		return err
 	}
-	color.Cyan("Jumlah Komentar Positif: %d", positif)
 
-	netral, err := a.commentRepo.GetCommentByKategori("Netral", &comments)
+	a.recordComment("note_comment", comment)
+
+	color.Green("Catatan komentar #%d disimpan", comment.Id)
+
+	_, err = askPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	return helper.ErrContinue
+}
+
+// statusTransitions lists the statuses a comment can move to from each processing status.
+// A processed comment can be reopened, and a comment in review can be sent back.
+var statusTransitions = map[string][]string{
+	repository.StatusBaru:     {repository.StatusDitinjau},
+	repository.StatusDitinjau: {repository.StatusSelesai, repository.StatusBaru},
+	repository.StatusSelesai:  {repository.StatusDitinjau},
+}
+
+// StatusKomentar handles the processing status of the comments.
+//
+// The function workflow:
+//  1. Clears the screen and displays the number of comments per status
+//  2. Asks the admin what to do next:
+//     - Filter: Asks for a status and renders the comments with that status
+//     - Ubah Status: Prompts for the ID of a comment and moves it to one of the
+//     statuses allowed from its current status, see statusTransitions
+//     - Exit: Returns helper.ErrBack to go back to the previous menu
+//  3. Returns helper.ErrContinue to show the screen again
+//
+// Returns:
+//   - error: Storage errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (a *adminService) StatusKomentar() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > STATUS", "STATUS KOMENTAR")
+
+	counts := map[string]int{}
+	err := a.commentRepo.ForEachComment(func(comment model.Comment) bool {
+		counts[repository.StatusOf(comment)]++
+		return true
+	})
 	if err != nil {
 		return err
 	}
-	color.Cyan("Jumlah Komentar Netral: %d", netral)
 
-	negatif, err := a.commentRepo.GetCommentByKategori("Negatif", &comments)
+	color.Cyan("Baru: %d | Ditinjau: %d | Selesai: %d", counts[repository.StatusBaru], counts[repository.StatusDitinjau], counts[repository.StatusSelesai])
+
+	templates := &promptui.SelectTemplates{
+		Label:    "{{ . | blue }}:",
+		Active:   "\u27A1 {{ . | cyan }}",
+		Inactive: "  {{ . | cyan }}",
+		Selected: "\u2705 {{ . | blue | cyan }}",
+	}
+
+	menuPrompt := promptui.Select{
+		Label:     "Pilih Menu",
+		Items:     []string{"Filter", "Ubah Status", "Exit"},
+		Templates: templates,
+	}
+
+	_, choice, err := menuPrompt.Run()
+	if err != nil || choice == "Exit" {
+		return helper.ErrBack
+	}
+
+	if choice == "Filter" {
+		filterPrompt := promptui.Select{
+			Label:     "Status",
+			Items:     []string{repository.StatusBaru, repository.StatusDitinjau, repository.StatusSelesai},
+			Templates: templates,
+		}
+
+		_, status, err := filterPrompt.Run()
+		if err != nil {
+			return helper.ErrContinue
+		}
+
+		var comments [255]model.Comment
+		n, err := a.commentRepo.Comments().WhereStatus(status).Find(&comments)
+		if err != nil {
+			return err
+		}
+
+		t := helper.NewTable()
+		t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori", "Status"})
+		for i := 0; i < n; i++ {
+			t.AppendRow(table.Row{i + 1, comments[i].Id, comments[i].Komentar, comments[i].Kategori, repository.StatusOf(comments[i])})
+		}
+		t.Render()
+
+		helper.Pause()
+		return helper.ErrContinue
+	}
+
+	idPrompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar",
+		Validate: func(input string) error {
+			_, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("id komentar harus berupa angka")
+			}
+
+			return nil
+		},
+	}
+
+	idInput, err := idPrompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	id, _ := strconv.Atoi(idInput)
+
+	var comment model.Comment
+	err = a.commentRepo.FindCommentById(id, &comment)
+	if err != nil {
+		color.Red(err.Error())
+		helper.Pause()
+		return helper.ErrContinue
+	}
+
+	current := repository.StatusOf(comment)
+	statusPrompt := promptui.Select{
+		Label:     fmt.Sprintf("Status baru (sekarang %s)", current),
+		Items:     statusTransitions[current],
+		Templates: templates,
+	}
+
+	_, status, err := statusPrompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	err = a.commentRepo.SetStatus(comment.Id, status)
 	if err != nil {
 		return err
 	}
-	color.Cyan("Jumlah Komentar Negatif: %d", negatif)
 
-	fmt.Scanln()
+	a.recordComment("status_comment", comment)
 
-	return nil
+	color.Green("Status komentar #%d: %s -> %s", comment.Id, current, status)
+	helper.Pause()
+
+	return helper.ErrContinue
 }
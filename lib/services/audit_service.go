@@ -0,0 +1,316 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// auditLimit is the maximum number of audit entries shown at once, newest first.
+const auditLimit = 100
+
+const (
+	// auditPassword replaces a password in the audit log
+	auditPassword = "***"
+
+	// auditPasswordChanged replaces the password of the new value when it was changed
+	auditPasswordChanged = "*** (diubah)"
+)
+
+// AuditService defines the interface for the audit log of admin actions.
+// The admin screens record every change of a user or a comment with the admin that
+// made it and the value before and after the change.
+type AuditService interface {
+	// SetActor sets the admin account the following changes are recorded for.
+	// An empty user ends the admin session.
+	SetActor(user model.User)
+
+	// Record appends a change to the audit log. A failure is shown as a warning but
+	// does not undo the change.
+	Record(action string, targetId int, before any, after any)
+
+	// RecordBy appends a change made by the given account instead of the admin of the
+	// session, e.g. by a user of the REST API.
+	RecordBy(actor model.User, action string, targetId int, before any, after any)
+
+	// LihatAuditLog displays the audit log, filtered by action type.
+	LihatAuditLog() error
+}
+
+// auditChange is a change that is recorded in the audit log once the transaction that
+// made it has been committed, so a rolled back change is never recorded.
+type auditChange struct {
+	action   string
+	targetId int
+	before   any
+	after    any
+}
+
+// auditService implements the AuditService interface.
+type auditService struct {
+	auditRepo repository.AuditRepository
+
+	// actor is the admin account of the current admin session
+	actor model.User
+}
+
+// NewAuditService creates and returns a new AuditService implementation.
+//
+// Parameters:
+//   - auditRepo: The AuditRepository implementation the changes are appended to
+//
+// Returns:
+//   - AuditService: A new instance of the auditService implementation
+func NewAuditService(auditRepo repository.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// SetActor sets the admin account the following changes are recorded for.
+//
+// Parameters:
+//   - user: The logged-in admin account, empty when the admin session ends
+func (s *auditService) SetActor(user model.User) {
+	s.actor = user
+}
+
+// Record appends a change to the audit log for the admin of the session, see RecordBy.
+//
+// Parameters:
+//   - action: The change, e.g. "create_user", "edit_comment" or "delete_comment"
+//   - targetId: The ID of the user or comment that was changed
+//   - before: The value before the change, nil for a create
+//   - after: The value after the change, nil for a delete
+func (s *auditService) Record(action string, targetId int, before any, after any) {
+	s.RecordBy(s.actor, action, targetId, before, after)
+}
+
+// RecordBy appends a change to the audit log.
+//
+// The values are stored as JSON. Passwords of user values are never stored: a password
+// is replaced by auditPassword, or by auditPasswordChanged in the new value when the
+// password was changed, so the log still shows the change.
+//
+// Parameters:
+//   - actor: The account that made the change
+//   - action: The change, e.g. "create_user", "edit_comment" or "delete_comment"
+//   - targetId: The ID of the user or comment that was changed
+//   - before: The value before the change, nil for a create
+//   - after: The value after the change, nil for a delete
+func (s *auditService) RecordBy(actor model.User, action string, targetId int, before any, after any) {
+	oldUser, isOldUser := before.(model.User)
+	newUser, isNewUser := after.(model.User)
+	if isOldUser && isNewUser && oldUser.Password != newUser.Password {
+		newUser.Password = auditPasswordChanged
+		after = newUser
+	}
+
+	entry := model.AuditEntry{
+		At:       time.Now(),
+		Actor:    actor.Username,
+		Role:     actor.Role,
+		Action:   action,
+		TargetId: targetId,
+		Old:      auditValue(before),
+		New:      auditValue(after),
+	}
+
+	err := s.auditRepo.Append(entry)
+	if err != nil {
+		helper.Trace("audit %s %d: %v", action, targetId, err)
+		color.Yellow("Audit log tidak dapat ditulis: %v", err)
+	}
+}
+
+// auditValue encodes a value for the audit log.
+//
+// Parameters:
+//   - value: The value to encode, nil for none
+//
+// Returns:
+//   - json.RawMessage: The JSON encoded value, nil when there is no value or it cannot be encoded
+func auditValue(value any) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	if user, ok := value.(model.User); ok {
+		if user.Password != "" && user.Password != auditPasswordChanged {
+			user.Password = auditPassword
+		}
+		value = user
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// LihatAuditLog displays the audit log.
+//
+// The function workflow:
+//  1. Clears the screen and reads the audit log
+//     - If it is empty: Waits for the user to press Enter and returns helper.ErrBack
+//  2. Lets the admin choose an action type to filter on, or "Semua" for every entry
+//  3. Renders the matching entries newest first, at most auditLimit of them, with the
+//     time, the admin, the action, the target and the fields that changed, see auditChanges
+//  4. Waits for the user to press Enter and returns helper.ErrContinue to choose another filter
+//
+// Returns:
+//   - error: Read errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (s *auditService) LihatAuditLog() error {
+	helper.Header("MENU > ADMIN > AUDIT LOG", "AUDIT LOG")
+
+	entries, err := s.auditRepo.GetAllEntries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		color.Cyan("Belum ada perubahan yang tercatat")
+		helper.Pause()
+		return helper.ErrBack
+	}
+
+	var actions []string
+	for _, entry := range entries {
+		if !slices.Contains(actions, entry.Action) {
+			actions = append(actions, entry.Action)
+		}
+	}
+	slices.Sort(actions)
+
+	prompt := promptui.Select{
+		Label: "Filter Aksi",
+		Items: append(append([]string{"Semua"}, actions...), "Kembali"),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, filter, err := prompt.Run()
+	if err != nil || filter == "Kembali" {
+		return helper.ErrBack
+	}
+
+	helper.Header("MENU > ADMIN > AUDIT LOG", "AUDIT LOG")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Waktu", "Admin", "Aksi", "Target", "Lama", "Baru"})
+
+	matches := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if filter != "Semua" && entry.Action != filter {
+			continue
+		}
+
+		matches++
+		if matches > auditLimit {
+			continue
+		}
+
+		actor := entry.Actor
+		if entry.Role != "" {
+			actor = fmt.Sprintf("%s (%s)", entry.Actor, entry.Role)
+		}
+
+		target := entry.Action[strings.LastIndex(entry.Action, "_")+1:]
+		old, new := auditChanges(entry.Old, entry.New)
+
+		t.AppendRow(table.Row{
+			entry.At.Format("2006-01-02 15:04:05"),
+			actor,
+			entry.Action,
+			fmt.Sprintf("%s #%d", target, entry.TargetId),
+			helper.Truncate(old, 40),
+			helper.Truncate(new, 40),
+		})
+	}
+	t.Render()
+
+	if matches > auditLimit {
+		color.Cyan("Menampilkan %d terbaru dari %d entri", auditLimit, matches)
+	}
+
+	helper.Pause()
+
+	return helper.ErrContinue
+}
+
+// auditChanges lists the fields that differ between the old and the new value of an
+// audit entry, so the table shows what was changed instead of both values in full.
+//
+// Parameters:
+//   - old: The JSON encoded value before the change, empty for a create
+//   - new: The JSON encoded value after the change, empty for a delete
+//
+// Returns:
+//   - string: The changed fields of the old value as "field=value" pairs, "-" if none
+//   - string: The changed fields of the new value as "field=value" pairs, "-" if none
+func auditChanges(old json.RawMessage, new json.RawMessage) (string, string) {
+	var before, after map[string]any
+	json.Unmarshal(old, &before)
+	json.Unmarshal(new, &after)
+
+	var keys []string
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var oldFields, newFields []string
+	for _, key := range keys {
+		oldValue, inOld := before[key]
+		newValue, inNew := after[key]
+		if inOld && inNew && fmt.Sprint(oldValue) == fmt.Sprint(newValue) {
+			continue
+		}
+
+		if inOld && old != nil {
+			oldFields = append(oldFields, fmt.Sprintf("%s=%v", key, oldValue))
+		}
+		if inNew && new != nil {
+			newFields = append(newFields, fmt.Sprintf("%s=%v", key, newValue))
+		}
+	}
+
+	return joinFields(oldFields), joinFields(newFields)
+}
+
+// joinFields joins "field=value" pairs for a table cell.
+//
+// Parameters:
+//   - fields: The pairs to join
+//
+// Returns:
+//   - string: The pairs separated by commas, "-" when there are none
+func joinFields(fields []string) string {
+	if len(fields) == 0 {
+		return "-"
+	}
+
+	return strings.Join(fields, ", ")
+}
@@ -1,9 +1,9 @@
 package services
 
 import (
-	"fmt"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
+	"tugas-besar/lib/global"
 	"tugas-besar/lib/helper"
 	"tugas-besar/lib/model"
 )
@@ -53,13 +53,9 @@ func NewAuthService(userService UserService) AuthService {
 func (service *authService) Login(user *model.User) error {
 	var username, password string
 
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Login")
-	color.Yellow("=========================================")
-	color.Yellow("=                LOGIN                  =")
-	color.Yellow("=========================================")
+	helper.Header("MENU > LOGIN", "LOGIN")
 
-	err := loginForm(&username, &password)
+	err := loginForm(service.userService, &username, &password)
 	if err != nil {
 		return err
 	}
@@ -71,41 +67,100 @@ func (service *authService) Login(user *model.User) error {
 
 	err = service.userService.FindUserByUsername(username, user)
 	if err != nil {
+		service.recordLogin(username, 0, false)
+
 		color.Red("User not found: %s", username)
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
-	if user.Password != password {
+	if !helper.CheckPasswordHash(password, user.Password) {
+		service.recordLogin(username, user.Id, false)
+		*user = model.User{}
+
 		color.Red("Password does not match")
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
+	service.recordLogin(user.Username, user.Id, true)
+	service.upgradePassword(user, password)
+
 	color.Green("Login successful! Welcome, %s!", user.Username)
-	fmt.Scanln()
+	helper.Pause()
 
 	return nil
 }
 
+// upgradePassword hashes the password of an account that still has a legacy plain text
+// password, after the user logged in with it. A failure is only traced, the user can
+// still log in and the password is hashed on the next login.
+//
+// Parameters:
+//   - user: Pointer to the logged-in user, updated with the hashed password
+//   - password: The password the user logged in with
+func (service *authService) upgradePassword(user *model.User, password string) {
+	if helper.IsPasswordHash(user.Password) {
+		return
+	}
+
+	var users [255]model.User
+	err := service.userService.GetAllUsers(&users)
+	if err != nil {
+		helper.Trace("upgrade password %s: %v", user.Username, err)
+		return
+	}
+
+	for i := 0; i < global.UserCount; i++ {
+		if users[i].Id != user.Id {
+			continue
+		}
+
+		err = service.userService.EditUser(i, model.User{Password: password, Version: users[i].Version, MustChangePassword: users[i].MustChangePassword})
+		if err != nil {
+			helper.Trace("upgrade password %s: %v", user.Username, err)
+			return
+		}
+
+		service.userService.FindUserByUsername(user.Username, user)
+		return
+	}
+}
+
+// recordLogin adds a login attempt to the login history. A failure to record is only
+// traced, it must not block the login itself.
+//
+// Parameters:
+//   - username: The username that was entered
+//   - userId: The ID of the account, 0 when the username does not exist
+//   - success: Whether the password matched
+func (service *authService) recordLogin(username string, userId int, success bool) {
+	err := service.userService.RecordLogin(username, userId, success)
+	if err != nil {
+		helper.Trace("record login %q: %v", username, err)
+	}
+}
+
 // loginForm displays interactive prompts to collect username and password.
 // It uses promptui to create formatted input fields with appropriate masking for the password.
+// A partly typed username is autocompleted from the registered usernames.
 //
 // Parameters:
+//   - userService: The UserService used to autocomplete the username
 //   - username: A pointer to a string that will be populated with the entered username
 //   - password: A pointer to a string that will be populated with the entered password
 //
 // Returns:
 //   - error: An error if the prompt interaction fails, nil otherwise
-func loginForm(username, password *string) error {
+func loginForm(userService UserService, username, password *string) error {
 	usernamePrompt := promptui.Prompt{Label: "Username"}
 	passwordPrompt := promptui.Prompt{Label: "Password", Mask: '*'}
 
@@ -114,6 +169,11 @@ func loginForm(username, password *string) error {
 		return err
 	}
 
+	usernameInput, err = completeUsername(userService.CompleteUsername, usernameInput)
+	if err != nil {
+		return err
+	}
+
 	passwordInput, err := passwordPrompt.Run()
 	if err != nil {
 		return err
@@ -136,11 +196,7 @@ func loginForm(username, password *string) error {
 func (service *authService) Register() error {
 	var username, password, confirmPassword string
 
-	helper.ClearScreen()
-	color.Yellow("Main Menu > Register")
-	color.Yellow("=========================================")
-	color.Yellow("=                REGISTER               =")
-	color.Yellow("=========================================")
+	helper.Header("MENU > REGISTER", "REGISTER")
 
 	err := registerForm(&username, &password, &confirmPassword)
 	if err != nil {
@@ -156,20 +212,20 @@ func (service *authService) Register() error {
 		color.Red("User with username %s already exists", username)
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	if password != confirmPassword {
 		color.Red("Password does not match")
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	err = service.userService.CreateUser(&model.User{
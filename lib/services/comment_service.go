@@ -3,8 +3,9 @@ package services
 import (
 	"fmt"
 	"github.com/jedib0t/go-pretty/v6/table"
-	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
@@ -23,6 +24,9 @@ type CommentService interface {
 	// (Positif, Netral, or Negatif). After submission, it creates the comment in the system.
 	CreateCommentPage(user model.User) error
 
+	// BalasKomentar lets a user reply to an existing comment, starting or continuing a discussion.
+	BalasKomentar(user model.User) error
+
 	// CreateComment adds a new comment to the system.
 	// Returns an error if the creation fails, nil otherwise.
 	CreateComment(comment *model.Comment, userId int) error
@@ -54,12 +58,18 @@ type CommentService interface {
 	// to delete, and removes the selected comment from the system.
 	DeleteUserComment(user model.User) error
 
-	// ShowTable retrieves and displays all comments in a formatted table.
-	// It queries the repository for all comments and renders them in a table
+	// ShowTable retrieves and displays the current page of comments in a formatted table.
+	// It queries the repository for one page of comments and renders them in a table
 	// with columns for comment number, ID, text content, and category.
 	// The table is formatted with colored styling for better readability.
 	ShowTable() error
 
+	// PageKeys adds the page navigation keys of the comment table to menu keys ending with Exit.
+	PageKeys(keys []string) []string
+
+	// TurnPage moves the comment table to another page when key is a page navigation key.
+	TurnPage(key string) bool
+
 	// CreateCommentForm displays interactive prompts for entering comment text and selecting a category.
 	// It creates a text input prompt for the comment and a selection menu for the category
 	// (Positif, Netral, Negatif) with custom styling. The user's inputs are stored in the provided
@@ -75,43 +85,79 @@ type CommentService interface {
 	// EditComment updates a comment with the specified ID in the repository.
 	// It delegates the update operation to the underlying repository implementation.
 	EditComment(id int, komentar model.Comment) error
+
+	// ScheduledComment displays the comments of a user that are waiting to be published.
+	ScheduledComment(user model.User) error
+
+	// Favorit lists the comments a user bookmarked and lets the user add or remove a bookmark.
+	// The admin keeps its own favorites under UserId 0.
+	Favorit(user model.User) error
+
+	// LabelKedua lets a user act as the second labeler, assigning categories to comments
+	// of other users without seeing their first label.
+	LabelKedua(user model.User) error
+
+	// RapidEntry runs a data-entry loop that keeps asking for comment text and category
+	// until an empty comment is entered.
+	RapidEntry(user model.User) error
+
+	// StartPublisher starts the background job that publishes scheduled comments once
+	// their time has come.
+	StartPublisher()
 }
 
 // commentService implements the commentService interface.
 // It acts as a service layer between the application and the repository.
 type commentService struct {
-	commentRepo repository.CommentRepository
+	commentRepo  repository.CommentRepository
+	scheduleRepo repository.ScheduleRepository
+	templateRepo repository.TemplateRepository
+	userRepo     repository.UserRepository
+	bookmarkRepo repository.BookmarkRepository
+	sentiment    SentimentService
+
+	// page is the page of the comment table that is shown, starting at 0
+	page int
 }
 
 // NewCommentService creates and returns a new CommentService implementation.
 //
 // Parameters:
 //   - commentRepo: The comment repository implementation to use for data operations
+//   - scheduleRepo: The schedule repository implementation that queues comments for later
+//   - templateRepo: The template repository implementation offering reusable comment texts
+//   - userRepo: The user repository implementation used to autocomplete @mentions
+//   - bookmarkRepo: The bookmark repository implementation holding the favorite comments
+//   - sentiment: The SentimentService implementation that proposes the category of a new comment
 //
 // Returns:
 //   - CommentService: A new instance of the commentService implementation
-func NewCommentService(commentRepo repository.CommentRepository) CommentService {
+func NewCommentService(commentRepo repository.CommentRepository, scheduleRepo repository.ScheduleRepository, templateRepo repository.TemplateRepository, userRepo repository.UserRepository, bookmarkRepo repository.BookmarkRepository, sentiment SentimentService) CommentService {
 	return &commentService{
-		commentRepo: commentRepo,
+		commentRepo:  commentRepo,
+		scheduleRepo: scheduleRepo,
+		templateRepo: templateRepo,
+		userRepo:     userRepo,
+		bookmarkRepo: bookmarkRepo,
+		sentiment:    sentiment,
 	}
 }
 
 // CreateCommentPage displays a form for creating a new comment and processes the user's input.
 // It clears the screen, shows a header for the comment input form, then prompts the user
 // to enter comment text and select a category through the CreateCommentForm function.
-// Upon successful input, it creates a new comment in the system with the provided information.
+// The user then chooses to publish the comment now or at a later time. A comment published
+// now is created in the system right away; a scheduled comment is queued until its time
+// and a confirmation is shown instead.
 //
 // Parameters:
 //   - user: The model.User representing the currently logged-in user
 //
 // Returns:
-//   - error: An error if the form display, user input, or comment creation fails, nil on success
+//   - error: An error if the form display, user input, or comment creation fails, helper.ErrBack
+//     after a comment was scheduled, nil on success
 func (c *commentService) CreateCommentPage(user model.User) error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > INPUT KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           INPUT KOMENTAR             =")
-	color.Yellow("========================================")
+	helper.Header("MENU > USER > INPUT KOMENTAR", "INPUT KOMENTAR")
 
 	var komentar, kategori string
 
@@ -120,6 +166,59 @@ func (c *commentService) CreateCommentPage(user model.User) error {
 		return err
 	}
 
+	whenPrompt := promptui.Select{
+		Label: "Tayangkan",
+		Items: []string{"Sekarang", "Jadwalkan"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, when, err := whenPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	if when == "Jadwalkan" {
+		timePrompt := promptui.Prompt{
+			Label: "Waktu tayang (YYYY-MM-DD HH:MM)",
+			Validate: func(input string) error {
+				publishAt, err := time.ParseInLocation("2006-01-02 15:04", input, time.Local)
+				if err != nil {
+					return fmt.Errorf("format waktu harus YYYY-MM-DD HH:MM")
+				}
+
+				if !publishAt.After(time.Now()) {
+					return fmt.Errorf("waktu tayang harus di masa depan")
+				}
+
+				return nil
+			},
+		}
+
+		input, err := timePrompt.Run()
+		if err != nil {
+			return err
+		}
+
+		publishAt, _ := time.ParseInLocation("2006-01-02 15:04", input, time.Local)
+		err = c.scheduleRepo.Schedule(model.Comment{
+			Komentar: komentar,
+			Kategori: kategori,
+		}, user.Id, publishAt)
+		if err != nil {
+			return err
+		}
+
+		color.Green("Komentar dijadwalkan tayang pada %s", publishAt.Format("2006-01-02 15:04"))
+		helper.Pause()
+
+		return helper.ErrBack
+	}
+
 	err = c.CreateComment(&model.Comment{
 		Komentar: komentar,
 		Kategori: kategori,
@@ -136,6 +235,15 @@ func (c *commentService) CreateCommentPage(user model.User) error {
 // (Positif, Netral, Negatif) with custom styling. The user's inputs are stored in the provided
 // string pointers.
 //
+// When comment templates exist, the user first chooses between writing the comment from
+// scratch and one of the templates. A template prefills the comment text, which can still
+// be edited, and preselects its category. Every @mention in the text is autocompleted
+// to a full username.
+//
+// Once the text is entered, the SentimentService proposes a category from the Indonesian
+// lexicon. The proposal is shown and preselected, so the user only confirms or overrides
+// it. An unchanged template text keeps the category of the template.
+//
 // Parameters:
 //   - komentar: A pointer to a string where the comment text will be stored
 //   - kategori: A pointer to a string where the selected category will be stored
@@ -143,10 +251,42 @@ func (c *commentService) CreateCommentPage(user model.User) error {
 // Returns:
 //   - error: An error if any prompt operation fails, nil on success
 func (c *commentService) CreateCommentForm(komentar, kategori *string) error {
-	komentarPrompt := promptui.Prompt{Label: "Komentar"}
+	var template model.Template
+	err := c.chooseTemplate(&template)
+	if err != nil {
+		return err
+	}
+
+	komentarPrompt := promptui.Prompt{Label: "Komentar", Default: template.Komentar, AllowEdit: true}
+
+	komentarInput, err := komentarPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	komentarInput, err = completeMentions(c.userRepo.CompleteUsername, komentarInput)
+	if err != nil {
+		return err
+	}
+
+	suggestion := template.Kategori
+	if suggestion == "" || komentarInput != template.Komentar {
+		suggestion, _ = c.sentiment.Analyze(komentarInput)
+		color.Cyan("Saran kategori: %s (keyakinan %.0f%%)", suggestion, c.sentiment.Confidence(komentarInput)*100)
+	}
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+	cursor := 0
+	for i, category := range categories {
+		if category == suggestion {
+			cursor = i
+		}
+	}
+
 	kategoriPrompt := promptui.Select{
-		Label: "Kategori",
-		Items: []string{"Positif", "Netral", "Negatif"},
+		Label:     "Kategori",
+		Items:     categories,
+		CursorPos: cursor,
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -155,18 +295,56 @@ func (c *commentService) CreateCommentForm(komentar, kategori *string) error {
 		},
 	}
 
-	komentarInput, err := komentarPrompt.Run()
+	_, kategoriInput, err := kategoriPrompt.Run()
 	if err != nil {
 		return err
 	}
 
-	_, kategoriInput, err := kategoriPrompt.Run()
+	*komentar = komentarInput
+	*kategori = kategoriInput
+
+	return nil
+}
+
+// chooseTemplate asks whether the comment is written from scratch or from a template.
+// Nothing is asked when there are no templates.
+//
+// Parameters:
+//   - template: Pointer to store the chosen template, left empty for "Tulis Sendiri"
+//
+// Returns:
+//   - error: An error if the templates cannot be retrieved or the prompt fails
+func (c *commentService) chooseTemplate(template *model.Template) error {
+	var templates [255]model.Template
+	n, err := c.templateRepo.GetAllTemplates(&templates)
+	if err != nil || n == 0 {
+		return err
+	}
+
+	items := []string{"Tulis Sendiri"}
+	for i := 0; i < n; i++ {
+		items = append(items, templates[i].Nama)
+	}
+
+	prompt := promptui.Select{
+		Label: "Template",
+		Items: items,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
 	if err != nil {
 		return err
 	}
 
-	*komentar = komentarInput
-	*kategori = kategoriInput
+	if index > 0 {
+		*template = templates[index-1]
+	}
 
 	return nil
 }
@@ -176,7 +354,9 @@ func (c *commentService) CreateCommentForm(komentar, kategori *string) error {
 // Then it retrieves all comments from the repository, renders them in a table showing
 // the comment number, text content, and category. After displaying the comments,
 // it presents a menu with options for Search, Sorting, or Exit, and stores the
-// user's selection in the chose parameter.
+// user's selection in the chose parameter. When the comments do not fit on one page the
+// menu also offers "Halaman Berikutnya" and "Halaman Sebelumnya", which move the table
+// to another page and are returned in chose like the other options.
 //
 // Parameters:
 //   - chose: A pointer to a string that will store the user's menu selection
@@ -184,20 +364,19 @@ func (c *commentService) CreateCommentForm(komentar, kategori *string) error {
 // Returns:
 //   - error: An error if retrieving comments or handling the menu fails, nil on success
 func (c *commentService) ShowComment(chose *string) error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > LIHAT KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           LIHAT KOMENTAR             =")
-	color.Yellow("========================================")
+	helper.Header("MENU > USER > LIHAT KOMENTAR", "LIHAT KOMENTAR")
 
 	err := c.ShowTable()
 	if err != nil {
 		return err
 	}
 
+	labels, keys := helper.MenuItems("user_komentar", c.PageKeys([]string{"Search", "Sorting", "Exit"}))
+
 	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Search", "Sorting", "Exit"},
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("user_komentar", keys),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -206,12 +385,18 @@ func (c *commentService) ShowComment(chose *string) error {
 		},
 	}
 
-	_, result, err := prompt.Run()
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, chose) {
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
 
-	*chose = result
+	*chose = keys[index]
+	helper.RememberMenu("user_komentar", keys[index])
+	c.TurnPage(*chose)
 
 	return nil
 }
@@ -222,19 +407,15 @@ func (c *commentService) ShowComment(chose *string) error {
 // The function follows these steps:
 // 1. Clears the screen and displays the search interface header
 // 2. Prompts the user to enter a keyword to search for in comments
-// 3. Queries the repository for comments matching the keyword
-// 4. Displays matching comments in a formatted table with numbering, comment text, and category
+// 3. Queries the repository for comments matching every word of the keyword, ranked by relevance
+// 4. Displays matching comments in a formatted table with numbering, comment text, category and relevance
 // 5. Asks the user if they want to search again
 //
 // Returns:
-//   - error: Returns "continue" if the user wants to search again, "back" if the user wants
+//   - error: Returns helper.ErrContinue if the user wants to search again, helper.ErrBack if the user wants
 //     to return to the previous menu, or another error if any operation fails
 func (c *commentService) SearchComment() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > LIHAT KOMENTAR > CARI KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           CARI KOMENTAR              =")
-	color.Yellow("========================================")
+	helper.Header("MENU > USER > LIHAT KOMENTAR > CARI KOMENTAR", "CARI KOMENTAR")
 
 	searchPrompt := promptui.Prompt{
 		Label: "Masukkan kata kunci untuk mencari komentar",
@@ -245,33 +426,14 @@ func (c *commentService) SearchComment() error {
 		return err
 	}
 
-	var comments [255]model.Comment
-	err = c.commentRepo.SearchComments(searchInput, &comments)
+	var results [255]model.SearchResult
+	n, err := c.commentRepo.RankSearch(searchInput, &results)
 	if err != nil {
 		return err
 	}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > LIHAT KOMENTAR > CARI KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           CARI KOMENTAR              =")
-	color.Yellow("========================================")
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
-	var j int
-	for i := 0; i < global.CommentCount; i++ {
-		if comments[i].Komentar != "" {
-			j++
-			t.AppendRow(table.Row{
-				j,
-				comments[i].Komentar,
-				comments[i].Kategori,
-			})
-		}
-	}
-	t.SetStyle(table.StyleColoredBright)
-	t.Render()
+	helper.Header("MENU > USER > LIHAT KOMENTAR > CARI KOMENTAR", "CARI KOMENTAR")
+	renderSearchResults(results, n)
 
 	askPrompt := promptui.Prompt{
 		Label:     "Search Again?",
@@ -280,10 +442,10 @@ func (c *commentService) SearchComment() error {
 
 	_, err = askPrompt.Run()
 	if err != nil {
-		return fmt.Errorf("back")
+		return helper.ErrBack
 	}
 
-	return fmt.Errorf("continue")
+	return helper.ErrContinue
 }
 
 // SortingComment handles the comment sorting functionality.
@@ -300,15 +462,12 @@ func (c *commentService) SearchComment() error {
 // Returns:
 //   - error: An error if any part of the sorting operation fails, nil on success
 func (c *commentService) SortingComment() error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > LIHAT KOMENTAR > SORTING KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           SORTING KOMENTAR           =")
-	color.Yellow("========================================")
+	helper.Header("MENU > USER > LIHAT KOMENTAR > SORTING KOMENTAR", "SORTING KOMENTAR")
 
 	prompt := promptui.Select{
-		Label: "Pilih Berdasarkan",
-		Items: []string{"Komentar", "Kategori"},
+		Label:     "Pilih Berdasarkan",
+		Items:     []string{"Komentar", "Kategori"},
+		CursorPos: helper.MenuCursor("user_sorting", []string{"Komentar", "Kategori"}),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -318,8 +477,9 @@ func (c *commentService) SortingComment() error {
 	}
 
 	promptMode := promptui.Select{
-		Label: "Pilih Mode",
-		Items: []string{"Ascending", "Descending"},
+		Label:     "Pilih Mode",
+		Items:     []string{"Ascending", "Descending"},
+		CursorPos: helper.MenuCursor("user_sorting_mode", []string{"Ascending", "Descending"}),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -332,11 +492,13 @@ func (c *commentService) SortingComment() error {
 	if err != nil {
 		return err
 	}
+	helper.RememberMenu("user_sorting", result)
 
 	_, mode, err := promptMode.Run()
 	if err != nil {
 		return err
 	}
+	helper.RememberMenu("user_sorting_mode", mode)
 
 	modeInt := 0
 	if mode == "Descending" {
@@ -367,7 +529,7 @@ func (c *commentService) SortingComment() error {
 // 1. Retrieves comments from the repository sorted by comment text
 // 2. Clears the screen and displays a header for the sorted comments
 // 3. Creates and renders a table showing the sorted comments with numbering, text, and category
-// 4. Waits for the user to press Enter (via fmt.Scanln()) before returning
+// 4. Waits for the user to press Enter (via helper.Pause) before returning
 //
 // Parameters:
 //   - mode: An integer indicating the sort direction (0 for ascending, 1 for descending)
@@ -382,16 +544,12 @@ func (c *commentService) sortCommentByKomentar(mode int) error {
 		return err
 	}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > LIHAT KOMENTAR > SORTING KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           SORTING KOMENTAR           =")
-	color.Yellow("========================================")
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	helper.Header("MENU > USER > LIHAT KOMENTAR > SORTING KOMENTAR", "SORTING KOMENTAR")
+	t := helper.NewTable()
 	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
 	j := 0
-	for i := 0; i < global.CommentCount; i++ {
+	n := c.commentRepo.CountComments()
+	for i := 0; i < n; i++ {
 		j++
 		t.AppendRow(table.Row{
 			j,
@@ -399,10 +557,9 @@ func (c *commentService) sortCommentByKomentar(mode int) error {
 			comments[i].Kategori,
 		})
 	}
-	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 
-	fmt.Scanln()
+	helper.Pause()
 
 	return nil
 }
@@ -415,7 +572,7 @@ func (c *commentService) sortCommentByKomentar(mode int) error {
 // 1. Retrieves comments from the repository sorted by category
 // 2. Clears the screen and displays a header for the sorted comments
 // 3. Creates and renders a table showing the sorted comments with numbering, text, and category
-// 4. Waits for the user to press Enter (via fmt.Scanln()) before returning
+// 4. Waits for the user to press Enter (via helper.Pause) before returning
 //
 // Parameters:
 //   - mode: An integer indicating the sort direction (0 for ascending, 1 for descending)
@@ -430,16 +587,12 @@ func (c *commentService) sortCommentByKategori(mode int) error {
 		return err
 	}
 
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > LIHAT KOMENTAR > SORTING KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           SORTING KOMENTAR           =")
-	color.Yellow("========================================")
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	helper.Header("MENU > USER > LIHAT KOMENTAR > SORTING KOMENTAR", "SORTING KOMENTAR")
+	t := helper.NewTable()
 	t.AppendHeader(table.Row{"#", "Komentar", "Kategori"})
 	j := 0
-	for i := 0; i < global.CommentCount; i++ {
+	n := c.commentRepo.CountComments()
+	for i := 0; i < n; i++ {
 		j++
 		t.AppendRow(table.Row{
 			j,
@@ -447,10 +600,9 @@ func (c *commentService) sortCommentByKategori(mode int) error {
 			comments[i].Kategori,
 		})
 	}
-	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 
-	fmt.Scanln()
+	helper.Pause()
 
 	return nil
 }
@@ -472,15 +624,11 @@ func (c *commentService) sortCommentByKategori(mode int) error {
 //   - user: The model.User representing the currently logged-in user
 //
 // Returns:
-//   - error: Returns "continue" if the user wants to edit another comment after
-//     an error, "back" if the user wants to return to the previous menu, nil on
+//   - error: Returns helper.ErrContinue if the user wants to edit another comment after
+//     an error, helper.ErrBack if the user wants to return to the previous menu, nil on
 //     successful update, or another error if any operation fails
 func (c *commentService) EditUserComment(user model.User) error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > EDIT KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=            EDIT KOMENTAR             =")
-	color.Yellow("========================================")
+	helper.Header("MENU > USER > EDIT KOMENTAR", "EDIT KOMENTAR")
 
 	err := c.showCommentByUserTable(user.Id)
 	if err != nil {
@@ -513,6 +661,25 @@ func (c *commentService) EditUserComment(user model.User) error {
 		return fmt.Errorf("id komentar harus berupa angka")
 	}
 
+	askPrompt := promptui.Prompt{
+		Label:     "Edit Again?",
+		IsConfirm: true,
+	}
+
+	// Remember the version that is being edited so a concurrent change is detected
+	var current model.Comment
+	err = c.commentRepo.FindCommentById(id, &current)
+	if err != nil || current.UserId != user.Id {
+		color.Red("comment with ID %d not found or does not belong to user with ID %d", id, user.Id)
+
+		_, err = askPrompt.Run()
+		if err != nil {
+			return helper.ErrBack
+		}
+
+		return helper.ErrContinue
+	}
+
 	var komentar, kategori string
 	err = c.EditForm(&komentar, &kategori)
 	if err != nil {
@@ -522,22 +689,17 @@ func (c *commentService) EditUserComment(user model.User) error {
 	err = c.commentRepo.EditUserComment(id, user.Id, model.Comment{
 		Komentar: komentar,
 		Kategori: kategori,
+		Version:  current.Version,
 	})
-
-	askPrompt := promptui.Prompt{
-		Label:     "Edit Again?",
-		IsConfirm: true,
-	}
-
 	if err != nil {
 		color.Red(err.Error())
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	return nil
@@ -592,22 +754,19 @@ func (c *commentService) EditForm(komentar, kategori *string) error {
 //     showing numbering, comment ID, text, and category
 //  3. Prompts the user to enter the ID of the comment they want to delete
 //  4. Validates the input to ensure it's a valid numeric ID
-//  5. Calls the repository to delete the comment with the specified ID
-//  6. If the deletion fails, displays an error and asks if the user wants to try again
+//  5. Shows the comment text and asks the user to confirm the deletion
+//  6. Calls the repository to delete the comment with the specified ID
+//  7. If the deletion fails, displays an error and asks if the user wants to try again
 //
 // Parameters:
 //   - user: The model.User representing the currently logged-in user
 //
 // Returns:
-//   - error: Returns "continue" if the user wants to delete another comment after
-//     an error, "back" if the user wants to return to the previous menu, nil on
-//     successful deletion, or another error if any operation fails
+//   - error: Returns helper.ErrContinue if the user wants to delete another comment after
+//     an error, helper.ErrBack if the user cancels the deletion or wants to return to the
+//     previous menu, nil on successful deletion, or another error if any operation fails
 func (c *commentService) DeleteUserComment(user model.User) error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER > HAPUS KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=            HAPUS KOMENTAR            =")
-	color.Yellow("========================================")
+	helper.Header("MENU > USER > HAPUS KOMENTAR", "HAPUS KOMENTAR")
 
 	err := c.showCommentByUserTable(user.Id)
 	if err != nil {
@@ -645,21 +804,92 @@ func (c *commentService) DeleteUserComment(user model.User) error {
 		IsConfirm: true,
 	}
 
+	var comment model.Comment
+	err = c.commentRepo.FindCommentById(id, &comment)
+	if err == nil && comment.UserId == user.Id {
+		if !helper.ConfirmDelete(comment.Komentar) {
+			return helper.ErrBack
+		}
+	}
+
 	err = c.commentRepo.DeleteUserComment(id, user.Id)
 	if err != nil {
 		color.Red(err.Error())
 
 		_, err = askPrompt.Run()
 		if err != nil {
-			return fmt.Errorf("back")
+			return helper.ErrBack
 		}
 
-		return fmt.Errorf("continue")
+		return helper.ErrContinue
 	}
 
 	return nil
 }
 
+// BalasKomentar displays the comments and lets the user reply to one of them.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and the comment table with the threads
+//  2. Asks for the ID of the comment to reply to, which must exist
+//  3. Shows the comment that is replied to and asks for the reply through CreateCommentForm
+//  4. Creates the reply in the project of the comment it replies to, with its ParentId set
+//
+// Parameters:
+//   - user: The model.User who writes the reply
+//
+// Returns:
+//   - error: helper.ErrBack when a prompt is cancelled, or an error if the reply cannot be created
+func (c *commentService) BalasKomentar(user model.User) error {
+	helper.Header("MENU > USER > BALAS KOMENTAR", "BALAS KOMENTAR")
+
+	err := c.ShowTable()
+	if err != nil {
+		return err
+	}
+
+	idPrompt := promptui.Prompt{
+		Label: "Masukkan Id Komentar yang ingin dibalas",
+		Validate: func(input string) error {
+			id, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("id harus berupa angka")
+			}
+
+			var parent model.Comment
+			return c.commentRepo.FindCommentById(id, &parent)
+		},
+	}
+
+	input, err := idPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	id, _ := strconv.Atoi(input)
+
+	var parent model.Comment
+	err = c.commentRepo.FindCommentById(id, &parent)
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("Membalas #%d: %s", parent.Id, helper.Truncate(parent.Komentar, 60))
+
+	var komentar, kategori string
+	err = c.CreateCommentForm(&komentar, &kategori)
+	if err != nil {
+		return err
+	}
+
+	return c.CreateComment(&model.Comment{
+		Komentar:  komentar,
+		Kategori:  kategori,
+		ProjectId: parent.ProjectId,
+		ParentId:  parent.Id,
+	}, user.Id)
+}
+
 // CreateComment adds a new comment to the system.
 // It delegates the creation operation to the underlying repository.
 //
@@ -672,6 +902,366 @@ func (c *commentService) CreateComment(comment *model.Comment, userId int) error
 	return c.commentRepo.Create(comment, userId)
 }
 
+// RapidEntry runs a data-entry mode optimized for transcribing many comments quickly.
+//
+// The header is shown once and the screen is not cleared between entries. Each entry
+// asks for the comment text and then the category, which can be typed as 1, 2 or 3 (or
+// the category name), and prints a one-line confirmation with the running count. An empty
+// comment or cancelling a prompt ends the session and shows how many comments were added.
+//
+// Parameters:
+//   - user: The model.User who is creating the comments
+//
+// Returns:
+//   - error: An error if a comment cannot be stored; the comments stored before it are kept
+func (c *commentService) RapidEntry(user model.User) error {
+	helper.Header("MENU > USER > INPUT CEPAT", "INPUT CEPAT")
+	color.Cyan("Kategori: 1 = Positif, 2 = Netral, 3 = Negatif, tambah ? jika ragu. Komentar kosong untuk selesai.")
+
+	count := 0
+
+	for {
+		komentarPrompt := promptui.Prompt{Label: fmt.Sprintf("Komentar #%d", count+1)}
+
+		komentar, err := komentarPrompt.Run()
+		if err != nil || komentar == "" {
+			break
+		}
+
+		kategoriPrompt := promptui.Prompt{
+			Label: "Kategori [1/2/3, tambah ? jika ragu]",
+			Validate: func(input string) error {
+				if parseKategori(input) == "" {
+					return fmt.Errorf("pilih 1, 2 atau 3")
+				}
+
+				return nil
+			},
+		}
+
+		input, err := kategoriPrompt.Run()
+		if err != nil {
+			break
+		}
+
+		kategori := parseKategori(input)
+
+		comment := model.Comment{Komentar: komentar, Kategori: kategori}
+		err = c.CreateComment(&comment, user.Id)
+		if err == nil && isRagu(input) {
+			err = c.commentRepo.SetReview(comment.Id, "ragu")
+		}
+		if err != nil {
+			color.Cyan("%d komentar ditambahkan", count)
+			return err
+		}
+
+		count++
+		color.Green("\u2713 #%d %s tersimpan", count, kategori)
+	}
+
+	color.Cyan("%d komentar ditambahkan", count)
+	helper.Pause()
+
+	return nil
+}
+
+// parseKategori reads a category typed as a number or a name.
+// A trailing "?", which marks the label as uncertain, is ignored.
+//
+// Parameters:
+//   - input: "1", "2" or "3", or a category name in any letter case
+//
+// Returns:
+//   - string: "Positif", "Netral" or "Negatif", or an empty string if the input is not a category
+func parseKategori(input string) string {
+	input = strings.TrimSuffix(strings.TrimSpace(input), "?")
+
+	switch input {
+	case "1":
+		return "Positif"
+	case "2":
+		return "Netral"
+	case "3":
+		return "Negatif"
+	}
+
+	return NormalizeKategori(input)
+}
+
+// isRagu reports whether a typed category ends with "?", which flags the comment as
+// uncertain so it is queued for review by an admin.
+//
+// Parameters:
+//   - input: The category as typed
+//
+// Returns:
+//   - bool: true if the label is marked as uncertain
+func isRagu(input string) bool {
+	return strings.HasSuffix(strings.TrimSpace(input), "?")
+}
+
+// LabelKedua runs the second-labeler mode used to measure label reliability.
+//
+// The comments of the active project that have no second label yet are shown one at a
+// time without their first category, so the second label is assigned independently.
+// Comments written by the labeler themself are left out. For every comment the category
+// is typed as 1, 2 or 3 (or the category name); "s" skips the comment and an empty input
+// or cancelling the prompt ends the session.
+//
+// Parameters:
+//   - user: The model.User acting as the second labeler
+//
+// Returns:
+//   - error: An error if the comments cannot be retrieved or a label cannot be stored
+func (c *commentService) LabelKedua(user model.User) error {
+	helper.Header("MENU > USER > LABEL KEDUA", "LABEL KEDUA")
+	color.Cyan("Kategori: 1 = Positif, 2 = Netral, 3 = Negatif, tambah ? jika ragu, s = lewati. Kosong untuk selesai.")
+
+	var comments [255]model.Comment
+	err := c.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	var queue []model.Comment
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar != "" && comments[i].Kategori2 == "" && comments[i].UserId != user.Id {
+			queue = append(queue, comments[i])
+		}
+	}
+
+	if len(queue) == 0 {
+		color.Cyan("Tidak ada komentar yang perlu dilabeli")
+		helper.Pause()
+		return nil
+	}
+
+	labeled := 0
+	for i, comment := range queue {
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(queue), comment.Komentar)
+
+		kategoriPrompt := promptui.Prompt{
+			Label: "Kategori [1/2/3/s, tambah ? jika ragu]",
+			Validate: func(input string) error {
+				if input != "" && input != "s" && parseKategori(input) == "" {
+					return fmt.Errorf("pilih 1, 2, 3 atau s")
+				}
+
+				return nil
+			},
+		}
+
+		input, err := kategoriPrompt.Run()
+		if err != nil || input == "" {
+			break
+		}
+
+		if input == "s" {
+			continue
+		}
+
+		err = c.commentRepo.SetSecondLabel(comment.Id, user.Id, parseKategori(input))
+		if err == nil && isRagu(input) {
+			err = c.commentRepo.SetReview(comment.Id, "ragu")
+		}
+		if err != nil {
+			return err
+		}
+
+		labeled++
+	}
+
+	color.Cyan("%d komentar diberi label kedua", labeled)
+	helper.Pause()
+
+	return nil
+}
+
+// renderSearchResults renders ranked search results as a table with a relevance column.
+//
+// Parameters:
+//   - results: The results, ordered from the most to the least relevant
+//   - n: The number of results
+func renderSearchResults(results [255]model.SearchResult, n int) {
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Komentar", "Kategori", "Relevansi"})
+	for i := 0; i < n; i++ {
+		t.AppendRow(table.Row{
+			i + 1,
+			results[i].Comment.Komentar,
+			results[i].Comment.Kategori,
+			fmt.Sprintf("%.2f", results[i].Relevance),
+		})
+	}
+	t.Render()
+}
+
+// ScheduledComment displays the comments of a user that are waiting to be published,
+// with the time each one will appear, and waits for the user to press Enter.
+//
+// Parameters:
+//   - user: The model.User whose scheduled comments are shown
+//
+// Returns:
+//   - error: An error if the scheduled comments cannot be retrieved, nil on success
+func (c *commentService) ScheduledComment(user model.User) error {
+	helper.Header("MENU > USER > KOMENTAR TERJADWAL", "KOMENTAR TERJADWAL")
+
+	var scheduled [255]model.ScheduledComment
+	n, err := c.scheduleRepo.GetScheduledByUserId(user.Id, &scheduled)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		color.Cyan("Tidak ada komentar yang dijadwalkan")
+		helper.Pause()
+		return nil
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Komentar", "Kategori", "Tayang"})
+	for i := 0; i < n; i++ {
+		t.AppendRow(table.Row{
+			i + 1,
+			scheduled[i].Comment.Komentar,
+			scheduled[i].Comment.Kategori,
+			scheduled[i].PublishAt.Format("2006-01-02 15:04"),
+		})
+	}
+	t.Render()
+
+	helper.Pause()
+
+	return nil
+}
+
+// Favorit displays the favorite comments of a user and lets the user add or remove one.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and a table of the bookmarked comments, most recently bookmarked first. Comments that have been deleted since are left out
+//  2. Asks the user what to do:
+//     - "Tambah": Prompts for the ID of the comment to bookmark
+//     - "Hapus": Prompts for the ID of the comment to remove from the favorites
+//  3. Displays success message
+//
+// Parameters:
+//   - user: The model.User whose favorites are shown, the zero User for the admin
+//
+// Returns:
+//   - error: helper.ErrContinue after an action so the list is shown again, helper.ErrBack when the user
+//     leaves, or a lookup or repository error
+func (c *commentService) Favorit(user model.User) error {
+	breadcrumb := "MENU > USER > FAVORIT"
+	if user.Id == 0 {
+		breadcrumb = "MENU > ADMIN > LIHAT KOMENTAR > FAVORIT"
+	}
+	helper.Header(breadcrumb, "KOMENTAR FAVORIT")
+
+	var bookmarks [255]model.Bookmark
+	n, err := c.bookmarkRepo.GetBookmarksByUserId(user.Id, &bookmarks)
+	if err != nil {
+		return err
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Id", "Komentar", "Kategori", "Ditandai"})
+	for i := 0; i < n; i++ {
+		var comment model.Comment
+		if c.commentRepo.FindCommentById(bookmarks[i].CommentId, &comment) != nil {
+			continue
+		}
+
+		t.AppendRow(table.Row{comment.Id, comment.Komentar, comment.Kategori, bookmarks[i].CreatedAt.Format("2006-01-02 15:04")})
+	}
+	t.Render()
+
+	prompt := promptui.Select{
+		Label: "Pilih Menu",
+		Items: []string{"Tambah", "Hapus", "Exit"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, action, err := prompt.Run()
+	if err != nil || action == "Exit" {
+		return helper.ErrBack
+	}
+
+	idPrompt := promptui.Prompt{
+		Label: "Id komentar",
+		Validate: func(input string) error {
+			_, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("id komentar harus berupa angka")
+			}
+
+			return nil
+		},
+	}
+
+	input, err := idPrompt.Run()
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	id, _ := strconv.Atoi(input)
+
+	switch action {
+	case "Tambah":
+		var comment model.Comment
+		err = c.commentRepo.FindCommentById(id, &comment)
+		if err != nil {
+			return err
+		}
+
+		err = c.bookmarkRepo.Add(user.Id, id)
+		if err != nil {
+			return err
+		}
+
+		color.Green("Komentar %d ditambahkan ke favorit", id)
+
+	case "Hapus":
+		err = c.bookmarkRepo.Remove(user.Id, id)
+		if err != nil {
+			return err
+		}
+
+		color.Green("Komentar %d dihapus dari favorit", id)
+	}
+
+	helper.Pause()
+
+	return helper.ErrContinue
+}
+
+// StartPublisher starts the background publishing of scheduled comments.
+//
+// A goroutine publishes the due comments right away, so comments that became due while
+// the app was closed appear at startup, and then checks again every SCHEDULE_INTERVAL
+// seconds (default 30). Failed publications are retried on the next check. A check takes
+// the store lock of the repositories, so it waits for a running transaction and never
+// interleaves with a change made from the menu.
+func (c *commentService) StartPublisher() {
+	interval, err := strconv.Atoi(helper.GetEnv("SCHEDULE_INTERVAL", "30"))
+	if err != nil || interval < 1 {
+		interval = 30
+	}
+
+	go func() {
+		for {
+			_, _ = c.scheduleRepo.PublishDue(time.Now())
+			time.Sleep(time.Duration(interval) * time.Second)
+		}
+	}()
+}
+
 // CommentShowPage displays a menu for viewing different types of comments.
 // It presents a selection interface with options to view all comments, positive comments,
 // negative comments, search for comments, view comment statistics, or return to the previous menu.
@@ -687,15 +1277,14 @@ func (c *commentService) CreateComment(comment *model.Comment, userId int) error
 // Returns:
 //   - error: An error if displaying the menu or capturing the selection fails, nil on success
 func (*commentService) CommentShowPage(chose *string) error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > LIHAT KOMENTAR")
-	color.Yellow("========================================")
-	color.Yellow("=           LIHAT KOMENTAR             =")
-	color.Yellow("========================================")
+	helper.Header("MENU > LIHAT KOMENTAR", "LIHAT KOMENTAR")
+
+	labels, keys := helper.MenuItems("komentar", []string{"Lihat Semua Komentar", "Lihat Komentar Positif", "Lihat Komentar Negatif", "Cari Komentar", "Statistik Komentar", "Kembali"})
 
 	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Lihat Semua Komentar", "Lihat Komentar Positif", "Lihat Komentar Negatif", "Cari Komentar", "Statistik Komentar", "Kembali"},
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("komentar", keys),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -704,57 +1293,101 @@ func (*commentService) CommentShowPage(chose *string) error {
 		},
 	}
 
-	_, result, err := prompt.Run()
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, chose) {
+		return nil
+	}
 
 	if err != nil {
 		return err
 	}
 
-	*chose = result
+	*chose = keys[index]
+	helper.RememberMenu("komentar", keys[index])
 
 	return nil
 }
 
-// ShowTable retrieves and displays all comments in a formatted table.
-// It creates a table with columns for comment number, text content, and category.
-// The function queries the repository for all comments, adds each comment
-// to the table (up to the global.CommentCount limit), and renders the table
-// with colored formatting to standard output.
+// ShowTable retrieves and displays the current page of comments in a formatted table.
+// It creates a table with columns for comment number, text content, category, and the
+// times the comment was created and last edited.
+// The function queries the repository for one page (PAGE_SIZE rows) of the comments of
+// the active project in thread order: every reply follows the comment it replies to,
+// indented one level per reply. The table is rendered with colored formatting to
+// standard output, followed by the page number when there is more than one page. When
+// comments were deleted and the page no longer exists, the last page is shown.
 //
 // Returns:
 //   - error: An error if retrieving comments fails, nil on success
 func (c *commentService) ShowTable() error {
 	var comments [255]model.Comment
+	var depths [255]int
 
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori"})
+	pages := pageCount(c.commentRepo.CountComments())
+	c.page = min(c.page, pages-1)
 
-	err := c.commentRepo.GetAllComments(&comments)
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori", "Dibuat", "Diubah"})
+
+	offset := c.page * PageSize()
+	n, err := c.commentRepo.GetThreads(offset, PageSize(), &comments, &depths)
 	if err != nil {
 		return err
 	}
 
-	for i := 0; i < global.CommentCount; i++ {
+	for i := 0; i < n; i++ {
+		komentar := comments[i].Komentar
+		if depths[i] > 0 {
+			komentar = strings.Repeat("  ", depths[i]-1) + "\u21B3 " + komentar
+		}
+
 		t.AppendRow(table.Row{
-			i + 1,
+			offset + i + 1,
 			comments[i].Id,
-			comments[i].Komentar,
+			komentar,
 			comments[i].Kategori,
+			comments[i].CreatedAt.Format("2006-01-02 15:04"),
+			updatedAt(comments[i]),
 		})
 	}
 
-	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 
+	if pages > 1 {
+		color.Cyan("Halaman %d dari %d", c.page+1, pages)
+	}
+
 	return nil
 }
 
+// PageKeys adds "Halaman Berikutnya" and "Halaman Sebelumnya" in front of the last menu
+// key when the comment table has a page to move to.
+//
+// Parameters:
+//   - keys: The menu keys, ending with Exit
+//
+// Returns:
+//   - []string: The menu keys including the page navigation
+func (c *commentService) PageKeys(keys []string) []string {
+	return pageItems(keys, c.page, pageCount(c.commentRepo.CountComments()))
+}
+
+// TurnPage moves the comment table shown by ShowTable to the next or previous page.
+//
+// Parameters:
+//   - key: The selected menu key
+//
+// Returns:
+//   - bool: true if key was a page navigation key
+func (c *commentService) TurnPage(key string) bool {
+	return turnPage(key, &c.page)
+}
+
 // showCommentByUserTable retrieves and displays comments from a specific user in a formatted table.
-// It creates a table with columns for row number, comment ID, text content, and category.
-// The function queries the repository for comments belonging to the specified user,
-// adds each non-empty comment to the table, and renders the table with colored formatting
-// to standard output.
+// It creates a table with columns for row number, comment ID, text content, category, and
+// the times the comment was created and last edited. The function queries the repository
+// for comments belonging to the specified user, adds each non-empty comment to the table,
+// and renders the table with colored formatting to standard output.
 //
 // Parameters:
 //   - userId: An integer representing the ID of the user whose comments should be displayed
@@ -764,9 +1397,8 @@ func (c *commentService) ShowTable() error {
 func (c *commentService) showCommentByUserTable(userId int) error {
 	var comments [255]model.Comment
 
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori"})
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori", "Dibuat", "Diubah"})
 	err := c.commentRepo.GetCommentByUserId(userId, &comments)
 	if err != nil {
 		return err
@@ -780,10 +1412,11 @@ func (c *commentService) showCommentByUserTable(userId int) error {
 				comments[i].Id,
 				comments[i].Komentar,
 				comments[i].Kategori,
+				comments[i].CreatedAt.Format("2006-01-02 15:04"),
+				updatedAt(comments[i]),
 			})
 		}
 	}
-	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 
 	return nil
@@ -807,3 +1440,19 @@ func (c *commentService) EditComment(id int, komentar model.Comment) error {
 
 	return nil
 }
+
+// updatedAt formats the last edit time of a comment for a table, "-" when the comment
+// was never edited.
+//
+// Parameters:
+//   - comment: The comment to format
+//
+// Returns:
+//   - string: The last edit time, or "-"
+func updatedAt(comment model.Comment) string {
+	if !comment.UpdatedAt.After(comment.CreatedAt) {
+		return "-"
+	}
+
+	return comment.UpdatedAt.Format("2006-01-02 15:04")
+}
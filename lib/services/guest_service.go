@@ -0,0 +1,245 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// GuestService defines the interface for the read-only guest mode.
+// Guests can browse and search comments and view public statistics without logging in;
+// none of the menus it offers can modify data.
+type GuestService interface {
+	// GuestMenu displays the guest menu and captures the guest's selection.
+	GuestMenu(result *string) error
+
+	// Statistik displays the public sentiment statistics.
+	Statistik() error
+
+	// Kiosk shows the latest comments and the sentiment chart full-screen and refreshes
+	// them periodically until Enter is pressed, for use on a projector.
+	Kiosk() error
+}
+
+// guestService implements the GuestService interface.
+type guestService struct {
+	commentRepo repository.CommentRepository
+	events      repository.EventBus
+}
+
+// NewGuestService creates and returns a new GuestService implementation.
+//
+// Parameters:
+//   - commentRepo: The CommentRepository implementation used to read the comments
+//   - events: The EventBus implementation the kiosk mode listens on for changes
+//
+// Returns:
+//   - GuestService: A new instance of the guestService implementation
+func NewGuestService(commentRepo repository.CommentRepository, events repository.EventBus) GuestService {
+	return &guestService{
+		commentRepo: commentRepo,
+		events:      events,
+	}
+}
+
+// GuestMenu displays the guest menu and captures the guest's selection.
+//
+// It clears the screen, displays the guest header and presents the read-only
+// options (Lihat Komentar, Statistik, Mode Kiosk, Exit).
+//
+// Parameters:
+//   - result: Pointer to store the selected menu option as a string
+//
+// Returns:
+//   - error: Any error encountered during menu display or selection process
+func (g *guestService) GuestMenu(result *string) error {
+	helper.Header("MENU > TAMU", "LIHAT SEBAGAI TAMU")
+	color.Cyan("Mode baca saja. Login untuk menambah atau mengubah komentar.")
+
+	labels, keys := helper.MenuItems("guest", []string{"Lihat Komentar", "Statistik", "Mode Kiosk", "Exit"})
+
+	prompt := promptui.Select{
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("guest", keys),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, result) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*result = keys[index]
+	helper.RememberMenu("guest", keys[index])
+
+	return nil
+}
+
+// Statistik displays the public sentiment statistics.
+//
+// It renders the number of comments per category with their percentage and a bar.
+// Unlike the admin chart it does not reveal anything about the registered users.
+//
+// Returns:
+//   - error: Any error encountered while reading the comments
+func (g *guestService) Statistik() error {
+	var comments [255]model.Comment
+
+	helper.Header("MENU > TAMU > STATISTIK", "STATISTIK")
+
+	err := g.renderChart(&comments)
+	if err != nil {
+		return err
+	}
+
+	helper.Pause()
+
+	return nil
+}
+
+// Kiosk shows the latest comments and the sentiment chart full-screen.
+//
+// The screen is redrawn as soon as a domain event arrives on the event bus, and at the
+// latest every KIOSK_INTERVAL seconds (default 10), so new comments appear without anyone
+// touching the keyboard. Pressing Enter leaves the kiosk mode.
+//
+// Returns:
+//   - error: Any error encountered while reading the comments
+func (g *guestService) Kiosk() error {
+	interval, err := strconv.Atoi(helper.GetEnv("KIOSK_INTERVAL", "10"))
+	if err != nil || interval < 1 {
+		interval = 10
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	events, unsubscribe := g.events.Subscribe()
+	defer unsubscribe()
+
+	quit, stop := helper.EnterPressed()
+	defer stop()
+
+	var last *model.JournalEntry
+	for {
+		err = g.renderKiosk(interval, last)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-quit:
+			return nil
+		case <-ticker.C:
+		case event := <-events:
+			last = &event
+
+			// Coalesce a burst of events (e.g. an import) into a single redraw
+			for drained := false; !drained; {
+				select {
+				case event = <-events:
+					last = &event
+				default:
+					drained = true
+				}
+			}
+		}
+	}
+}
+
+// renderKiosk draws one frame of the kiosk mode: the counters, the newest comments and the chart.
+//
+// Parameters:
+//   - interval: The refresh interval in seconds, shown in the footer
+//   - last: The most recent domain event, nil if none arrived yet
+//
+// Returns:
+//   - error: Any error encountered while reading the comments
+func (g *guestService) renderKiosk(interval int, last *model.JournalEntry) error {
+	var comments [255]model.Comment
+
+	helper.Header("", "ANALISIS SENTIMEN - LIVE")
+
+	err := g.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("Komentar: %d   User: %d", g.commentRepo.CountComments(), global.UserCount)
+	if last != nil {
+		color.Cyan("Perubahan terakhir: %s pukul %s", last.Command, last.Timestamp.Format("15:04:05"))
+	}
+
+	t := helper.NewTable()
+	t.SetTitle("Komentar Terbaru")
+	t.AppendHeader(table.Row{"#", "Komentar", "Kategori", "Waktu"})
+	shown := 0
+	for i := global.CommentCount - 1; i >= 0 && shown < PageSize(); i-- {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		shown++
+		t.AppendRow(table.Row{shown, comments[i].Komentar, comments[i].Kategori, comments[i].CreatedAt.Format("15:04")})
+	}
+	t.Render()
+
+	err = g.renderChart(&comments)
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("Diperbarui %s, refresh setiap %d detik. Tekan Enter untuk keluar.", time.Now().Format("15:04:05"), interval)
+
+	return nil
+}
+
+// renderChart renders the number of comments per category with their percentage and a bar.
+//
+// Parameters:
+//   - comments: A scratch array used to query the comments per category
+//
+// Returns:
+//   - error: Any error encountered while reading the comments
+func (g *guestService) renderChart(comments *[255]model.Comment) error {
+	total := g.commentRepo.CountComments()
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Kategori", "Jumlah", "Persentase", "Bar"})
+	for _, kategori := range []string{"Positif", "Netral", "Negatif"} {
+		count, err := g.commentRepo.GetCommentByKategori(kategori, comments)
+		if err != nil {
+			return err
+		}
+
+		t.AppendRow(table.Row{
+			kategori,
+			count,
+			fmt.Sprintf("%.1f%%", percentage(count, total)),
+			bar(count, total, 20),
+		})
+	}
+	t.AppendFooter(table.Row{"Total", total, "", ""})
+	t.Render()
+
+	return nil
+}
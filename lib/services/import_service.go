@@ -0,0 +1,956 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// ImportService defines the interface for importing comment datasets from files.
+type ImportService interface {
+	// ImportKomentar displays the comment import interface.
+	// It asks for a CSV or JSON file and a data source, imports every valid row,
+	// writes the rejected rows with their reasons to a rejects file and shows a summary.
+	ImportKomentar() error
+
+	// ImportFile imports the comments in a CSV or JSON file.
+	// Rows that fail validation are collected in the result instead of being stored.
+	// Rows that duplicate an existing comment are skipped, or merged into it when merge is true.
+	// When autoLabel is true, rows without a category are labeled by the sentiment analyzer.
+	ImportFile(path, sumber string, merge, autoLabel bool) (ImportResult, error)
+
+	// ImportUsers displays the user import interface. It asks for a CSV file of
+	// usernames and/or e-mail addresses, creates the accounts with temporary passwords
+	// and shows the credentials, which can be saved to a CSV file for distribution.
+	ImportUsers() error
+
+	// ImportUserFile creates an account with a generated temporary password for every
+	// valid row of a CSV file. The import is all-or-nothing.
+	ImportUserFile(path string) (UserImportResult, error)
+}
+
+// UserImportResult describes the outcome of a user import.
+type UserImportResult struct {
+	// Total is the number of data rows read from the file.
+	Total int
+
+	// Credentials lists the created accounts with their temporary passwords.
+	Credentials []Credential
+
+	// Rejected lists the rows that were not imported.
+	Rejected []RejectedRow
+}
+
+// Credential is the login of an imported account, handed to the respondent.
+type Credential struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// ImportResult describes the outcome of an import.
+type ImportResult struct {
+	// Total is the number of data rows read from the file.
+	Total int
+
+	// Imported is the number of rows stored as comments.
+	Imported int
+
+	// Skipped is the number of duplicate rows that were left out.
+	Skipped int
+
+	// Merged is the number of duplicate rows merged into the existing comment.
+	Merged int
+
+	// AutoLabeled is the number of stored rows whose category was predicted by the sentiment analyzer.
+	AutoLabeled int
+
+	// Rejected lists the rows that failed validation.
+	Rejected []RejectedRow
+
+	// RejectsPath is the location of the rejects file, empty when nothing was rejected.
+	RejectsPath string
+}
+
+// RejectedRow is a row that failed validation during an import.
+type RejectedRow struct {
+	// Line is the row number in the source file (the CSV header is line 1).
+	Line int
+
+	// Komentar, Kategori and Username are the raw values of the row.
+	Komentar, Kategori, Username string
+
+	// Alasan is the short reason the row was rejected.
+	Alasan string
+
+	// Detail is the offending value, if any.
+	Detail string
+}
+
+// importRow is a single row read from an import file before validation.
+type importRow struct {
+	Line      int
+	Uuid      string
+	Komentar  string
+	Kategori  string
+	Username  string
+	Sumber    string
+	CreatedAt string
+}
+
+// importService implements the ImportService interface.
+type importService struct {
+	userService UserService
+	commentRepo repository.CommentRepository
+	tx          repository.TransactionRepository
+	sentiment   SentimentService
+	audit       AuditService
+}
+
+// NewImportService creates and returns a new ImportService implementation.
+//
+// Parameters:
+//   - userService: The UserService implementation used to resolve usernames
+//   - commentRepo: The CommentRepository implementation used to store the comments
+//   - tx: The TransactionRepository implementation that makes an import all-or-nothing
+//   - sentiment: The SentimentService implementation used to label rows without a category
+//   - audit: The AuditService implementation every imported comment and account is recorded with
+//
+// Returns:
+//   - ImportService: A new instance of the importService implementation
+func NewImportService(userService UserService, commentRepo repository.CommentRepository, tx repository.TransactionRepository, sentiment SentimentService, audit AuditService) ImportService {
+	return &importService{
+		userService: userService,
+		commentRepo: commentRepo,
+		tx:          tx,
+		sentiment:   sentiment,
+		audit:       audit,
+	}
+}
+
+// ImportKomentar displays the comment import interface.
+//
+// The function workflow:
+//  1. Clears the screen and displays the import header
+//  2. Prompts for the path of a .csv or .json file and the data source name
+//  3. Asks whether duplicate comments should be skipped or merged and whether rows
+//     without a category should be labeled automatically
+//  4. Imports the file through ImportFile
+//  5. Renders a summary table (rows read, imported, duplicates, rejected) and a table of
+//     rejection reasons, and prints the location of the rejects file
+//
+// Returns:
+//   - error: File or import errors, or helper.ErrBack when the admin cancels
+func (s *importService) ImportKomentar() error {
+	helper.Header("MENU > ADMIN > IMPORT KOMENTAR", "IMPORT KOMENTAR")
+	color.Cyan("Format CSV: header dengan kolom komentar, kategori, username, sumber dan uuid (opsional)")
+	color.Cyan("Format JSON: array objek dengan field yang sama")
+
+	pathPrompt := promptui.Prompt{
+		Label: "Path file (.csv / .json)",
+		Validate: func(input string) error {
+			if input == "" {
+				return fmt.Errorf("path tidak boleh kosong")
+			}
+
+			_, err := os.Stat(input)
+			if err != nil {
+				return fmt.Errorf("file tidak ditemukan")
+			}
+
+			return nil
+		},
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	sumberPrompt := promptui.Prompt{
+		Label:   "Sumber data",
+		Default: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+	}
+
+	sumber, err := sumberPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	duplicatePrompt := promptui.Select{
+		Label: "Jika komentar sudah ada",
+		Items: []string{"Lewati duplikat", "Gabungkan (perbarui kategori)"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	mode, _, err := duplicatePrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	autoLabelPrompt := promptui.Prompt{
+		Label:     "Label otomatis baris tanpa kategori",
+		IsConfirm: true,
+	}
+
+	_, err = autoLabelPrompt.Run()
+	autoLabel := err == nil
+
+	result, err := s.ImportFile(path, sumber, mode == 1, autoLabel)
+	if err != nil {
+		return err
+	}
+
+	ShowImportSummary(result)
+	helper.Pause()
+
+	return nil
+}
+
+// shownRejects is the number of rejected rows listed on screen after an import,
+// the complete list is in the rejects file.
+const shownRejects = 10
+
+// ShowImportSummary renders the outcome of an import as tables: the counts, the number of
+// rejected rows per reason and the first rejected rows with their line and reason.
+//
+// Parameters:
+//   - result: The import result to display
+func ShowImportSummary(result ImportResult) {
+	t := helper.NewTable()
+	t.SetTitle("Ringkasan Import")
+	t.AppendHeader(table.Row{"Keterangan", "Jumlah"})
+	t.AppendRow(table.Row{"Baris dibaca", result.Total})
+	t.AppendRow(table.Row{"Berhasil diimport", result.Imported})
+	t.AppendRow(table.Row{"Duplikat dilewati", result.Skipped})
+	t.AppendRow(table.Row{"Duplikat digabung", result.Merged})
+	t.AppendRow(table.Row{"Dilabeli otomatis", result.AutoLabeled})
+	t.AppendRow(table.Row{"Ditolak", len(result.Rejected)})
+	t.Render()
+
+	if len(result.Rejected) == 0 {
+		return
+	}
+
+	var reasons []string
+	counts := make(map[string]int)
+	for _, row := range result.Rejected {
+		if counts[row.Alasan] == 0 {
+			reasons = append(reasons, row.Alasan)
+		}
+		counts[row.Alasan]++
+	}
+
+	r := helper.NewTable()
+	r.AppendHeader(table.Row{"Alasan Ditolak", "Jumlah"})
+	for _, reason := range reasons {
+		r.AppendRow(table.Row{reason, counts[reason]})
+	}
+	r.Render()
+
+	d := helper.NewTable()
+	d.AppendHeader(table.Row{"Baris", "Komentar", "Alasan", "Nilai"})
+	for _, row := range result.Rejected[:min(shownRejects, len(result.Rejected))] {
+		d.AppendRow(table.Row{row.Line, helper.Truncate(row.Komentar, 40), row.Alasan, row.Detail})
+	}
+	d.Render()
+
+	if len(result.Rejected) > shownRejects {
+		color.Cyan("... dan %d baris ditolak lainnya", len(result.Rejected)-shownRejects)
+	}
+
+	color.Cyan("Baris yang ditolak ditulis ke %s", result.RejectsPath)
+}
+
+// ImportFile imports the comments in a CSV or JSON file.
+//
+// Every row is validated before it is stored. A row is rejected when:
+//   - any of its values is not valid UTF-8
+//   - the comment text is empty
+//   - the category is not Positif, Netral or Negatif (case-insensitive), unless it is
+//     empty and autoLabel is true: the category is then predicted by the sentiment analyzer
+//   - the username is given but no such user exists
+//   - created_at is given but cannot be parsed
+//   - the comment storage is full
+//
+// A row is a duplicate when a comment with the same UUID already exists or, for rows
+// without a UUID, a comment with the same text (ignoring case and extra whitespace), the
+// same user and the same source already exists, either in the store or earlier in the file. Duplicates are not stored again: they are skipped, or
+// when merge is true the category of the existing comment is updated to the imported one.
+//
+// Valid rows are stored through CommentRepository.Create. Rejected rows are written to
+// <file>_rejects.csv next to the source file, together with the reason. The rows are
+// stored in a single transaction: if a comment cannot be stored or the rejects file
+// cannot be written, the whole import is rolled back. Once the import is stored, every
+// created comment and every merged duplicate is recorded in the audit log.
+//
+// Parameters:
+//   - path: The location of the .csv or .json file
+//   - sumber: The data source stored on rows that do not specify their own
+//   - merge: Whether duplicates are merged into the existing comment instead of skipped
+//   - autoLabel: Whether rows without a category are labeled by the sentiment analyzer
+//
+// Returns:
+//   - ImportResult: The number of rows read, imported, skipped, merged and auto-labeled and the rejected rows
+//   - error: An error if the file cannot be read or the import was rolled back
+func (s *importService) ImportFile(path, sumber string, merge, autoLabel bool) (ImportResult, error) {
+	var result ImportResult
+
+	var rows []importRow
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = readImportCSV(path)
+	case ".json":
+		rows, err = readImportJSON(path)
+	default:
+		return result, fmt.Errorf("format file tidak didukung: %s (gunakan .csv atau .json)", filepath.Ext(path))
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.Total = len(rows)
+
+	var comments [255]model.Comment
+	err = s.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return result, err
+	}
+
+	existing := make(map[string]model.Comment)
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		for _, key := range duplicateKeys(comments[i]) {
+			existing[key] = comments[i]
+		}
+	}
+
+	var changes []auditChange
+	err = s.tx.Run(func() error {
+		for _, row := range rows {
+			labeled := false
+			if autoLabel && strings.TrimSpace(row.Kategori) == "" && utf8.ValidString(row.Komentar) {
+				row.Kategori, _ = s.sentiment.Analyze(row.Komentar)
+				labeled = true
+			}
+
+			comment, rejected := s.validateRow(row, sumber)
+			if rejected != nil {
+				result.Rejected = append(result.Rejected, *rejected)
+				continue
+			}
+
+			key := duplicateKeys(comment)[0]
+			if duplicate, ok := existing[key]; ok {
+				if !merge {
+					result.Skipped++
+					continue
+				}
+
+				// A predicted category never overrides a category that was labeled by hand
+				if duplicate.Kategori != comment.Kategori && !labeled {
+					err := s.commentRepo.EditComment(duplicate.Id, model.Comment{Kategori: comment.Kategori, Version: duplicate.Version})
+					if err != nil {
+						return err
+					}
+
+					before := duplicate
+					duplicate.Kategori = comment.Kategori
+					duplicate.Version++
+					changes = append(changes, auditChange{"edit_comment", duplicate.Id, before, duplicate})
+					for _, key := range duplicateKeys(duplicate) {
+						existing[key] = duplicate
+					}
+				}
+
+				result.Merged++
+				continue
+			}
+
+			if global.CommentCount >= len(global.Comments) {
+				result.Rejected = append(result.Rejected, reject(row, "penyimpanan penuh", ""))
+				continue
+			}
+
+			err := s.commentRepo.Create(&comment, comment.UserId)
+			if err != nil {
+				return err
+			}
+
+			comment.Version = 1
+			for _, key := range duplicateKeys(comment) {
+				existing[key] = comment
+			}
+			changes = append(changes, auditChange{"create_comment", comment.Id, nil, comment})
+			result.Imported++
+			if labeled {
+				result.AutoLabeled++
+			}
+		}
+
+		if len(result.Rejected) == 0 {
+			return nil
+		}
+
+		result.RejectsPath = strings.TrimSuffix(path, filepath.Ext(path)) + "_rejects.csv"
+
+		lines := make([][]string, len(result.Rejected))
+		for i, row := range result.Rejected {
+			alasan := row.Alasan
+			if row.Detail != "" {
+				alasan += ": " + row.Detail
+			}
+			lines[i] = []string{strconv.Itoa(row.Line), row.Komentar, row.Kategori, row.Username, alasan}
+		}
+
+		return helper.WriteCSV(result.RejectsPath, []string{"baris", "komentar", "kategori", "username", "alasan"}, lines)
+	})
+	if err != nil {
+		return ImportResult{Total: len(rows)}, fmt.Errorf("import dibatalkan, tidak ada data yang disimpan: %v", err)
+	}
+
+	for _, change := range changes {
+		s.audit.Record(change.action, change.targetId, change.before, change.after)
+	}
+
+	return result, nil
+}
+
+// validateRow checks a single import row and converts it to a comment.
+//
+// Parameters:
+//   - row: The raw row read from the file
+//   - sumber: The data source used when the row does not specify one
+//
+// Returns:
+//   - model.Comment: The comment to store, valid only when rejected is nil
+//   - *RejectedRow: The rejection details, nil when the row is valid
+func (s *importService) validateRow(row importRow, sumber string) (model.Comment, *RejectedRow) {
+	var comment model.Comment
+
+	for _, value := range []string{row.Komentar, row.Kategori, row.Username, row.Sumber, row.CreatedAt} {
+		if !utf8.ValidString(value) {
+			rejected := reject(row, "encoding tidak valid", "bukan UTF-8")
+			return comment, &rejected
+		}
+	}
+
+	comment.Komentar = strings.TrimSpace(row.Komentar)
+	if comment.Komentar == "" {
+		rejected := reject(row, "komentar kosong", "")
+		return comment, &rejected
+	}
+
+	comment.Kategori = NormalizeKategori(row.Kategori)
+	if comment.Kategori == "" {
+		rejected := reject(row, "kategori tidak dikenal", row.Kategori)
+		return comment, &rejected
+	}
+
+	if row.Username != "" {
+		var user model.User
+		err := s.userService.FindUserByUsername(row.Username, &user)
+		if err != nil {
+			rejected := reject(row, "user tidak ditemukan", row.Username)
+			return comment, &rejected
+		}
+		comment.UserId = user.Id
+	}
+
+	if row.CreatedAt != "" {
+		createdAt, err := parseTime(row.CreatedAt)
+		if err != nil {
+			rejected := reject(row, "created_at tidak valid", row.CreatedAt)
+			return comment, &rejected
+		}
+		comment.CreatedAt = createdAt
+	}
+
+	comment.Uuid = strings.TrimSpace(row.Uuid)
+	comment.Sumber = sumber
+	if row.Sumber != "" {
+		comment.Sumber = row.Sumber
+	}
+
+	return comment, nil
+}
+
+// duplicateKeys builds the keys used to detect duplicate comments during an import.
+// A comment with a UUID matches on its UUID, so datasets exported from other machines
+// can be merged safely. Every comment also matches on its text (ignoring case and extra
+// whitespace) together with its user and source.
+//
+// Parameters:
+//   - comment: The comment to build the keys for
+//
+// Returns:
+//   - []string: The keys of the comment, the most specific one first
+func duplicateKeys(comment model.Comment) []string {
+	text := strings.ToLower(strings.Join(strings.Fields(comment.Komentar), " "))
+	textKey := fmt.Sprintf("%s\x00%d\x00%s", text, comment.UserId, strings.ToLower(strings.TrimSpace(comment.Sumber)))
+
+	if comment.Uuid == "" {
+		return []string{textKey}
+	}
+
+	return []string{"uuid\x00" + strings.ToLower(comment.Uuid), textKey}
+}
+
+// NormalizeKategori maps a category name to its canonical spelling.
+//
+// Parameters:
+//   - kategori: The category as written in the source data
+//
+// Returns:
+//   - string: "Positif", "Netral" or "Negatif", or an empty string if the category is unknown
+func NormalizeKategori(kategori string) string {
+	switch strings.ToLower(strings.TrimSpace(kategori)) {
+	case "positif":
+		return "Positif"
+	case "netral":
+		return "Netral"
+	case "negatif":
+		return "Negatif"
+	}
+
+	return ""
+}
+
+// reject builds a RejectedRow from an import row.
+//
+// Parameters:
+//   - row: The row that failed validation
+//   - alasan: The short reason
+//   - detail: The offending value, may be empty
+//
+// Returns:
+//   - RejectedRow: The rejection details
+func reject(row importRow, alasan, detail string) RejectedRow {
+	return RejectedRow{
+		Line:     row.Line,
+		Komentar: row.Komentar,
+		Kategori: row.Kategori,
+		Username: row.Username,
+		Alasan:   alasan,
+		Detail:   detail,
+	}
+}
+
+// parseTime parses a timestamp in RFC 3339, "2006-01-02 15:04:05" or "2006-01-02" format.
+//
+// Parameters:
+//   - value: The timestamp to parse
+//
+// Returns:
+//   - time.Time: The parsed time, in local time for the formats without a zone
+//   - error: An error if none of the formats match
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		t, err = time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// importColumn maps a CSV header or JSON key to the importRow field it fills.
+//
+// Parameters:
+//   - name: The column name, compared case-insensitively
+//
+// Returns:
+//   - string: The canonical column name, or an empty string if the column is ignored
+func importColumn(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "komentar", "text", "comment":
+		return "komentar"
+	case "kategori", "label", "category":
+		return "kategori"
+	case "username", "user":
+		return "username"
+	case "sumber", "source":
+		return "sumber"
+	case "created_at", "tanggal":
+		return "created_at"
+	case "uuid":
+		return "uuid"
+	}
+
+	return ""
+}
+
+// setImportValue stores a value in the importRow field of a canonical column.
+//
+// Parameters:
+//   - row: The row to fill
+//   - column: The canonical column name returned by importColumn
+//   - value: The value to store
+func setImportValue(row *importRow, column, value string) {
+	switch column {
+	case "komentar":
+		row.Komentar = value
+	case "kategori":
+		row.Kategori = value
+	case "username":
+		row.Username = value
+	case "sumber":
+		row.Sumber = value
+	case "created_at":
+		row.CreatedAt = value
+	case "uuid":
+		row.Uuid = value
+	}
+}
+
+// readImportCSV reads the rows of a CSV file with a header row.
+//
+// Parameters:
+//   - path: The location of the CSV file
+//
+// Returns:
+//   - []importRow: The data rows, numbered from line 2
+//   - error: An error if the file cannot be read or has no komentar column
+func readImportCSV(path string) ([]importRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("file CSV tidak valid: %v", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file CSV kosong")
+	}
+
+	columns := make([]string, len(records[0]))
+	hasKomentar := false
+	for i, name := range records[0] {
+		columns[i] = importColumn(strings.TrimPrefix(name, "\ufeff"))
+		if columns[i] == "komentar" {
+			hasKomentar = true
+		}
+	}
+
+	if !hasKomentar {
+		return nil, fmt.Errorf("kolom komentar tidak ditemukan pada header CSV")
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := importRow{Line: i + 2}
+		for j, value := range record {
+			if j < len(columns) {
+				setImportValue(&row, columns[j], value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ImportUsers displays the user import interface.
+//
+// The function workflow:
+//  1. Clears the screen and displays the import header
+//  2. Prompts for the path of a .csv file with the columns username and/or email
+//  3. Imports the accounts through ImportUserFile
+//  4. Renders the credentials and the rejected rows
+//  5. Offers to save the credentials to a CSV file for distribution
+//
+// Returns:
+//   - error: File or import errors, or helper.ErrBack when the admin cancels
+func (s *importService) ImportUsers() error {
+	helper.Header("MENU > ADMIN > LIHAT USER > IMPORT", "IMPORT USER")
+	color.Cyan("Format CSV: header dengan kolom username dan/atau email")
+	color.Cyan("Tanpa username, username diambil dari bagian email sebelum @")
+
+	pathPrompt := promptui.Prompt{
+		Label: "Path file (.csv)",
+		Validate: func(input string) error {
+			if input == "" {
+				return fmt.Errorf("path tidak boleh kosong")
+			}
+
+			_, err := os.Stat(input)
+			if err != nil {
+				return fmt.Errorf("file tidak ditemukan")
+			}
+
+			return nil
+		},
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	result, err := s.ImportUserFile(path)
+	if err != nil {
+		return err
+	}
+
+	color.Green("%d dari %d user diimport", len(result.Credentials), result.Total)
+
+	if len(result.Credentials) > 0 {
+		t := helper.NewTable()
+		t.SetTitle("Kredensial Sementara")
+		t.AppendHeader(table.Row{"Username", "Email", "Password"})
+		for _, credential := range result.Credentials {
+			t.AppendRow(table.Row{credential.Username, credential.Email, credential.Password})
+		}
+		t.Render()
+	}
+
+	if len(result.Rejected) > 0 {
+		t := helper.NewTable()
+		t.SetTitle("Baris Ditolak")
+		t.AppendHeader(table.Row{"Baris", "Username", "Alasan"})
+		for _, row := range result.Rejected {
+			t.AppendRow(table.Row{row.Line, row.Username, row.Alasan})
+		}
+		t.Render()
+	}
+
+	if len(result.Credentials) == 0 {
+		helper.Pause()
+		return nil
+	}
+
+	exportPrompt := promptui.Prompt{
+		Label:   "Simpan kredensial ke file (kosongkan untuk lewati)",
+		Default: "kredensial_user.csv",
+	}
+
+	exportPath, err := exportPrompt.Run()
+	if err != nil || exportPath == "" {
+		return nil
+	}
+
+	rows := make([][]string, len(result.Credentials))
+	for i, credential := range result.Credentials {
+		rows[i] = []string{credential.Username, credential.Email, credential.Password}
+	}
+
+	err = helper.WriteCSV(exportPath, []string{"username", "email", "password"}, rows)
+	if err != nil {
+		return err
+	}
+
+	color.Green("Kredensial disimpan ke %s. Bagikan lalu hapus file ini.", exportPath)
+	helper.Pause()
+
+	return nil
+}
+
+// ImportUserFile creates the accounts listed in a CSV file.
+//
+// The header must have a username or an email column (case-insensitive). A row
+// without a username gets the part of the e-mail address before the @. Rows are
+// rejected when they have neither, when the username is already taken, or when it
+// appears twice in the file. E-mail addresses are not stored with the account, they
+// are only carried into the credentials so the list can be mailed out.
+//
+// Every account gets a random temporary password, see temporaryPassword, that has to be
+// changed on the first login. The rows are
+// created in one transaction, so a failure leaves no partial import behind. Once the
+// import is stored, every created account is recorded in the audit log.
+//
+// Parameters:
+//   - path: The location of the CSV file
+//
+// Returns:
+//   - UserImportResult: The created credentials and the rejected rows
+//   - error: An error if the file cannot be read or an account cannot be stored
+func (s *importService) ImportUserFile(path string) (UserImportResult, error) {
+	var result UserImportResult
+
+	file, err := os.Open(path)
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return result, fmt.Errorf("file CSV tidak valid: %v", err)
+	}
+
+	if len(records) == 0 {
+		return result, fmt.Errorf("file CSV kosong")
+	}
+
+	usernameColumn, emailColumn := -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "username", "user":
+			usernameColumn = i
+		case "email", "e-mail":
+			emailColumn = i
+		}
+	}
+
+	if usernameColumn < 0 && emailColumn < 0 {
+		return result, fmt.Errorf("kolom username atau email tidak ditemukan pada header CSV")
+	}
+
+	value := func(record []string, column int) string {
+		if column < 0 || column >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[column])
+	}
+
+	result.Total = len(records) - 1
+	seen := make(map[string]bool)
+
+	var created []model.User
+	err = s.tx.Run(func() error {
+		for i, record := range records[1:] {
+			row := importRow{Line: i + 2, Username: value(record, usernameColumn)}
+			email := value(record, emailColumn)
+			if row.Username == "" {
+				row.Username, _, _ = strings.Cut(email, "@")
+			}
+
+			switch {
+			case row.Username == "":
+				result.Rejected = append(result.Rejected, reject(row, "username kosong", ""))
+				continue
+			case seen[strings.ToLower(row.Username)]:
+				result.Rejected = append(result.Rejected, reject(row, "username ganda di file", row.Username))
+				continue
+			case s.userService.IsUserExists(row.Username, -1):
+				result.Rejected = append(result.Rejected, reject(row, "username sudah dipakai", row.Username))
+				continue
+			}
+			seen[strings.ToLower(row.Username)] = true
+
+			password, err := temporaryPassword()
+			if err != nil {
+				return err
+			}
+
+			user := model.User{Username: row.Username, Password: password, MustChangePassword: true}
+			err = s.userService.CreateUser(&user)
+			if err != nil {
+				return fmt.Errorf("baris %d: %v", row.Line, err)
+			}
+			created = append(created, user)
+
+			result.Credentials = append(result.Credentials, Credential{row.Username, email, password})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return UserImportResult{}, err
+	}
+
+	for _, user := range created {
+		s.audit.Record("create_user", user.Id, nil, user)
+	}
+
+	return result, nil
+}
+
+// temporaryPassword generates a random 10 character password for an imported account.
+// Characters that are easy to confuse on paper (0/O, 1/l/I) are left out.
+//
+// Returns:
+//   - string: The password
+//   - error: An error if the system random source fails
+func temporaryPassword() (string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	secret := make([]byte, 10)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	for i, b := range secret {
+		secret[i] = alphabet[int(b)%len(alphabet)]
+	}
+
+	return string(secret), nil
+}
+
+// readImportJSON reads the rows of a JSON file containing an array of objects.
+//
+// Parameters:
+//   - path: The location of the JSON file
+//
+// Returns:
+//   - []importRow: The data rows, numbered from 1
+//   - error: An error if the file cannot be read or is not an array of objects
+func readImportJSON(path string) ([]importRow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]interface{}
+	err = json.Unmarshal(data, &records)
+	if err != nil {
+		return nil, fmt.Errorf("file JSON tidak valid: %v", err)
+	}
+
+	rows := make([]importRow, 0, len(records))
+	for i, record := range records {
+		row := importRow{Line: i + 1}
+		for key, value := range record {
+			if value == nil {
+				continue
+			}
+			setImportValue(&row, importColumn(key), fmt.Sprint(value))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tugas-besar/lib/helper"
+)
+
+// InferenceService defines the interface for classifying comments with a remote
+// HuggingFace model through the HuggingFace Inference API.
+type InferenceService interface {
+	// IsConfigured reports whether a model and an API token are available.
+	IsConfigured() bool
+
+	// Model returns the name of the configured model.
+	Model() string
+
+	// Classify sends a text to the model and maps the most likely label onto the
+	// app's categories (Positif, Netral, Negatif).
+	Classify(text string) (kategori string, score float64, err error)
+}
+
+// inferenceService implements the InferenceService interface using net/http.
+// Like the mail settings, the HuggingFace settings are read from environment variables
+// when a request is made.
+type inferenceService struct {
+	client *http.Client
+}
+
+// NewInferenceService creates and returns a new InferenceService implementation.
+//
+// Returns:
+//   - InferenceService: A new instance of the inferenceService implementation
+func NewInferenceService() InferenceService {
+	return &inferenceService{
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// inferenceLabel is one label with its probability in a HuggingFace text-classification response.
+type inferenceLabel struct {
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// IsConfigured reports whether a model and an API token are available.
+// HF_API_TOKEN must be set, together with HF_MODEL or HF_API_URL.
+//
+// Returns:
+//   - bool: true if comments can be classified remotely, false otherwise
+func (i *inferenceService) IsConfigured() bool {
+	return helper.GetEnv("HF_API_TOKEN", "") != "" && i.endpoint() != ""
+}
+
+// Model returns the name of the configured model, or the endpoint when only
+// HF_API_URL is set.
+//
+// Returns:
+//   - string: The model name or endpoint
+func (i *inferenceService) Model() string {
+	model := helper.GetEnv("HF_MODEL", "")
+	if model == "" {
+		return i.endpoint()
+	}
+
+	return model
+}
+
+// Classify sends a text to the configured HuggingFace model.
+//
+// The request is posted to HF_API_URL, or to the public Inference API endpoint of
+// HF_MODEL when no URL is set, with HF_API_TOKEN as bearer token. The label with the
+// highest score is mapped onto a category with the comma-separated HF_LABEL_MAP
+// variable (e.g. "positive:Positif,neutral:Netral,negative:Negatif"). Labels are
+// matched case-insensitively.
+//
+// Parameters:
+//   - text: The comment text to classify
+//
+// Returns:
+//   - kategori: The mapped category of the most likely label
+//   - score: The probability the model gave to that label
+//   - err: An error if the API is not configured, the request fails or the label is not mapped
+func (i *inferenceService) Classify(text string) (string, float64, error) {
+	if !i.IsConfigured() {
+		return "", 0, fmt.Errorf("HuggingFace belum dikonfigurasi (HF_API_TOKEN, HF_MODEL)")
+	}
+
+	body, err := json.Marshal(map[string]string{"inputs": text})
+	if err != nil {
+		return "", 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, i.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+helper.GetEnv("HF_API_TOKEN", ""))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("gagal menghubungi HuggingFace: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+
+		return "", 0, fmt.Errorf("HuggingFace mengembalikan %s: %s", resp.Status, apiErr.Error)
+	}
+
+	// Text-classification models answer [[{label, score}, ...]]; some endpoints drop the outer list
+	var raw json.RawMessage
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var labels []inferenceLabel
+	var nested [][]inferenceLabel
+	if json.Unmarshal(raw, &nested) == nil && len(nested) > 0 {
+		labels = nested[0]
+	} else if err := json.Unmarshal(raw, &labels); err != nil {
+		return "", 0, fmt.Errorf("respons HuggingFace tidak dikenali: %v", err)
+	}
+
+	if len(labels) == 0 {
+		return "", 0, fmt.Errorf("HuggingFace tidak mengembalikan label")
+	}
+
+	best := labels[0]
+	for _, label := range labels[1:] {
+		if label.Score > best.Score {
+			best = label
+		}
+	}
+
+	kategori, ok := labelMap()[strings.ToLower(best.Label)]
+	if !ok {
+		return "", 0, fmt.Errorf("label %q tidak ada di HF_LABEL_MAP", best.Label)
+	}
+
+	return kategori, best.Score, nil
+}
+
+// endpoint returns the URL of the configured model.
+//
+// Returns:
+//   - string: HF_API_URL if set, the Inference API URL of HF_MODEL otherwise,
+//     or an empty string when neither is set
+func (i *inferenceService) endpoint() string {
+	url := helper.GetEnv("HF_API_URL", "")
+	if url != "" {
+		return url
+	}
+
+	model := helper.GetEnv("HF_MODEL", "")
+	if model == "" {
+		return ""
+	}
+
+	return "https://api-inference.huggingface.co/models/" + model
+}
+
+// labelMap parses the comma-separated HF_LABEL_MAP variable.
+// Entries without a valid category are ignored.
+//
+// Returns:
+//   - map[string]string: The lowercased model labels mapped onto the app's categories
+func labelMap() map[string]string {
+	mapping := map[string]string{}
+
+	for _, entry := range strings.Split(helper.GetEnv("HF_LABEL_MAP", "positive:Positif,neutral:Netral,negative:Negatif"), ",") {
+		label, kategori, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+
+		kategori = NormalizeKategori(kategori)
+		if kategori == "" {
+			continue
+		}
+
+		mapping[strings.ToLower(strings.TrimSpace(label))] = kategori
+	}
+
+	return mapping
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"tugas-besar/lib/helper"
+)
+
+// MailService defines the interface for sending e-mail through SMTP.
+type MailService interface {
+	// IsConfigured reports whether the SMTP settings and recipients are available.
+	IsConfigured() bool
+
+	// Send delivers a plain-text message to all configured recipients.
+	Send(subject, body string) error
+}
+
+// mailService implements the MailService interface using net/smtp.
+// The SMTP settings are read from environment variables when a message is sent,
+// so changes to the .env file only require a restart.
+type mailService struct {
+}
+
+// NewMailService creates and returns a new MailService implementation.
+//
+// Returns:
+//   - MailService: A new instance of the mailService implementation
+func NewMailService() MailService {
+	return &mailService{}
+}
+
+// IsConfigured reports whether the SMTP settings and recipients are available.
+// SMTP_HOST, SMTP_FROM and REPORT_RECIPIENTS must all be set.
+//
+// Returns:
+//   - bool: true if e-mail can be sent, false otherwise
+func (m *mailService) IsConfigured() bool {
+	return helper.GetEnv("SMTP_HOST", "") != "" &&
+		helper.GetEnv("SMTP_FROM", "") != "" &&
+		len(recipients()) > 0
+}
+
+// Send delivers a plain-text message to all configured recipients.
+//
+// It connects to SMTP_HOST:SMTP_PORT (port 587 by default) and authenticates with
+// SMTP_USER/SMTP_PASS when a user is configured. The recipients are taken from the
+// comma-separated REPORT_RECIPIENTS variable.
+//
+// Parameters:
+//   - subject: The subject line of the message
+//   - body: The plain-text body of the message
+//
+// Returns:
+//   - error: An error if SMTP is not configured or the message cannot be delivered
+func (m *mailService) Send(subject, body string) error {
+	if !m.IsConfigured() {
+		return fmt.Errorf("SMTP belum dikonfigurasi (SMTP_HOST, SMTP_FROM, REPORT_RECIPIENTS)")
+	}
+
+	host := helper.GetEnv("SMTP_HOST", "")
+	port := helper.GetEnv("SMTP_PORT", "587")
+	user := helper.GetEnv("SMTP_USER", "")
+	from := helper.GetEnv("SMTP_FROM", "")
+	to := recipients()
+
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, helper.GetEnv("SMTP_PASS", ""), host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	err := smtp.SendMail(host+":"+port, auth, from, to, []byte(msg.String()))
+	if err != nil {
+		return fmt.Errorf("gagal mengirim email: %v", err)
+	}
+
+	return nil
+}
+
+// recipients parses the comma-separated REPORT_RECIPIENTS variable.
+//
+// Returns:
+//   - []string: The trimmed, non-empty e-mail addresses
+func recipients() []string {
+	var list []string
+
+	for _, address := range strings.Split(helper.GetEnv("REPORT_RECIPIENTS", ""), ",") {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			list = append(list, address)
+		}
+	}
+
+	return list
+}
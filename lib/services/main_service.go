@@ -1,19 +1,49 @@
 package services
 
 import (
+	"embed"
+	"fmt"
+	"os"
+	"sync"
+	"text/template"
+
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"tugas-besar/lib/helper"
 )
 
+// screens holds the text of the help and about screens.
+//
+//go:embed templates/*.tmpl
+var screens embed.FS
+
+// member is a group member listed on the about screen.
+type member struct {
+	Nim  string
+	Name string
+	Role string
+}
+
 // MainService defines the interface for the main operations of the application.
 // It abstracts the core business logic to allow for better testing and modularity.
 type MainService interface {
 	MainMenu(chose *string) error
+
+	// Bantuan displays the usage instructions and keyboard hints.
+	Bantuan() error
+
+	// Tentang displays the group member credits and the feature overview.
+	Tentang() error
+
+	// SetNotice sets a one-line notice shown in the main menu header, such as an
+	// available update. It is safe to call from a background goroutine.
+	SetNotice(notice string)
 }
 
 // mainServiceImpl implements the MainService interface with concrete business logic.
 type mainServiceImpl struct {
+	mu     sync.Mutex
+	notice string
 }
 
 // NewMainService creates and returns a new instance of MainService.
@@ -28,7 +58,7 @@ func NewMainService() MainService {
 
 // MainMenu displays the main application menu and captures the user's choice.
 // It first clears the screen and displays a welcome banner before showing
-// an interactive menu with options for Login, Register, Admin, and Exit.
+// an interactive menu with options for Login, Register, Lihat sebagai Tamu, Cari, Profil, Admin, Bantuan, Tentang, and Exit.
 //
 // Parameters:
 //   - chose: A pointer to a string where the selected menu option will be stored
@@ -37,7 +67,9 @@ func NewMainService() MainService {
 //   - error: nil on successful selection, or an error if the prompt operation fails
 //
 // The function uses color formatting and promptui for an enhanced user interface.
-func (*mainServiceImpl) MainMenu(chose *string) error {
+// Reaching the main menu ends a jump back requested with Esc or :menu, see
+// helper.RequestMainMenu.
+func (m *mainServiceImpl) MainMenu(chose *string) error {
 	helper.ClearScreen()
 	color.Yellow("=========================================")
 	color.Yellow("=  Selamat datang di Tugas Besar Alpro  =")
@@ -45,9 +77,20 @@ func (*mainServiceImpl) MainMenu(chose *string) error {
 	color.Yellow("=            Kelompok 2                 =")
 	color.Yellow("=========================================")
 
+	m.mu.Lock()
+	notice := m.notice
+	m.mu.Unlock()
+
+	if notice != "" {
+		color.Green(notice)
+	}
+
+	labels, keys := helper.MenuItems("main", []string{"Login", "Register", "Lihat sebagai Tamu", "Cari", "Profil", "Admin", "Bantuan", "Tentang", "Exit"})
+
 	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Login", "Register", "Admin", "Exit"},
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("main", keys),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -56,13 +99,105 @@ func (*mainServiceImpl) MainMenu(chose *string) error {
 		},
 	}
 
-	_, result, err := prompt.Run()
+	// The main menu is reached, a jump back to it is done
+	helper.ClearMainMenuRequest()
+
+	index, _, err := prompt.Run()
+
+	// Esc on the main menu itself shows the main menu again
+	if helper.MainMenuRequested() {
+		helper.ClearMainMenuRequest()
+		*chose = ""
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*chose = keys[index]
+	helper.RememberMenu("main", keys[index])
+
+	return nil
+}
+
+// SetNotice sets a one-line notice shown in green below the main menu banner.
+// An empty notice removes it.
+//
+// Parameters:
+//   - notice: The text of the notice
+func (m *mainServiceImpl) SetNotice(notice string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.notice = notice
+}
+
+// Bantuan displays the help screen rendered from templates/bantuan.tmpl and waits
+// for user input (via helper.Pause) before returning.
+//
+// Returns:
+//   - error: An error if the template cannot be rendered
+func (*mainServiceImpl) Bantuan() error {
+	helper.Header("MENU > BANTUAN", "BANTUAN")
+
+	return renderScreen("bantuan.tmpl", struct {
+		JournalFile string
+		MenuFile    string
+	}{
+		JournalFile: helper.GetEnv("JOURNAL_FILE", "journal.jsonl"),
+		MenuFile:    helper.GetEnv("MENU_FILE", "menu.json"),
+	})
+}
+
+// Tentang displays the about screen rendered from templates/tentang.tmpl and waits
+// for user input (via helper.Pause) before returning.
+//
+// Returns:
+//   - error: An error if the template cannot be rendered
+func (*mainServiceImpl) Tentang() error {
+	helper.Header("MENU > TENTANG", "TENTANG")
+
+	return renderScreen("tentang.tmpl", struct {
+		Version string
+		Members []member
+	}{
+		Version: helper.BuildInfo(),
+		Members: []member{
+			{Nim: "103042400034", Name: "Aver Varian Hernawan", Role: "Leader"},
+			{Nim: "103042400068", Name: "Kallistus Wahyu Sandivan", Role: "Member"},
+		},
+	})
+}
+
+// renderScreen renders an embedded screen template to stdout and waits for user input.
+// The templates can color text with the yellow, cyan and green functions.
+//
+// Parameters:
+//   - name: The file name of the template in the templates directory
+//   - data: The data the template is executed with
+//
+// Returns:
+//   - error: An error if the template cannot be parsed or executed
+func renderScreen(name string, data any) error {
+	funcs := template.FuncMap{
+		"yellow": color.YellowString,
+		"cyan":   color.CyanString,
+		"green":  color.GreenString,
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).ParseFS(screens, "templates/"+name)
+	if err != nil {
+		return fmt.Errorf("gagal memuat layar %s: %v", name, err)
+	}
 
+	err = tmpl.Execute(os.Stdout, data)
 	if err != nil {
 		return err
 	}
 
-	*chose = result
+	fmt.Println()
+	helper.Pause()
 
 	return nil
 }
@@ -0,0 +1,699 @@
+package services
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// MaintenanceService defines the interface for the admin maintenance tools.
+// These tools find and repair data that has become inconsistent over time.
+type MaintenanceService interface {
+	// MaintenanceMenu displays the maintenance menu and captures the admin's selection.
+	MaintenanceMenu(result *string) error
+
+	// OrphanComments lists the comments whose owner no longer exists and lets the admin
+	// export them, delete them or reassign them to a placeholder account.
+	OrphanComments() error
+
+	// Diagnostik checks the referential integrity of the store, reports the inconsistencies
+	// it finds and optionally repairs them.
+	Diagnostik() error
+
+	// LaporanError collects the recent journal entries, the redacted configuration and
+	// diagnostics into one zip file that can be attached to a bug report.
+	LaporanError() error
+
+	// Kompaksi replaces the journal by a snapshot of the stored records and reports
+	// the journal entries and bytes that were reclaimed.
+	Kompaksi() error
+}
+
+// maintenanceService implements the MaintenanceService interface.
+type maintenanceService struct {
+	userService UserService
+	commentRepo repository.CommentRepository
+	integrity   repository.IntegrityRepository
+	tx          repository.TransactionRepository
+	journal     repository.JournalRepository
+	audit       AuditService
+}
+
+// NewMaintenanceService creates and returns a new MaintenanceService implementation.
+//
+// Parameters:
+//   - userService: The UserService implementation used to look up and create users
+//   - commentRepo: The CommentRepository implementation used to read and repair comments
+//   - integrity: The IntegrityRepository implementation used to check and repair the store
+//   - tx: The TransactionRepository implementation that makes each cleanup all-or-nothing
+//   - journal: The JournalRepository implementation whose recent entries go into error reports
+//   - audit: The AuditService implementation the cleanups of orphaned comments are recorded with
+//
+// Returns:
+//   - MaintenanceService: A new instance of the maintenanceService implementation
+func NewMaintenanceService(userService UserService, commentRepo repository.CommentRepository, integrity repository.IntegrityRepository, tx repository.TransactionRepository, journal repository.JournalRepository, audit AuditService) MaintenanceService {
+	return &maintenanceService{
+		userService: userService,
+		commentRepo: commentRepo,
+		integrity:   integrity,
+		tx:          tx,
+		journal:     journal,
+		audit:       audit,
+	}
+}
+
+// MaintenanceMenu displays the maintenance menu and captures the admin's selection.
+//
+// Parameters:
+//   - result: Pointer to store the selected menu option as a string
+//
+// Returns:
+//   - error: Any error encountered during menu display or selection process
+func (m *maintenanceService) MaintenanceMenu(result *string) error {
+	helper.Header("MENU > ADMIN > MAINTENANCE", "MAINTENANCE")
+
+	labels, keys := helper.MenuItems("maintenance", []string{"Diagnostik", "Komentar Yatim", "Buat Laporan Error", "Kompaksi Penyimpanan", "Exit"})
+
+	prompt := promptui.Select{
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("maintenance", keys),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, result) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*result = keys[index]
+	helper.RememberMenu("maintenance", keys[index])
+
+	return nil
+}
+
+// OrphanComments lists the comments whose owner no longer exists.
+//
+// A comment is orphaned when its UserId does not match any user. Comments with
+// UserId 0 belong to the admin and are never orphaned.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and the table of orphaned comments
+//  2. Asks the admin what to do with them:
+//     - "Export CSV": Writes the orphaned comments to a CSV file and shows the menu again
+//     - "Hapus": Deletes every orphaned comment after confirmation
+//     - "Pindahkan ke Placeholder": Reassigns the comments to a placeholder account,
+//     which is created with a random password if it does not exist yet
+//  3. Records every deleted comment, the created placeholder and every reassignment in
+//     the audit log and displays success message
+//
+// Returns:
+//   - nil: When the cleanup succeeds or there is nothing to clean up
+//   - error: Repository errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (m *maintenanceService) OrphanComments() error {
+	helper.Header("MENU > ADMIN > MAINTENANCE > KOMENTAR YATIM", "KOMENTAR YATIM")
+
+	orphans, err := m.findOrphans()
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		color.Green("Tidak ada komentar yatim")
+		helper.Pause()
+		return nil
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "ID", "User ID", "Komentar", "Kategori"})
+	for i, comment := range orphans {
+		t.AppendRow(table.Row{i + 1, comment.Id, comment.UserId, comment.Komentar, comment.Kategori})
+	}
+	t.Render()
+
+	prompt := promptui.Select{
+		Label: "Pilih Tindakan",
+		Items: []string{"Export CSV", "Hapus", "Pindahkan ke Placeholder", "Exit"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, action, err := prompt.Run()
+	if err != nil || action == "Exit" {
+		return helper.ErrBack
+	}
+
+	switch action {
+	case "Export CSV":
+		pathPrompt := promptui.Prompt{
+			Label:   "Nama file",
+			Default: "komentar_yatim.csv",
+		}
+
+		path, err := pathPrompt.Run()
+		if err != nil {
+			return helper.ErrContinue
+		}
+
+		rows := make([][]string, len(orphans))
+		for i, comment := range orphans {
+			rows[i] = []string{strconv.Itoa(comment.Id), strconv.Itoa(comment.UserId), comment.Komentar, comment.Kategori, comment.Sumber}
+		}
+
+		err = helper.WriteCSV(path, []string{"id", "user_id", "komentar", "kategori", "sumber"}, rows)
+		if err != nil {
+			return err
+		}
+
+		color.Green("%d komentar yatim diexport ke %s", len(orphans), path)
+		helper.Pause()
+		return helper.ErrContinue
+
+	case "Hapus":
+		if !helper.ConfirmDelete(fmt.Sprintf("%d komentar yatim", len(orphans))) {
+			return helper.ErrContinue
+		}
+
+		err = m.tx.Run(func() error {
+			for _, comment := range orphans {
+				err := m.commentRepo.DeleteComment(comment.Id)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, comment := range orphans {
+			m.audit.Record("delete_comment", comment.Id, comment, nil)
+		}
+
+		color.Green("%d komentar yatim dihapus", len(orphans))
+
+	case "Pindahkan ke Placeholder":
+		usernamePrompt := promptui.Prompt{
+			Label:   "Username placeholder",
+			Default: "deleted-user",
+		}
+
+		username, err := usernamePrompt.Run()
+		if err != nil || username == "" {
+			return helper.ErrContinue
+		}
+
+		var placeholder model.User
+		created := false
+		moved := 0
+		reassigned := make(map[int]int)
+		err = m.tx.Run(func() error {
+			var err error
+			created, err = m.placeholderUser(username, &placeholder)
+			if err != nil {
+				return err
+			}
+
+			for _, comment := range orphans {
+				if _, ok := reassigned[comment.UserId]; ok {
+					continue
+				}
+
+				count, err := m.commentRepo.ReassignComments(comment.UserId, placeholder.Id)
+				if err != nil {
+					return err
+				}
+				reassigned[comment.UserId] = count
+				moved += count
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if created {
+			m.audit.Record("create_user", placeholder.Id, nil, placeholder)
+		}
+		for userId, count := range reassigned {
+			m.audit.Record("reassign_user", userId, nil, map[string]any{"moved_to": placeholder.Id, "comments_moved": count})
+		}
+
+		color.Green("%d komentar yatim dipindahkan ke %s", moved, placeholder.Username)
+	}
+
+	helper.Pause()
+	return nil
+}
+
+// Diagnostik checks the referential integrity of the store.
+//
+// The function workflow:
+//  1. Clears the screen and displays the header
+//  2. Runs every integrity check and renders a table with the status and the
+//     inconsistencies found by each check
+//  3. If any check has repairable issues, asks the admin whether to repair them
+//     and reports how many issues were repaired
+//  4. Points the admin to the orphaned comment tool when comments reference missing users
+//
+// Returns:
+//   - nil: When the diagnostics finish
+//   - error: helper.ErrBack when the admin cancels the repair prompt
+func (m *maintenanceService) Diagnostik() error {
+	helper.Header("MENU > ADMIN > MAINTENANCE > DIAGNOSTIK", "DIAGNOSTIK")
+
+	checks := m.integrity.Check()
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Pemeriksaan", "Status", "Detail"})
+
+	repairable := 0
+	orphaned := false
+	for _, check := range checks {
+		status := "OK"
+		if len(check.Issues) > 0 {
+			status = fmt.Sprintf("%d masalah", len(check.Issues))
+		}
+
+		if check.Repairable {
+			repairable += len(check.Issues)
+		} else if len(check.Issues) > 0 {
+			orphaned = true
+		}
+
+		t.AppendRow(table.Row{check.Name, status, strings.Join(check.Issues, "\n")})
+	}
+
+	t.Render()
+
+	if orphaned {
+		color.Cyan("Gunakan Maintenance > Komentar Yatim untuk menangani komentar tanpa pemilik")
+	}
+
+	if repairable == 0 {
+		color.Green("Tidak ada masalah yang perlu diperbaiki")
+		helper.Pause()
+		return nil
+	}
+
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Perbaiki %d masalah secara otomatis", repairable),
+		IsConfirm: true,
+	}
+
+	_, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	repaired := m.integrity.Repair()
+
+	color.Green("%d masalah diperbaiki", repaired)
+	helper.Pause()
+	return nil
+}
+
+// Kompaksi compacts the journal, which otherwise grows with every edit, delete and login.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and asks for confirmation
+//  2. Copies the journal into BACKUP_DIR (default "backup"), so the history stays available
+//  3. Replaces the journal by a snapshot with one entry per stored record, which drops the
+//     revisions and bookmarks of deleted comments and reconciles the ID counters, and
+//     reloads the store from it
+//  4. Renders a table with the journal entries and bytes before and after and the
+//     amount that was reclaimed
+//
+// Returns:
+//   - nil: When the compaction finishes
+//   - error: helper.ErrBack when the admin cancels the confirmation prompt, or an error if
+//     the backup or the snapshot cannot be written
+func (m *maintenanceService) Kompaksi() error {
+	helper.Header("MENU > ADMIN > MAINTENANCE > KOMPAKSI", "KOMPAKSI PENYIMPANAN")
+
+	prompt := promptui.Prompt{
+		Label:     "Padatkan journal sekarang",
+		IsConfirm: true,
+	}
+
+	_, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	backup, err := m.journal.Backup(helper.GetEnv("BACKUP_DIR", "backup"))
+	if err != nil {
+		return err
+	}
+	if backup != "" {
+		color.Cyan("Journal lama disimpan di %s", backup)
+	}
+
+	result, err := m.journal.Compact()
+	if err != nil {
+		return err
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Journal", "Sebelum", "Sesudah", "Dibebaskan"})
+	t.AppendRow(table.Row{"Entri", result.EntriesBefore, result.EntriesAfter, result.EntriesBefore - result.EntriesAfter})
+	t.AppendRow(table.Row{"Byte", result.BytesBefore, result.BytesAfter, result.BytesBefore - result.BytesAfter})
+	t.Render()
+
+	color.Green("Kompaksi selesai, %d entri dan %d byte dibebaskan", result.EntriesBefore-result.EntriesAfter, result.BytesBefore-result.BytesAfter)
+	helper.Pause()
+	return nil
+}
+
+// LaporanError builds an error report bundle for bug reports.
+//
+// The zip file laporan-error-YYYYMMDD-HHMMSS.zip contains:
+//   - diagnostik.txt: The build, the platform, the record counts, the integrity checks
+//     and the crash files found in CRASH_DIR
+//   - journal.jsonl: The last 100 journal entries with every password removed
+//   - debug.log: The last 200 lines of the debug log (LOG_FILE), when it exists
+//   - config.env: The .env file with the values of secrets (keys containing PASS,
+//     TOKEN, SECRET or KEY) replaced by "***"
+//
+// Returns:
+//   - error: helper.ErrBack when the prompt is cancelled, or any error encountered while
+//     writing the bundle
+func (m *maintenanceService) LaporanError() error {
+	helper.Header("MENU > ADMIN > MAINTENANCE > BUAT LAPORAN ERROR", "BUAT LAPORAN ERROR")
+
+	pathPrompt := promptui.Prompt{
+		Label:   "Nama file",
+		Default: fmt.Sprintf("laporan-error-%s.zip", time.Now().Format("20060102-150405")),
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	err = m.writeBundleEntry(archive, "diagnostik.txt", m.diagnosticReport)
+	if err != nil {
+		return err
+	}
+
+	err = m.writeBundleEntry(archive, "journal.jsonl", m.recentJournal)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(helper.LogFile()); err == nil {
+		err = m.writeBundleEntry(archive, "debug.log", recentLog)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.writeBundleEntry(archive, "config.env", redactedConfig)
+	if err != nil {
+		return err
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+
+	color.Green("Laporan error ditulis ke %s", path)
+	color.Cyan("Periksa isinya sebelum dibagikan, lalu lampirkan pada laporan bug.")
+	helper.Pause()
+
+	return nil
+}
+
+// writeBundleEntry adds a file to the error report bundle.
+//
+// Parameters:
+//   - archive: The zip archive being written
+//   - name: The name of the file inside the archive
+//   - write: The function that writes the content of the file
+//
+// Returns:
+//   - error: An error if the entry cannot be created or written
+func (m *maintenanceService) writeBundleEntry(archive *zip.Writer, name string, write func(io.Writer) error) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	return write(entry)
+}
+
+// diagnosticReport writes the build, platform, record counts, integrity checks and
+// crash files as plain text.
+//
+// Parameters:
+//   - w: The writer to write the report to
+//
+// Returns:
+//   - error: An error if the report cannot be written
+func (m *maintenanceService) diagnosticReport(w io.Writer) error {
+	fmt.Fprintf(w, "Dibuat:    %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(w, "Versi:     %s\n", helper.BuildInfo())
+	fmt.Fprintf(w, "Platform:  %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(w, "Journal:   %s\n", m.journal.Path())
+	fmt.Fprintf(w, "User:      %d\n", global.UserCount)
+	fmt.Fprintf(w, "Komentar:  %d\n", global.CommentCount)
+	fmt.Fprintf(w, "Revisi:    %d\n", global.RevisionCount)
+	fmt.Fprintf(w, "Proyek:    %d (aktif: %d)\n", global.ProjectCount, global.ActiveProjectId)
+	fmt.Fprintf(w, "Terjadwal: %d\n", global.ScheduledCount)
+	fmt.Fprintf(w, "Template:  %d\n", global.TemplateCount)
+
+	fmt.Fprintln(w, "\nPemeriksaan integritas:")
+	for _, check := range m.integrity.Check() {
+		fmt.Fprintf(w, "  %s: %d masalah\n", check.Name, len(check.Issues))
+		for _, issue := range check.Issues {
+			fmt.Fprintf(w, "    - %s\n", issue)
+		}
+	}
+
+	crashes, _ := filepath.Glob(filepath.Join(helper.GetEnv("CRASH_DIR", "."), "crash-*.json"))
+	fmt.Fprintf(w, "\nFile crash: %d\n", len(crashes))
+	for _, crash := range crashes {
+		fmt.Fprintf(w, "  %s\n", crash)
+	}
+
+	return nil
+}
+
+// recentJournal writes the last 100 journal entries as JSON Lines with every password removed.
+//
+// Parameters:
+//   - w: The writer to write the entries to
+//
+// Returns:
+//   - error: An error if the journal cannot be read or the entries cannot be written
+func (m *maintenanceService) recentJournal(w io.Writer) error {
+	entries, err := m.journal.GetAllEntries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 100 {
+		entries = entries[len(entries)-100:]
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if entry.User != nil {
+			user := *entry.User
+			user.Password = ""
+			entry.User = &user
+		}
+
+		err = encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recentLog writes the last 200 lines of the debug log file.
+//
+// Parameters:
+//   - w: The writer to write the lines to
+//
+// Returns:
+//   - error: An error if the log file cannot be read or the lines cannot be written
+func recentLog(w io.Writer) error {
+	file, err := os.Open(helper.LogFile())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	const limit = 200
+	lines := make([]string, 0, limit)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == limit {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		_, err = fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redactedConfig writes the .env file with the values of secrets replaced by "***".
+// A missing .env file is noted instead of failing the bundle.
+//
+// Parameters:
+//   - w: The writer to write the configuration to
+//
+// Returns:
+//   - error: An error if the configuration cannot be written
+func redactedConfig(w io.Writer) error {
+	file, err := os.Open(".env")
+	if err != nil {
+		_, err = fmt.Fprintln(w, "# .env tidak ditemukan")
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		key, value, ok := strings.Cut(line, "=")
+		upper := strings.ToUpper(key)
+		secret := strings.Contains(upper, "PASS") || strings.Contains(upper, "TOKEN") ||
+			strings.Contains(upper, "SECRET") || strings.Contains(upper, "KEY")
+		if ok && secret && strings.TrimSpace(value) != "" {
+			line = key + "=***"
+		}
+
+		_, err = fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// findOrphans collects the comments whose UserId does not match any user.
+// Comments created by the admin (UserId 0) are not orphaned.
+//
+// Returns:
+//   - []model.Comment: The orphaned comments in storage order
+//   - error: Any error encountered while reading users or comments
+func (m *maintenanceService) findOrphans() ([]model.Comment, error) {
+	var users [255]model.User
+	err := m.userService.GetAllUsers(&users)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments [255]model.Comment
+	err = m.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := map[int]bool{0: true}
+	for i := 0; i < global.UserCount; i++ {
+		userIds[users[i].Id] = true
+	}
+
+	var orphans []model.Comment
+	for i := 0; i < global.CommentCount; i++ {
+		if !userIds[comments[i].UserId] {
+			orphans = append(orphans, comments[i])
+		}
+	}
+
+	return orphans, nil
+}
+
+// placeholderUser finds the placeholder account, creating it if it does not exist.
+// A new placeholder gets a random password so nobody can log in with it.
+//
+// Parameters:
+//   - username: The username of the placeholder account
+//   - user: Pointer to store the placeholder account
+//
+// Returns:
+//   - bool: True if the account was created
+//   - error: An error if the account cannot be created, nil otherwise
+func (m *maintenanceService) placeholderUser(username string, user *model.User) (bool, error) {
+	if m.userService.IsUserExists(username, -1) {
+		return false, m.userService.FindUserByUsername(username, user)
+	}
+
+	secret := make([]byte, 16)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return false, err
+	}
+
+	err = m.userService.CreateUser(&model.User{Username: username, Password: hex.EncodeToString(secret)})
+	if err != nil {
+		return false, err
+	}
+
+	return true, m.userService.FindUserByUsername(username, user)
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"tugas-besar/lib/helper"
+)
+
+// Permission names an action in the admin interface that can be granted to a role.
+type Permission string
+
+const (
+	// PermissionManageUsers allows viewing, editing, merging and deleting users,
+	// as well as the maintenance tools and the journal.
+	PermissionManageUsers Permission = "manage_users"
+
+	// PermissionManageComments allows adding, editing, deleting and importing comments.
+	PermissionManageComments Permission = "manage_comments"
+
+	// PermissionViewStats allows viewing the charts and the reports.
+	PermissionViewStats Permission = "view_stats"
+
+	// PermissionExportData allows writing reports and charts to files and sending them by e-mail.
+	PermissionExportData Permission = "export_data"
+
+	// PermissionManageRoles allows giving users an admin role and taking it away.
+	PermissionManageRoles Permission = "manage_roles"
+)
+
+const (
+	// RoleAdmin is the role of the users that may do everything in the admin menu.
+	RoleAdmin = "admin"
+
+	// RoleModerator is the role of the users limited to the MODERATOR_PERMISSIONS.
+	RoleModerator = "moderator"
+)
+
+// IsAdminRole reports whether a role opens the admin menu.
+//
+// Parameters:
+//   - role: The Role of a user
+//
+// Returns:
+//   - bool: true for RoleAdmin and RoleModerator, false for a regular user
+func IsAdminRole(role string) bool {
+	return role == RoleAdmin || role == RoleModerator
+}
+
+// PermissionService defines the interface for the admin permissions matrix.
+// It remembers the role of the current admin session and answers whether that
+// role may perform an action.
+type PermissionService interface {
+	// SetRole sets the role of the current admin session.
+	SetRole(role string)
+
+	// Role returns the role of the current admin session.
+	Role() string
+
+	// Check returns an error if the current role lacks the given permission, nil otherwise.
+	Check(permission Permission) error
+}
+
+// permissionService implements the PermissionService interface.
+type permissionService struct {
+	role   string
+	matrix map[string]map[Permission]bool
+}
+
+// NewPermissionService creates and returns a new PermissionService implementation.
+//
+// The admin role has every permission. The moderator role defaults to managing comments
+// and viewing stats; this can be changed by listing permission names separated by
+// commas in the MODERATOR_PERMISSIONS environment variable.
+//
+// Returns:
+//   - PermissionService: A new instance of the permissionService implementation
+func NewPermissionService() PermissionService {
+	moderator := make(map[Permission]bool)
+	for _, name := range strings.Split(helper.GetEnv("MODERATOR_PERMISSIONS", "manage_comments,view_stats"), ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			moderator[Permission(name)] = true
+		}
+	}
+
+	return &permissionService{
+		matrix: map[string]map[Permission]bool{
+			RoleAdmin: {
+				PermissionManageUsers:    true,
+				PermissionManageComments: true,
+				PermissionViewStats:      true,
+				PermissionExportData:     true,
+				PermissionManageRoles:    true,
+			},
+			RoleModerator: moderator,
+		},
+	}
+}
+
+// SetRole sets the role of the current admin session.
+//
+// Parameters:
+//   - role: RoleAdmin or RoleModerator; an empty string ends the session
+func (p *permissionService) SetRole(role string) {
+	p.role = role
+}
+
+// Role returns the role of the current admin session.
+//
+// Returns:
+//   - string: The current role, empty if nobody is logged in
+func (p *permissionService) Role() string {
+	return p.role
+}
+
+// Check looks up a permission in the matrix for the current role.
+//
+// Parameters:
+//   - permission: The permission required by the action
+//
+// Returns:
+//   - error: An error naming the role and the missing permission, nil if it is granted
+func (p *permissionService) Check(permission Permission) error {
+	if p.matrix[p.role][permission] {
+		return nil
+	}
+
+	return fmt.Errorf("akses ditolak: role %s tidak memiliki izin %s", p.role, permission)
+}
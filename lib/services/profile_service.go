@@ -0,0 +1,351 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/repository"
+)
+
+// DefaultProfile is the name of the profile stored in the journal file itself.
+const DefaultProfile = "default"
+
+// profileName matches the names allowed for a profile, which become part of a file name.
+var profileName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ProfileService defines the interface for the named data profiles.
+// Every profile is a separate dataset backed by its own journal file, e.g. the
+// "survey-A" profile of journal.jsonl is stored in journal-survey-A.jsonl.
+type ProfileService interface {
+	// ProfileMenu lists the profiles and lets the user switch to another or a new profile.
+	ProfileMenu() error
+
+	// Switch loads the dataset of a profile and makes it the active profile.
+	Switch(name string) error
+
+	// Recover lets the user restore a backup or accept the journal of the profile that
+	// Switch refused because it failed its checksum, and switches to it.
+	Recover(cause error) error
+}
+
+// profileService implements the ProfileService interface.
+type profileService struct {
+	base    string
+	active  string
+	refused string
+	journal repository.JournalRepository
+}
+
+// NewProfileService creates and returns a new ProfileService implementation.
+//
+// Parameters:
+//   - base: The journal file of the default profile; other profiles are stored next to it
+//   - journal: The JournalRepository implementation that is switched between profiles
+//
+// Returns:
+//   - ProfileService: A new instance of the profileService implementation
+func NewProfileService(base string, journal repository.JournalRepository) ProfileService {
+	return &profileService{
+		base:    base,
+		journal: journal,
+	}
+}
+
+// ProfileMenu displays the profiles and lets the user switch between them.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and a table of the profiles with their
+//     storage file, marking the active one
+//  2. Asks which profile to use; "Profil Baru" asks for the name of a new profile
+//  3. Switches to the chosen profile and displays a success message; a profile whose
+//     journal fails its checksum is handed to Recover
+//
+// Returns:
+//   - nil: When the profile was switched
+//   - error: helper.ErrBack when the user leaves, helper.ErrContinue when the name prompt is cancelled,
+//     or an error if the profile cannot be loaded
+func (p *profileService) ProfileMenu() error {
+	helper.Header("MENU > PROFIL", "PROFIL")
+
+	profiles, err := p.profiles()
+	if err != nil {
+		return err
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Profil", "File", "Aktif"})
+	for _, name := range profiles {
+		active := ""
+		if name == p.active {
+			active = "*"
+		}
+
+		t.AppendRow(table.Row{name, p.path(name), active})
+	}
+	t.Render()
+
+	prompt := promptui.Select{
+		Label: "Pilih Profil",
+		Items: append(profiles, "Profil Baru", "Exit"),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, name, err := prompt.Run()
+	if err != nil || name == "Exit" {
+		return helper.ErrBack
+	}
+
+	if name == "Profil Baru" {
+		namePrompt := promptui.Prompt{
+			Label: "Nama profil",
+			Validate: func(input string) error {
+				if !profileName.MatchString(input) {
+					return fmt.Errorf("nama hanya boleh berisi huruf, angka, - dan _")
+				}
+
+				return nil
+			},
+		}
+
+		name, err = namePrompt.Run()
+		if err != nil {
+			return helper.ErrContinue
+		}
+	}
+
+	err = p.Switch(name)
+	if errors.Is(err, repository.ErrChecksumMismatch) {
+		return p.Recover(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	color.Green("Profil aktif: %s", name)
+	helper.Pause()
+
+	return nil
+}
+
+// Switch loads the dataset of a profile and makes it the active profile.
+// The in-memory store is replaced by the data replayed from the profile's journal file;
+// a profile without a file starts empty. When the journal fails its checksum the active
+// profile and its data stay as they are and the profile is remembered for Recover.
+//
+// Parameters:
+//   - name: The name of the profile, DefaultProfile for the journal file itself
+//
+// Returns:
+//   - error: An error if the name is invalid or the profile's journal cannot be replayed
+func (p *profileService) Switch(name string) error {
+	if !profileName.MatchString(name) {
+		return fmt.Errorf("nama profil tidak valid: %q", name)
+	}
+
+	_, err := p.journal.Open(p.path(name))
+	if errors.Is(err, repository.ErrChecksumMismatch) {
+		p.refused = name
+	}
+	if err != nil {
+		return err
+	}
+
+	p.activate(name)
+
+	return nil
+}
+
+// activate marks a profile whose journal has been opened as the active profile.
+//
+// Parameters:
+//   - name: The name of the profile
+func (p *profileService) activate(name string) {
+	p.active = name
+	p.refused = ""
+	helper.SetActiveProfile(name)
+}
+
+// Recover is shown when the journal of the profile chosen in Switch does not match its
+// checksum manifest, see repository.ErrChecksumMismatch. The previously active profile
+// and its data are still loaded at that point.
+//
+// The function workflow:
+//  1. Displays the header, the cause and the latest backup of the journal in BACKUP_DIR
+//  2. Asks how to continue:
+//     - "Pulihkan Backup": Replaces the journal by the latest backup, keeping the damaged
+//     file next to it, and loads the backup (only offered when a backup exists)
+//     - "Muat Apa Adanya": Accepts the journal as it is, e.g. after repairing it by hand,
+//     updates its checksum and loads it
+//     - "Keluar": Returns helper.ErrBack without loading anything; at startup the
+//     application exits, from the profile menu the previous profile stays active
+//  3. Switches to the profile, displays the result and waits for the user to press Enter
+//
+// Parameters:
+//   - cause: The error returned by Switch
+//
+// Returns:
+//   - error: helper.ErrBack when the user chooses to exit, or an error if the backup cannot
+//     be restored or the journal cannot be loaded
+func (p *profileService) Recover(cause error) error {
+	helper.Header("", "PEMULIHAN DATA")
+
+	color.Red("Data profil %s tidak dimuat: %v", p.refused, cause)
+	color.Yellow("File journal terpotong atau diubah di luar aplikasi.")
+
+	backup, err := p.latestBackup()
+	if err != nil {
+		return err
+	}
+
+	items := []string{"Muat Apa Adanya", "Keluar"}
+	if backup != "" {
+		color.Cyan("Backup terbaru: %s", backup)
+		items = append([]string{"Pulihkan Backup"}, items...)
+	}
+
+	prompt := promptui.Select{
+		Label: "Pilih Tindakan",
+		Items: items,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, action, err := prompt.Run()
+	if err != nil || action == "Keluar" {
+		return helper.ErrBack
+	}
+
+	switch action {
+	case "Pulihkan Backup":
+		moved, err := p.journal.Restore(p.path(p.refused), backup)
+		if err != nil {
+			return err
+		}
+
+		if moved != "" {
+			color.Yellow("File yang rusak disimpan di %s", moved)
+		}
+	case "Muat Apa Adanya":
+		err = p.journal.Seal(p.path(p.refused))
+		if err != nil {
+			return err
+		}
+	}
+
+	replayed, err := p.journal.Open(p.path(p.refused))
+	if err != nil {
+		return err
+	}
+
+	p.activate(p.refused)
+
+	color.Green("%d command berhasil dimuat", replayed)
+	helper.Pause()
+
+	return nil
+}
+
+// latestBackup finds the newest backup of the refused profile's journal in BACKUP_DIR.
+// Backups are named after the journal with the time appended, see
+// repository.JournalRepository.Backup, so the newest one sorts last.
+//
+// Returns:
+//   - string: The path of the newest backup, empty if there is none
+//   - error: An error if the backup directory cannot be searched
+func (p *profileService) latestBackup() (string, error) {
+	journal := p.path(p.refused)
+	ext := filepath.Ext(journal)
+	stem := strings.TrimSuffix(filepath.Base(journal), ext)
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(stem) + `-\d{8}-\d{6}` + regexp.QuoteMeta(ext) + `$`)
+
+	matches, err := filepath.Glob(filepath.Join(helper.GetEnv("BACKUP_DIR", "backup"), stem+"-*"+ext))
+	if err != nil {
+		return "", err
+	}
+
+	var backups []string
+	for _, match := range matches {
+		if pattern.MatchString(filepath.Base(match)) {
+			backups = append(backups, match)
+		}
+	}
+
+	if len(backups) == 0 {
+		return "", nil
+	}
+
+	sort.Strings(backups)
+
+	return backups[len(backups)-1], nil
+}
+
+// path returns the journal file of a profile.
+//
+// Parameters:
+//   - name: The name of the profile
+//
+// Returns:
+//   - string: The base journal file for the default profile, otherwise the base file
+//     name with "-<name>" inserted before the extension
+func (p *profileService) path(name string) string {
+	if name == DefaultProfile {
+		return p.base
+	}
+
+	ext := filepath.Ext(p.base)
+
+	return strings.TrimSuffix(p.base, ext) + "-" + name + ext
+}
+
+// profiles lists the default profile followed by every profile that has a journal file,
+// sorted by name. The active profile is included even if it has no file yet.
+//
+// Returns:
+//   - []string: The profile names
+//   - error: An error if the directory of the journal cannot be searched
+func (p *profileService) profiles() ([]string, error) {
+	ext := filepath.Ext(p.base)
+	prefix := strings.TrimSuffix(p.base, ext) + "-"
+
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{DefaultProfile: true}
+	var names []string
+	if p.active != "" && p.active != DefaultProfile {
+		found[p.active] = true
+		names = append(names, p.active)
+	}
+
+	for _, match := range matches {
+		name := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext)
+		if profileName.MatchString(name) && !found[name] {
+			found[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...), nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// ProjectService defines the interface for the project grouping of comments.
+// The active project scopes every comment listing, statistic and export, and new
+// comments are added to it.
+type ProjectService interface {
+	// PilihProyek lets the user choose the active project. When canCreate is true a new
+	// project can be created from the same screen.
+	PilihProyek(canCreate bool) error
+
+	// Reset clears the active project, so every comment is included again.
+	Reset()
+}
+
+// projectService implements the ProjectService interface.
+type projectService struct {
+	projectRepo repository.ProjectRepository
+	commentRepo repository.CommentRepository
+}
+
+// NewProjectService creates and returns a new ProjectService implementation.
+//
+// Parameters:
+//   - projectRepo: The ProjectRepository implementation used to read and create projects
+//   - commentRepo: The CommentRepository implementation used to count the comments per project
+//
+// Returns:
+//   - ProjectService: A new instance of the projectService implementation
+func NewProjectService(projectRepo repository.ProjectRepository, commentRepo repository.CommentRepository) ProjectService {
+	return &projectService{
+		projectRepo: projectRepo,
+		commentRepo: commentRepo,
+	}
+}
+
+// PilihProyek displays the projects and sets the active project.
+//
+// The function workflow:
+//  1. Returns immediately when there are no projects and none can be created
+//  2. Clears the screen, displays the header and a table of the projects with their
+//     number of comments
+//  3. Asks which project to use; "Semua Proyek" clears the active project and
+//     "Proyek Baru" (only when canCreate is true) asks for the name of a new project
+//  4. Sets the active project and displays a success message
+//
+// Parameters:
+//   - canCreate: Whether the "Proyek Baru" option is offered
+//
+// Returns:
+//   - nil: When the active project was set or there is nothing to choose
+//   - error: helper.ErrBack when the selection is cancelled, helper.ErrContinue when the name prompt is
+//     cancelled, or an error if the project cannot be created
+func (p *projectService) PilihProyek(canCreate bool) error {
+	var projects [255]model.Project
+	count, err := p.projectRepo.GetAllProjects(&projects)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 && !canCreate {
+		return nil
+	}
+
+	helper.Header("MENU > PROYEK", "PROYEK")
+
+	// Count the comments of every project with the active project cleared
+	active := global.ActiveProjectId
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Id", "Proyek", "Komentar", "Aktif"})
+	items := make([]string, 0, count+2)
+	for i := 0; i < count; i++ {
+		global.ActiveProjectId = projects[i].Id
+		mark := ""
+		if projects[i].Id == active {
+			mark = "*"
+		}
+
+		t.AppendRow(table.Row{projects[i].Id, projects[i].Nama, p.commentRepo.CountComments(), mark})
+		items = append(items, projects[i].Nama)
+	}
+	global.ActiveProjectId = active
+	t.Render()
+
+	items = append(items, "Semua Proyek")
+	if canCreate {
+		items = append(items, "Proyek Baru")
+	}
+
+	prompt := promptui.Select{
+		Label: "Pilih Proyek",
+		Items: items,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	switch {
+	case index < count:
+		global.ActiveProjectId = projects[index].Id
+		color.Green("Proyek aktif: %s", projects[index].Nama)
+	case items[index] == "Semua Proyek":
+		p.Reset()
+		color.Green("Semua proyek ditampilkan")
+	default:
+		namePrompt := promptui.Prompt{
+			Label: "Nama proyek",
+			Validate: func(input string) error {
+				if strings.TrimSpace(input) == "" {
+					return fmt.Errorf("nama proyek tidak boleh kosong")
+				}
+
+				return nil
+			},
+		}
+
+		name, err := namePrompt.Run()
+		if err != nil {
+			return helper.ErrContinue
+		}
+
+		project := model.Project{Nama: strings.TrimSpace(name)}
+		err = p.projectRepo.Create(&project)
+		if err != nil {
+			return err
+		}
+
+		global.ActiveProjectId = project.Id
+		color.Green("Proyek %s dibuat dan diaktifkan", project.Nama)
+	}
+
+	helper.Pause()
+
+	return nil
+}
+
+// Reset clears the active project, so every comment is included again.
+func (p *projectService) Reset() {
+	global.ActiveProjectId = 0
+}
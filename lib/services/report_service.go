@@ -0,0 +1,2363 @@
+package services
+
+import (
+	"bufio"
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// ReportService defines the interface for the admin reports.
+// It provides read-only analytical views over the stored users and comments.
+type ReportService interface {
+	// LaporanMenu displays the report menu and captures the admin's selection.
+	LaporanMenu(result *string) error
+
+	// PerbandinganLabel displays a report comparing the manual category of every comment
+	// against the category predicted by the sentiment analyzer, together with the
+	// overall agreement percentage, and can show the resulting confusion matrix.
+	PerbandinganLabel() error
+
+	// KesepakatanLabel compares the first and second labels of the comments labeled twice
+	// and reports the simple agreement and Cohen's kappa.
+	KesepakatanLabel() error
+
+	// KlasifikasiHuggingFace classifies the comments with the configured HuggingFace model
+	// and compares its predictions with the manual categories.
+	KlasifikasiHuggingFace() error
+
+	// KlasifikasiFile classifies every line of a plain text file and writes the labeled
+	// lines to a CSV file, without importing them into the store.
+	KlasifikasiFile() error
+
+	// SampelAcak displays a random sample of N comments, optionally N per category, for a
+	// quick manual quality check of the labels.
+	SampelAcak() error
+
+	// UserKategori displays a cross-tabulation of users (rows) against sentiment
+	// categories (columns) with comment counts, and can export it to a CSV file.
+	UserKategori() error
+
+	// ExportGrafikPNG renders the sentiment distribution chart and the sentiment
+	// trend chart to PNG files so they can be embedded in documents and slides.
+	ExportGrafikPNG() error
+
+	// ExportDataset writes the comments as JSON Lines with the fields text, label, user
+	// and created_at so the dataset can be loaded directly into Python/ML notebooks.
+	ExportDataset() error
+
+	// ExportData prompts for a format and a file name and exports the users and comments.
+	ExportData() error
+
+	// ExportUsers prompts for a file name and exports the users with their comment statistics as CSV.
+	ExportUsers() error
+
+	// ExportUserStats writes the users with their comment count and category breakdown to
+	// a CSV file and returns the number of users written.
+	ExportUserStats(path string) (int, error)
+
+	// Statistics aggregates the comments for dashboards: the totals, the count per
+	// category, a per-day series of the last days and the most used words.
+	Statistics(days int, words int) (Statistics, error)
+
+	// ExportComments prompts for a file name and exports the comments as CSV.
+	ExportComments() error
+
+	// ExportCommentCSV writes one CSV row per comment with its author, category and
+	// timestamps and returns the number of rows written.
+	ExportCommentCSV(path string) (int, error)
+
+	// Export writes the users and comments as CSV, JSON or XLSX without any prompts.
+	// The admin notes are only included when notes is true. With anonymize the users are
+	// replaced by stable pseudonyms so the dataset can be shared publicly.
+	// It is shared by ExportData and the export subcommand.
+	Export(format, path string, notes bool, anonymize bool) ([]string, error)
+
+	// SplitDataset splits the labeled comments into a train and a test JSONL file with a
+	// configurable ratio, stratified by category so both files keep the label distribution.
+	SplitDataset() error
+
+	// EvaluasiKlasifikasi reports the accuracy, macro F1 and per-class support of a Naive
+	// Bayes classifier trained on the stored labels, computed with k-fold cross-validation.
+	EvaluasiKlasifikasi() error
+
+	// LaporanBulanan aggregates comments by month (counts, category split, top users,
+	// top words) and writes one formatted report file per month.
+	LaporanBulanan() error
+
+	// KirimLaporan e-mails the weekly or monthly summary report to the configured
+	// recipients immediately ("Send Now").
+	KirimLaporan() error
+
+	// StartSchedule starts the background delivery of the summary report by e-mail
+	// when SMTP and REPORT_SCHEDULE (weekly or monthly) are configured.
+	StartSchedule()
+}
+
+// reportService implements the ReportService interface.
+type reportService struct {
+	userService UserService
+	commentRepo repository.CommentRepository
+	sentiment   SentimentService
+	mail        MailService
+	inference   InferenceService
+}
+
+// NewReportService creates and returns a new ReportService implementation.
+//
+// Parameters:
+//   - userService: The UserService implementation used to look up users
+//   - commentRepo: The CommentRepository implementation used to read comments
+//   - sentiment: The SentimentService implementation used for automatic classification
+//   - mail: The MailService implementation used to deliver reports by e-mail
+//   - inference: The InferenceService implementation used to classify comments with HuggingFace
+//
+// Returns:
+//   - ReportService: A new instance of the reportService implementation
+func NewReportService(userService UserService, commentRepo repository.CommentRepository, sentiment SentimentService, mail MailService, inference InferenceService) ReportService {
+	return &reportService{
+		userService: userService,
+		commentRepo: commentRepo,
+		sentiment:   sentiment,
+		mail:        mail,
+		inference:   inference,
+	}
+}
+
+// LaporanMenu displays the report menu and captures the admin's selection.
+//
+// It clears the screen, displays a formatted header for the report section,
+// and presents a selection interface with the available reports.
+//
+// Parameters:
+//   - result: Pointer to store the selected menu option as a string
+//
+// Returns:
+//   - error: Any error encountered during menu display or selection process
+func (r *reportService) LaporanMenu(result *string) error {
+	helper.Header("MENU > ADMIN > LAPORAN", "LAPORAN")
+
+	labels, keys := helper.MenuItems("laporan", []string{"Perbandingan Label", "Klasifikasi HuggingFace", "Klasifikasi File", "Kesepakatan Label", "Sampel Acak", "User x Kategori", "Laporan Bulanan", "Kirim Laporan", "Export Grafik PNG", "Export Data", "Export Dataset JSONL", "Split Train/Test", "Evaluasi Klasifikasi", "Exit"})
+
+	prompt := promptui.Select{
+		Label:     "Pilih Laporan",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("laporan", keys),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, result) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*result = keys[index]
+	helper.RememberMenu("laporan", keys[index])
+
+	return nil
+}
+
+// KesepakatanLabel reports the reliability of the labels of the comments labeled twice.
+//
+// The function workflow:
+//  1. Collects the comments of the active project that have a second label
+//  2. Renders a matrix of the first label (rows) against the second label (columns)
+//  3. Displays the simple agreement, the agreement expected by chance and Cohen's kappa
+//     with its interpretation on the Landis and Koch scale
+//  4. Waits for user input (via helper.Pause) before returning
+//
+// Returns:
+//   - error: Any error encountered during data retrieval
+func (r *reportService) KesepakatanLabel() error {
+	var comments [255]model.Comment
+
+	err := r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LAPORAN > KESEPAKATAN LABEL", "KESEPAKATAN LABEL")
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+	var matrix [3][3]int
+	total := 0
+	for i := 0; i < global.CommentCount; i++ {
+		first := categoryIndex(categories, comments[i].Kategori)
+		second := categoryIndex(categories, comments[i].Kategori2)
+		if comments[i].Komentar == "" || first < 0 || second < 0 {
+			continue
+		}
+
+		matrix[first][second]++
+		total++
+	}
+
+	if total == 0 {
+		color.Cyan("Belum ada komentar dengan label kedua.")
+		helper.Pause()
+		return nil
+	}
+
+	t := helper.NewTable()
+	t.SetTitle("Label 1 (baris) x Label 2 (kolom)")
+	t.AppendHeader(table.Row{"", "Positif", "Netral", "Negatif", "Total"})
+	for i, kategori := range categories {
+		t.AppendRow(table.Row{kategori, matrix[i][0], matrix[i][1], matrix[i][2], matrix[i][0] + matrix[i][1] + matrix[i][2]})
+	}
+	t.AppendFooter(table.Row{"Total", matrix[0][0] + matrix[1][0] + matrix[2][0], matrix[0][1] + matrix[1][1] + matrix[2][1], matrix[0][2] + matrix[1][2] + matrix[2][2], total})
+	t.Render()
+
+	observed, expected, kappa := cohenKappa(matrix, total)
+
+	color.Cyan("Komentar berlabel ganda: %d", total)
+	color.Cyan("Kesepakatan sederhana:   %.1f%%", observed*100)
+	color.Cyan("Kesepakatan kebetulan:   %.1f%%", expected*100)
+	color.Cyan("Cohen's kappa:           %.3f (%s)", kappa, kappaLevel(kappa))
+
+	helper.Pause()
+
+	return nil
+}
+
+// categoryIndex returns the position of a category in a list of categories.
+//
+// Parameters:
+//   - categories: The categories in matrix order
+//   - kategori: The category to look up
+//
+// Returns:
+//   - int: The position of the category, -1 if it is not in the list
+func categoryIndex(categories []string, kategori string) int {
+	for i, category := range categories {
+		if category == kategori {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// cohenKappa computes the agreement between two labelers from their confusion matrix.
+//
+// Parameters:
+//   - matrix: The number of comments per first label (row) and second label (column)
+//   - total: The number of comments in the matrix
+//
+// Returns:
+//   - float64: The observed agreement, the share of comments both labelers agree on
+//   - float64: The agreement expected by chance from the label distribution of each labeler
+//   - float64: Cohen's kappa, 1 for perfect agreement and 0 for agreement at chance level
+func cohenKappa(matrix [3][3]int, total int) (float64, float64, float64) {
+	var agree int
+	var expected float64
+	for i := 0; i < 3; i++ {
+		agree += matrix[i][i]
+
+		var row, column int
+		for j := 0; j < 3; j++ {
+			row += matrix[i][j]
+			column += matrix[j][i]
+		}
+		expected += float64(row) / float64(total) * float64(column) / float64(total)
+	}
+
+	observed := float64(agree) / float64(total)
+	if expected == 1 {
+		return observed, expected, 1
+	}
+
+	return observed, expected, (observed - expected) / (1 - expected)
+}
+
+// kappaLevel describes a kappa value on the Landis and Koch scale.
+//
+// Parameters:
+//   - kappa: The kappa value
+//
+// Returns:
+//   - string: The strength of the agreement
+func kappaLevel(kappa float64) string {
+	switch {
+	case kappa < 0:
+		return "lebih buruk dari kebetulan"
+	case kappa <= 0.2:
+		return "sangat rendah"
+	case kappa <= 0.4:
+		return "rendah"
+	case kappa <= 0.6:
+		return "sedang"
+	case kappa <= 0.8:
+		return "kuat"
+	}
+
+	return "hampir sempurna"
+}
+
+// SampelAcak displays a random sample of comments for a manual quality check of the labels.
+//
+// The function workflow:
+//  1. Prompts for the sample size N (default 10)
+//  2. Asks whether to sample from all comments or N comments per category
+//  3. Shuffles the comments of the active project and renders the first N of every group
+//     with their ID, text and category
+//  4. Waits for user input (via helper.Pause) before returning
+//
+// Returns:
+//   - error: helper.ErrBack when a prompt is cancelled, or any error encountered during data retrieval
+func (r *reportService) SampelAcak() error {
+	helper.Header("MENU > ADMIN > LAPORAN > SAMPEL ACAK", "SAMPEL ACAK")
+
+	sizePrompt := promptui.Prompt{
+		Label:   "Jumlah sampel",
+		Default: "10",
+		Validate: func(input string) error {
+			number, err := strconv.Atoi(input)
+			if err != nil || number < 1 {
+				return fmt.Errorf("jumlah harus berupa angka positif")
+			}
+
+			return nil
+		},
+	}
+
+	input, err := sizePrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+	size, _ := strconv.Atoi(input)
+
+	modePrompt := promptui.Select{
+		Label: "Ambil sampel dari",
+		Items: []string{"Semua Komentar", "Per Kategori"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, mode, err := modePrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	var comments [255]model.Comment
+	err = r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	var pool []model.Comment
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar != "" {
+			pool = append(pool, comments[i])
+		}
+	}
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	groups := []string{""}
+	if mode == "Per Kategori" {
+		groups = []string{"Positif", "Netral", "Negatif"}
+	}
+
+	t := helper.NewTable()
+	t.SetTitle("Sampel acak dari %d komentar", len(pool))
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori"})
+	row := 0
+	for _, group := range groups {
+		taken := 0
+		for _, comment := range pool {
+			if taken == size {
+				break
+			}
+
+			if group != "" && comment.Kategori != group {
+				continue
+			}
+
+			taken++
+			row++
+			t.AppendRow(table.Row{row, comment.Id, comment.Komentar, comment.Kategori})
+		}
+
+		if group != "" {
+			t.AppendSeparator()
+		}
+	}
+	t.Render()
+
+	helper.Pause()
+
+	return nil
+}
+
+// PerbandinganLabel displays a side-by-side comparison of manual and automatic labels.
+//
+// For every stored comment it runs the sentiment analyzer and renders a table with the
+// manual Kategori, the predicted category, the lexicon score and whether both agree.
+// Below the table it prints how many comments agree and the agreement percentage, then
+// asks whether the confusion matrix of the classification should be displayed.
+//
+// Returns:
+//   - error: Any error encountered during data retrieval
+func (r *reportService) PerbandinganLabel() error {
+	var comments [255]model.Comment
+
+	err := r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LAPORAN > PERBANDINGAN LABEL", "PERBANDINGAN LABEL")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Manual", "Otomatis", "Skor", "Cocok"})
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+	var matrix [3][3]int
+	var agree, total int
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		total++
+		predicted, score := r.sentiment.Analyze(comments[i].Komentar)
+
+		match := "\u2717"
+		if predicted == comments[i].Kategori {
+			match = "\u2713"
+			agree++
+		}
+
+		manual := categoryIndex(categories, comments[i].Kategori)
+		auto := categoryIndex(categories, predicted)
+		if manual >= 0 && auto >= 0 {
+			matrix[manual][auto]++
+		}
+
+		t.AppendRow(table.Row{total, comments[i].Id, comments[i].Komentar, comments[i].Kategori, predicted, score, match})
+	}
+	t.Render()
+
+	if total == 0 {
+		color.Cyan("Belum ada komentar untuk dibandingkan.")
+		helper.Pause()
+		return nil
+	}
+
+	color.Cyan("Kesepakatan: %d dari %d komentar (%.1f%%)", agree, total, percentage(agree, total))
+
+	matrixPrompt := promptui.Prompt{
+		Label:     "Tampilkan confusion matrix?",
+		IsConfirm: true,
+	}
+
+	_, err = matrixPrompt.Run()
+	if err != nil {
+		return nil
+	}
+
+	helper.Header("MENU > ADMIN > LAPORAN > PERBANDINGAN LABEL > CONFUSION MATRIX", "CONFUSION MATRIX")
+
+	m := helper.NewTable()
+	m.SetTitle("Manual (baris) x Otomatis (kolom)")
+	m.AppendHeader(table.Row{"", "Positif", "Netral", "Negatif", "Total"})
+	for i, kategori := range categories {
+		m.AppendRow(table.Row{kategori, matrix[i][0], matrix[i][1], matrix[i][2], matrix[i][0] + matrix[i][1] + matrix[i][2]})
+	}
+	m.AppendFooter(table.Row{"Total", matrix[0][0] + matrix[1][0] + matrix[2][0], matrix[0][1] + matrix[1][1] + matrix[2][1], matrix[0][2] + matrix[1][2] + matrix[2][2], total})
+	m.Render()
+
+	// Precision is measured against the predicted column, recall against the manual row
+	s := helper.NewTable()
+	s.AppendHeader(table.Row{"Kategori", "Precision", "Recall", "F1"})
+	for k, kategori := range categories {
+		var predicted, actual int
+		for j := 0; j < 3; j++ {
+			predicted += matrix[j][k]
+			actual += matrix[k][j]
+		}
+
+		precision := percentage(matrix[k][k], predicted)
+		recall := percentage(matrix[k][k], actual)
+
+		var f1 float64
+		if precision+recall > 0 {
+			f1 = 2 * precision * recall / (precision + recall)
+		}
+
+		s.AppendRow(table.Row{kategori, fmt.Sprintf("%.1f%%", precision), fmt.Sprintf("%.1f%%", recall), fmt.Sprintf("%.1f%%", f1)})
+	}
+	s.Render()
+
+	helper.Pause()
+
+	return nil
+}
+
+// EvaluasiKlasifikasi evaluates the local classifier with k-fold cross-validation.
+//
+// The function workflow:
+//  1. Prompts for the number of folds k (default 5)
+//  2. Shuffles the labeled comments of the active project and splits them into k folds
+//  3. For every fold, trains a Naive Bayes classifier on the other folds and predicts the
+//     comments of the fold, adding the results to one confusion matrix
+//  4. Renders the precision, recall, F1 and support of every category, followed by the
+//     accuracy and the macro F1, and the accuracy of the lexicon analyzer for comparison
+//  5. Waits for user input (via helper.Pause) before returning
+//
+// Returns:
+//   - error: helper.ErrBack when the prompt is cancelled, or an error when there are fewer
+//     labeled comments than folds
+func (r *reportService) EvaluasiKlasifikasi() error {
+	helper.Header("MENU > ADMIN > LAPORAN > EVALUASI KLASIFIKASI", "EVALUASI KLASIFIKASI")
+
+	foldPrompt := promptui.Prompt{
+		Label:   "Jumlah fold (k)",
+		Default: "5",
+		Validate: func(input string) error {
+			k, err := strconv.Atoi(input)
+			if err != nil || k < 2 {
+				return fmt.Errorf("jumlah fold harus angka minimal 2")
+			}
+
+			return nil
+		},
+	}
+
+	input, err := foldPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	k, _ := strconv.Atoi(input)
+
+	rows, err := r.dataset()
+	if err != nil {
+		return err
+	}
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+
+	var labeled []datasetRow
+	for _, row := range rows {
+		if categoryIndex(categories, row.Label) >= 0 {
+			labeled = append(labeled, row)
+		}
+	}
+
+	if len(labeled) < k {
+		return fmt.Errorf("butuh minimal %d komentar berlabel untuk %d fold", k, k)
+	}
+
+	rand.Shuffle(len(labeled), func(i, j int) { labeled[i], labeled[j] = labeled[j], labeled[i] })
+
+	// Comment i belongs to fold i % k
+	var matrix [3][3]int
+	var lexiconAgree int
+	for fold := 0; fold < k; fold++ {
+		classifier := newNaiveBayes()
+		for i, row := range labeled {
+			if i%k != fold {
+				classifier.Train(row.Text, row.Label)
+			}
+		}
+
+		for i := fold; i < len(labeled); i += k {
+			predicted := categoryIndex(categories, classifier.Predict(labeled[i].Text))
+			if predicted < 0 {
+				continue
+			}
+
+			matrix[categoryIndex(categories, labeled[i].Label)][predicted]++
+		}
+	}
+
+	for _, row := range labeled {
+		predicted, _ := r.sentiment.Analyze(row.Text)
+		if predicted == row.Label {
+			lexiconAgree++
+		}
+	}
+
+	t := helper.NewTable()
+	t.SetTitle(fmt.Sprintf("Naive Bayes, %d-fold cross-validation", k))
+	t.AppendHeader(table.Row{"Kategori", "Precision", "Recall", "F1", "Support"})
+
+	var correct, total int
+	var macroF1 float64
+	for c, kategori := range categories {
+		var predicted, support int
+		for j := 0; j < 3; j++ {
+			predicted += matrix[j][c]
+			support += matrix[c][j]
+		}
+
+		precision := percentage(matrix[c][c], predicted)
+		recall := percentage(matrix[c][c], support)
+
+		var f1 float64
+		if precision+recall > 0 {
+			f1 = 2 * precision * recall / (precision + recall)
+		}
+
+		correct += matrix[c][c]
+		total += support
+		macroF1 += f1 / float64(len(categories))
+
+		t.AppendRow(table.Row{kategori, fmt.Sprintf("%.1f%%", precision), fmt.Sprintf("%.1f%%", recall), fmt.Sprintf("%.1f%%", f1), support})
+	}
+	t.AppendFooter(table.Row{"Total", "", "", "", total})
+	t.Render()
+
+	color.Cyan("Akurasi:          %.1f%%", percentage(correct, total))
+	color.Cyan("Macro F1:         %.1f%%", macroF1)
+	color.Cyan("Akurasi leksikon: %.1f%% (tanpa pelatihan, sebagai pembanding)", percentage(lexiconAgree, len(labeled)))
+
+	helper.Pause()
+
+	return nil
+}
+
+// KlasifikasiHuggingFace compares the manual labels with the predictions of a HuggingFace model.
+//
+// The function workflow:
+//  1. Checks that the HuggingFace connector is configured (HF_API_TOKEN, HF_MODEL)
+//  2. Sends every comment of the active project to the model, one request per comment
+//  3. Renders a table with the manual category, the predicted category, the model's
+//     probability and whether both agree; failed requests are listed with their error
+//  4. Prints how many comments agree and the agreement percentage
+//
+// Returns:
+//   - error: An error if the connector is not configured or data retrieval fails
+func (r *reportService) KlasifikasiHuggingFace() error {
+	if !r.inference.IsConfigured() {
+		return fmt.Errorf("HuggingFace belum dikonfigurasi (HF_API_TOKEN, HF_MODEL)")
+	}
+
+	var comments [255]model.Comment
+
+	err := r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	helper.Header("MENU > ADMIN > LAPORAN > KLASIFIKASI HUGGINGFACE", "KLASIFIKASI HUGGINGFACE")
+	color.Cyan("Model: %s", r.inference.Model())
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Manual", "HuggingFace", "Skor", "Cocok"})
+
+	var agree, total, failed int
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		predicted, score, err := r.inference.Classify(comments[i].Komentar)
+		if err != nil {
+			failed++
+			t.AppendRow(table.Row{total + failed, comments[i].Id, comments[i].Komentar, comments[i].Kategori, err.Error(), "", ""})
+			continue
+		}
+
+		total++
+		match := "\u2717"
+		if predicted == comments[i].Kategori {
+			match = "\u2713"
+			agree++
+		}
+
+		t.AppendRow(table.Row{total + failed, comments[i].Id, comments[i].Komentar, comments[i].Kategori, predicted, fmt.Sprintf("%.2f", score), match})
+	}
+	t.Render()
+
+	if total == 0 && failed == 0 {
+		color.Cyan("Belum ada komentar untuk diklasifikasi.")
+	} else {
+		color.Cyan("Kesepakatan: %d dari %d komentar (%.1f%%)", agree, total, percentage(agree, total))
+	}
+
+	if failed > 0 {
+		color.Red("%d komentar gagal diklasifikasi", failed)
+	}
+
+	helper.Pause()
+
+	return nil
+}
+
+// KlasifikasiFile classifies the lines of an external text file.
+//
+// The function workflow:
+//  1. Prompts for the input text file (one comment per line) and the output CSV file
+//  2. Asks for the classifier: the lexicon analyzer, or the HuggingFace model when configured
+//  3. Classifies every non-empty line and writes the columns komentar, kategori and skor
+//     with helper.WriteCSV. Lines the classifier fails on are written with an empty category
+//  4. Prints the number of classified lines per category
+//
+// Nothing is written to the store, so the file can be labeled without importing it.
+//
+// Returns:
+//   - error: helper.ErrBack when a prompt is cancelled, or any error encountered while reading
+//     or writing the files
+func (r *reportService) KlasifikasiFile() error {
+	helper.Header("MENU > ADMIN > LAPORAN > KLASIFIKASI FILE", "KLASIFIKASI FILE")
+
+	inputPrompt := promptui.Prompt{
+		Label: "File teks (satu komentar per baris)",
+		Validate: func(input string) error {
+			_, err := os.Stat(input)
+			return err
+		},
+	}
+
+	inputPath, err := inputPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	outputPrompt := promptui.Prompt{
+		Label:   "File CSV hasil",
+		Default: strings.TrimSuffix(inputPath, filepath.Ext(inputPath)) + "_berlabel.csv",
+	}
+
+	outputPath, err := outputPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	classifiers := []string{"Leksikon"}
+	if r.inference.IsConfigured() {
+		classifiers = append(classifiers, "HuggingFace")
+	}
+
+	classifierPrompt := promptui.Select{
+		Label: "Klasifikasi dengan",
+		Items: classifiers,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, classifier, err := classifierPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	file, err := os.Open(inputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	counts := map[string]int{}
+	var rows [][]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var kategori, score string
+		if classifier == "HuggingFace" {
+			predicted, probability, err := r.inference.Classify(line)
+			if err == nil {
+				kategori, score = predicted, fmt.Sprintf("%.4f", probability)
+			}
+		} else {
+			predicted, lexicon := r.sentiment.Analyze(line)
+			kategori, score = predicted, strconv.Itoa(lexicon)
+		}
+
+		if kategori == "" {
+			counts["Gagal"]++
+		} else {
+			counts[kategori]++
+		}
+
+		rows = append(rows, []string{line, kategori, score})
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return err
+	}
+
+	err = helper.WriteCSV(outputPath, []string{"komentar", "kategori", "skor"}, rows)
+	if err != nil {
+		return err
+	}
+
+	color.Green("%d baris berhasil diklasifikasi ke %s", len(rows)-counts["Gagal"], outputPath)
+	color.Cyan("Positif: %d, Netral: %d, Negatif: %d", counts["Positif"], counts["Netral"], counts["Negatif"])
+	if counts["Gagal"] > 0 {
+		color.Red("%d baris gagal diklasifikasi", counts["Gagal"])
+	}
+
+	helper.Pause()
+
+	return nil
+}
+
+// UserKategori displays a cross-tabulation of users against sentiment categories.
+//
+// The function workflow:
+//  1. Counts the comments of every user per category (Positif, Netral, Negatif)
+//  2. Renders a matrix with one row per user, one column per category and a total column,
+//     followed by a totals row. Comments created from the admin menu are listed as "(admin)"
+//  3. Asks whether the matrix should be exported to CSV
+//     - If yes: Prompts for the file name and writes the matrix with helper.WriteCSV
+//
+// Returns:
+//   - error: Any error encountered during data retrieval or export
+func (r *reportService) UserKategori() error {
+	var users [255]model.User
+	var comments [255]model.Comment
+
+	err := r.userService.GetAllUsers(&users)
+	if err != nil {
+		return err
+	}
+
+	err = r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+
+	// Row 0 is reserved for comments created by the admin (UserId 0)
+	var counts [256][3]int
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		row := -1
+		if comments[i].UserId == 0 {
+			row = 0
+		}
+
+		for j := 0; j < global.UserCount; j++ {
+			if users[j].Id == comments[i].UserId {
+				row = j + 1
+				break
+			}
+		}
+
+		if row == -1 {
+			continue
+		}
+
+		for k, kategori := range categories {
+			if comments[i].Kategori == kategori {
+				counts[row][k]++
+			}
+		}
+	}
+
+	helper.Header("MENU > ADMIN > LAPORAN > USER X KATEGORI", "USER X KATEGORI")
+
+	header := []string{"Username", "Positif", "Netral", "Negatif", "Total"}
+	var rows [][]string
+	var totals [3]int
+
+	for row := 0; row <= global.UserCount; row++ {
+		username := "(admin)"
+		if row > 0 {
+			username = users[row-1].Username
+		}
+
+		total := counts[row][0] + counts[row][1] + counts[row][2]
+		if row == 0 && total == 0 {
+			continue
+		}
+
+		line := []string{username}
+		for k := range categories {
+			line = append(line, strconv.Itoa(counts[row][k]))
+			totals[k] += counts[row][k]
+		}
+		line = append(line, strconv.Itoa(total))
+		rows = append(rows, line)
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{header[0], header[1], header[2], header[3], header[4]})
+	for _, line := range rows {
+		t.AppendRow(table.Row{line[0], line[1], line[2], line[3], line[4]})
+	}
+	t.AppendFooter(table.Row{"Total", totals[0], totals[1], totals[2], totals[0] + totals[1] + totals[2]})
+	t.Render()
+
+	exportPrompt := promptui.Prompt{
+		Label:     "Export ke CSV?",
+		IsConfirm: true,
+	}
+
+	_, err = exportPrompt.Run()
+	if err != nil {
+		return nil
+	}
+
+	pathPrompt := promptui.Prompt{
+		Label:   "Nama file",
+		Default: "user_kategori.csv",
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	err = helper.WriteCSV(path, header, rows)
+	if err != nil {
+		return err
+	}
+
+	color.Green("Laporan berhasil diekspor ke %s", path)
+	helper.Pause()
+
+	return nil
+}
+
+// ExportGrafikPNG renders the sentiment charts to PNG files.
+//
+// The function workflow:
+//  1. Prompts for the output directory (defaults to the current directory)
+//  2. Writes distribusi_sentimen.png: a bar chart with the number of comments per category
+//  3. Writes tren_sentimen.png: a line chart with the cumulative number of comments per
+//     category in the order the comments were created
+//  4. Prints the paths of the written files
+//
+// Returns:
+//   - error: Any error encountered during data retrieval or rendering
+func (r *reportService) ExportGrafikPNG() error {
+	helper.Header("MENU > ADMIN > LAPORAN > EXPORT GRAFIK PNG", "EXPORT GRAFIK PNG")
+
+	if r.commentRepo.CountComments() == 0 {
+		return fmt.Errorf("belum ada komentar untuk dibuat grafik")
+	}
+
+	dirPrompt := promptui.Prompt{
+		Label:   "Folder tujuan",
+		Default: ".",
+	}
+
+	dir, err := dirPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	var comments [255]model.Comment
+	err = r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+	colors := []drawing.Color{
+		drawing.ColorFromHex("4caf50"),
+		drawing.ColorFromHex("9e9e9e"),
+		drawing.ColorFromHex("f44336"),
+	}
+
+	var counts [3]float64
+	xValues := []float64{0}
+	yValues := [3][]float64{{0}, {0}, {0}}
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		for k, kategori := range categories {
+			if comments[i].Kategori == kategori {
+				counts[k]++
+			}
+			yValues[k] = append(yValues[k], counts[k])
+		}
+		xValues = append(xValues, float64(len(xValues)))
+	}
+
+	bars := make([]chart.Value, len(categories))
+	series := make([]chart.Series, len(categories))
+	for k, kategori := range categories {
+		bars[k] = chart.Value{
+			Label: kategori,
+			Value: counts[k],
+			Style: chart.Style{FillColor: colors[k], StrokeColor: colors[k]},
+		}
+		series[k] = chart.ContinuousSeries{
+			Name:    kategori,
+			XValues: xValues,
+			YValues: yValues[k],
+			Style:   chart.Style{StrokeColor: colors[k], StrokeWidth: 2},
+		}
+	}
+
+	distribusi := chart.BarChart{
+		Title:      "Distribusi Sentimen",
+		Height:     512,
+		BarWidth:   80,
+		Background: chart.Style{Padding: chart.Box{Top: 40}},
+		Bars:       bars,
+	}
+
+	tren := chart.Chart{
+		Title:      "Tren Sentimen",
+		Background: chart.Style{Padding: chart.Box{Top: 40, Left: 20}},
+		XAxis:      chart.XAxis{Name: "Urutan Komentar"},
+		YAxis:      chart.YAxis{Name: "Jumlah Kumulatif"},
+		Series:     series,
+	}
+	tren.Elements = []chart.Renderable{chart.Legend(&tren)}
+
+	distribusiPath := filepath.Join(dir, "distribusi_sentimen.png")
+	err = renderPNG(distribusiPath, distribusi.Render)
+	if err != nil {
+		return err
+	}
+
+	trenPath := filepath.Join(dir, "tren_sentimen.png")
+	err = renderPNG(trenPath, tren.Render)
+	if err != nil {
+		return err
+	}
+
+	color.Green("Grafik berhasil diekspor ke %s dan %s", distribusiPath, trenPath)
+	helper.Pause()
+
+	return nil
+}
+
+// ExportData exports the users and the comments of the active project.
+//
+// The function workflow:
+//  1. Asks for the format (CSV, JSON or XLSX)
+//  2. Prompts for the output file name, defaulting to data.<format>
+//  3. Asks whether the data should be anonymized for public sharing and, if not,
+//     whether the internal admin notes should be included
+//  4. Writes the data with Export and prints the written files
+//
+// Returns:
+//   - error: helper.ErrBack when a prompt is cancelled, or any error encountered during the export
+func (r *reportService) ExportData() error {
+	helper.Header("MENU > ADMIN > LAPORAN > EXPORT DATA", "EXPORT DATA")
+
+	formatPrompt := promptui.Select{
+		Label: "Format",
+		Items: []string{"csv", "json", "xlsx"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, format, err := formatPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	pathPrompt := promptui.Prompt{
+		Label:   "Nama file",
+		Default: "data." + format,
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	anonymizePrompt := promptui.Prompt{
+		Label:     "Anonimkan user (untuk dibagikan publik)",
+		IsConfirm: true,
+	}
+
+	_, err = anonymizePrompt.Run()
+	anonymize := err == nil
+
+	notes := false
+	if !anonymize {
+		notesPrompt := promptui.Prompt{
+			Label:     "Sertakan catatan admin",
+			IsConfirm: true,
+		}
+
+		_, err = notesPrompt.Run()
+		notes = err == nil
+	}
+
+	paths, err := r.Export(format, path, notes, anonymize)
+	if err != nil {
+		return err
+	}
+
+	color.Green("Data berhasil diekspor ke %s", strings.Join(paths, " dan "))
+	helper.Pause()
+
+	return nil
+}
+
+// Export writes the users and the comments of the active project to a file.
+//
+// The formats are:
+//   - csv: Two files, <name>_users.csv and <name>_komentar.csv, because CSV holds one table
+//   - json: One object with the arrays "users" and "komentar"
+//   - xlsx: One workbook with the sheets "Users" and "Komentar"
+//
+// Passwords are never exported. The internal admin notes are only exported when
+// requested, as the column "catatan" or the field of the comment objects.
+//
+// An anonymized export replaces every user by a pseudonym, see pseudonyms: the user
+// table only has the columns user and created_at, and the comments name their author
+// and second labeler by pseudonym instead of by ID. The UUIDs, the admin notes and the
+// follow-up assignee are left out, and @mentions in the comment texts are replaced too.
+//
+// Parameters:
+//   - format: "csv", "json" or "xlsx"
+//   - path: The output file name
+//   - notes: Whether the admin notes of the comments are included, ignored when anonymizing
+//   - anonymize: Whether the users are replaced by pseudonyms
+//
+// Returns:
+//   - []string: The paths of the written files
+//   - error: An error for an unknown format or when data retrieval or writing fails
+func (r *reportService) Export(format, path string, notes bool, anonymize bool) ([]string, error) {
+	type exportUser struct {
+		Id        int       `json:"id"`
+		Uuid      string    `json:"uuid,omitempty"`
+		Username  string    `json:"username"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	type anonymousUser struct {
+		User      string    `json:"user"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	type anonymousComment struct {
+		Id        int       `json:"id"`
+		User      string    `json:"user"`
+		ProjectId int       `json:"project_id,omitempty"`
+		Komentar  string    `json:"komentar"`
+		Kategori  string    `json:"kategori"`
+		Kategori2 string    `json:"kategori2,omitempty"`
+		Pelabel2  string    `json:"pelabel2,omitempty"`
+		Sumber    string    `json:"sumber,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	var users []model.User
+	err := r.userService.ForEachUser(func(u model.User) bool {
+		users = append(users, u)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names *pseudonyms
+	if anonymize {
+		names, err = newPseudonyms(users)
+		if err != nil {
+			return nil, err
+		}
+		notes = false
+	}
+
+	userHeader := []string{"id", "uuid", "username", "created_at"}
+	if anonymize {
+		userHeader = []string{"user", "created_at"}
+	}
+
+	var userRows [][]string
+	var userList any
+	exportUsers := []exportUser{}
+	anonymousUsers := []anonymousUser{}
+
+	for _, u := range users {
+		if anonymize {
+			anonymousUsers = append(anonymousUsers, anonymousUser{names.user(u.Id), u.CreatedAt})
+			userRows = append(userRows, []string{names.user(u.Id), u.CreatedAt.Format(time.RFC3339)})
+			continue
+		}
+
+		exportUsers = append(exportUsers, exportUser{u.Id, u.Uuid, u.Username, u.CreatedAt})
+		userRows = append(userRows, []string{strconv.Itoa(u.Id), u.Uuid, u.Username, u.CreatedAt.Format(time.RFC3339)})
+	}
+
+	userList = exportUsers
+	if anonymize {
+		userList = anonymousUsers
+	}
+
+	commentHeader := []string{"id", "uuid", "user_id", "project_id", "komentar", "kategori", "kategori2", "sumber", "created_at"}
+	if anonymize {
+		commentHeader = []string{"id", "user", "project_id", "komentar", "kategori", "kategori2", "sumber", "created_at"}
+	}
+	if notes {
+		commentHeader = append(commentHeader, "catatan")
+	}
+
+	var commentRows [][]string
+	var commentList any
+	var exportComments []model.Comment
+	var anonymousComments []anonymousComment
+
+	err = r.commentRepo.ForEachComment(func(c model.Comment) bool {
+		if anonymize {
+			comment := anonymousComment{c.Id, names.user(c.UserId), c.ProjectId, names.text(c.Komentar), c.Kategori, c.Kategori2, "", c.Sumber, c.CreatedAt}
+			if c.Pelabel2 != 0 {
+				comment.Pelabel2 = names.user(c.Pelabel2)
+			}
+
+			anonymousComments = append(anonymousComments, comment)
+			commentRows = append(commentRows, []string{strconv.Itoa(c.Id), comment.User, strconv.Itoa(c.ProjectId), comment.Komentar, c.Kategori, c.Kategori2, c.Sumber, c.CreatedAt.Format(time.RFC3339)})
+			return true
+		}
+
+		row := []string{strconv.Itoa(c.Id), c.Uuid, strconv.Itoa(c.UserId), strconv.Itoa(c.ProjectId), c.Komentar, c.Kategori, c.Kategori2, c.Sumber, c.CreatedAt.Format(time.RFC3339)}
+		if notes {
+			row = append(row, c.Catatan)
+		} else {
+			c.Catatan = ""
+		}
+
+		exportComments = append(exportComments, c)
+		commentRows = append(commentRows, row)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commentList = exportComments
+	if anonymize {
+		commentList = anonymousComments
+	}
+
+	switch format {
+	case "csv":
+		stem := strings.TrimSuffix(path, filepath.Ext(path))
+		userPath := stem + "_users.csv"
+		commentPath := stem + "_komentar.csv"
+
+		err = helper.WriteCSV(userPath, userHeader, userRows)
+		if err != nil {
+			return nil, err
+		}
+
+		err = helper.WriteCSV(commentPath, commentHeader, commentRows)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{userPath, commentPath}, nil
+
+	case "json":
+		data, err := json.MarshalIndent(struct {
+			Users    any `json:"users"`
+			Komentar any `json:"komentar"`
+		}{userList, commentList}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(path, data, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{path}, nil
+
+	case "xlsx":
+		err = helper.WriteXLSX(path, []helper.Sheet{
+			{Name: "Users", Header: userHeader, Rows: userRows},
+			{Name: "Komentar", Header: commentHeader, Rows: commentRows},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{path}, nil
+	}
+
+	return nil, fmt.Errorf("format tidak dikenal: %s (csv, json atau xlsx)", format)
+}
+
+// anonymizeSalt returns the key of the pseudonyms, ANONYMIZE_SALT. When it is not set a
+// random salt is generated once and saved to the .env file, so later exports, also after
+// a restart, use the same salt.
+//
+// Returns:
+//   - []byte: The salt
+//   - error: An error if no salt can be generated or it cannot be saved
+func anonymizeSalt() ([]byte, error) {
+	salt := helper.GetEnv("ANONYMIZE_SALT", "")
+	if salt != "" {
+		return []byte(salt), nil
+	}
+
+	key := make([]byte, 32)
+	_, err := cryptorand.Read(key)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat ANONYMIZE_SALT: %v", err)
+	}
+
+	salt = hex.EncodeToString(key)
+	err = helper.SaveEnv(helper.EnvFile, "ANONYMIZE_SALT", salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(salt), nil
+}
+
+// pseudonyms maps the users of an anonymized export to stable pseudonyms.
+//
+// A pseudonym is "peserta-" followed by a keyed hash of the UUID of the user (or its ID
+// when it has none) with ANONYMIZE_SALT, see anonymizeSalt, so the same participant gets
+// the same pseudonym in every export while the pseudonym cannot be traced back to the
+// user. Changing or removing the salt gives every participant a new pseudonym.
+type pseudonyms struct {
+	// byId maps the user IDs to their pseudonyms
+	byId map[int]string
+
+	// byName maps the lowercase usernames to their pseudonyms, for the @mentions
+	byName map[string]string
+}
+
+// newPseudonyms creates the pseudonyms of the given users.
+//
+// Parameters:
+//   - users: The users to create pseudonyms for
+//
+// Returns:
+//   - *pseudonyms: The pseudonyms of the users
+//   - error: An error if the salt of the pseudonyms cannot be read or saved
+func newPseudonyms(users []model.User) (*pseudonyms, error) {
+	key, err := anonymizeSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pseudonyms{byId: map[int]string{}, byName: map[string]string{}}
+	for _, u := range users {
+		identity := u.Uuid
+		if identity == "" {
+			identity = "id:" + strconv.Itoa(u.Id)
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(identity))
+		name := "peserta-" + hex.EncodeToString(mac.Sum(nil))[:8]
+
+		p.byId[u.Id] = name
+		p.byName[strings.ToLower(u.Username)] = name
+	}
+
+	return p, nil
+}
+
+// user returns the pseudonym of a user ID.
+//
+// Parameters:
+//   - id: The user ID, 0 for comments made by the admin
+//
+// Returns:
+//   - string: The pseudonym, "admin" for ID 0 or "anonim" for an unknown user
+func (p *pseudonyms) user(id int) string {
+	if id == 0 {
+		return "admin"
+	}
+
+	if name, ok := p.byId[id]; ok {
+		return name
+	}
+
+	return "anonim"
+}
+
+// text replaces the @mentions in a comment text by the pseudonyms of the mentioned
+// users, or by @anonim when the username is unknown.
+//
+// Parameters:
+//   - text: The comment text
+//
+// Returns:
+//   - string: The text without usernames
+func (p *pseudonyms) text(text string) string {
+	words := strings.Split(text, " ")
+	for i, word := range words {
+		name := strings.TrimRight(strings.TrimPrefix(word, "@"), ".,!?;:")
+		if !strings.HasPrefix(word, "@") || name == "" {
+			continue
+		}
+
+		pseudonym, ok := p.byName[strings.ToLower(name)]
+		if !ok {
+			pseudonym = "anonim"
+		}
+
+		words[i] = "@" + pseudonym + strings.TrimPrefix(word, "@"+name)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// ExportUsers exports the users with their comment statistics for a report appendix.
+//
+// The function workflow:
+//  1. Prompts for the output file name (defaults to users.csv)
+//  2. Writes the users with ExportUserStats
+//  3. Prints the number of exported users and the path of the file
+//
+// Returns:
+//   - error: helper.ErrBack when the prompt is cancelled, or any error encountered during the export
+func (r *reportService) ExportUsers() error {
+	helper.Header("MENU > ADMIN > LIHAT USER > EXPORT", "EXPORT USER")
+
+	pathPrompt := promptui.Prompt{
+		Label:   "Nama file",
+		Default: "users.csv",
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	n, err := r.ExportUserStats(path)
+	if err != nil {
+		return err
+	}
+
+	color.Green("%d user berhasil diekspor ke %s", n, path)
+	helper.Pause()
+
+	return nil
+}
+
+// ExportUserStats writes one CSV row per user with the columns username, role,
+// registered_at, komentar (the number of comments in the active project) and the number
+// of Positif, Netral and Negatif comments. The role is the admin role of the account, or
+// "user" for a regular user. No e-mail addresses are stored, so none are exported, and
+// neither are passwords.
+//
+// Parameters:
+//   - path: The output file name
+//
+// Returns:
+//   - int: The number of users written
+//   - error: Any error encountered during data retrieval or writing the file
+func (r *reportService) ExportUserStats(path string) (int, error) {
+	counts := make(map[int]map[string]int)
+	err := r.commentRepo.ForEachComment(func(c model.Comment) bool {
+		if counts[c.UserId] == nil {
+			counts[c.UserId] = make(map[string]int)
+		}
+		counts[c.UserId][c.Kategori]++
+		counts[c.UserId][""]++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	header := []string{"username", "role", "registered_at", "komentar", "positif", "netral", "negatif"}
+	var rows [][]string
+	err = r.userService.ForEachUser(func(u model.User) bool {
+		count := counts[u.Id]
+		role := u.Role
+		if role == "" {
+			role = "user"
+		}
+
+		rows = append(rows, []string{
+			u.Username,
+			role,
+			u.CreatedAt.Format(time.RFC3339),
+			strconv.Itoa(count[""]),
+			strconv.Itoa(count["Positif"]),
+			strconv.Itoa(count["Netral"]),
+			strconv.Itoa(count["Negatif"]),
+		})
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = helper.WriteCSV(path, header, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(rows), nil
+}
+
+// ExportComments exports the comments of the active project as CSV.
+//
+// The function workflow:
+//  1. Prompts for the output file name, defaulting to COMMENT_EXPORT_FILE (komentar.csv)
+//  2. Writes the comments with ExportCommentCSV
+//  3. Prints the number of exported rows and the path of the file
+//
+// Returns:
+//   - error: helper.ErrBack when the prompt is cancelled, or any error encountered during the export
+func (r *reportService) ExportComments() error {
+	helper.Header("MENU > ADMIN > LIHAT KOMENTAR > EXPORT", "EXPORT KOMENTAR")
+
+	pathPrompt := promptui.Prompt{
+		Label:   "Nama file",
+		Default: helper.GetEnv("COMMENT_EXPORT_FILE", "komentar.csv"),
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	n, err := r.ExportCommentCSV(path)
+	if err != nil {
+		return err
+	}
+
+	color.Green("%d komentar berhasil diekspor ke %s", n, path)
+	helper.Pause()
+
+	return nil
+}
+
+// ExportCommentCSV writes one CSV row per comment of the active project with the columns
+// id, user_id, username, komentar, kategori, status, created_at and edited_at. The
+// username of UserId 0 is "admin", edited_at is the time of the last edit and empty for
+// comments that were never edited. The internal admin notes are not exported.
+//
+// Parameters:
+//   - path: The output file name
+//
+// Returns:
+//   - int: The number of comments written
+//   - error: Any error encountered during data retrieval or writing the file
+func (r *reportService) ExportCommentCSV(path string) (int, error) {
+	usernames := map[int]string{0: "admin"}
+	err := r.userService.ForEachUser(func(u model.User) bool {
+		usernames[u.Id] = u.Username
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	header := []string{"id", "user_id", "username", "komentar", "kategori", "status", "created_at", "edited_at"}
+	var rows [][]string
+	var revisions [255]model.CommentRevision
+
+	err = r.commentRepo.ForEachComment(func(c model.Comment) bool {
+		editedAt := ""
+		n, err := r.commentRepo.GetCommentRevisions(c.Id, &revisions)
+		if err == nil && n > 0 {
+			editedAt = revisions[n-1].EditedAt.Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(c.Id),
+			strconv.Itoa(c.UserId),
+			usernames[c.UserId],
+			c.Komentar,
+			c.Kategori,
+			repository.StatusOf(c),
+			c.CreatedAt.Format(time.RFC3339),
+			editedAt,
+		})
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = helper.WriteCSV(path, header, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(rows), nil
+}
+
+// datasetRow is one line of the JSONL dataset export.
+type datasetRow struct {
+	Text      string    `json:"text"`
+	Label     string    `json:"label"`
+	User      string    `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportDataset writes the comments of the active project as a JSON Lines dataset.
+//
+// The function workflow:
+//  1. Prompts for the output file name (defaults to dataset.jsonl)
+//  2. Writes one JSON object per comment with the fields text, label, user and
+//     created_at. Comments created from the admin menu get the user "(admin)"
+//  3. Prints the number of exported comments and the path of the file
+//
+// Returns:
+//   - error: Any error encountered during data retrieval or writing the file
+func (r *reportService) ExportDataset() error {
+	helper.Header("MENU > ADMIN > LAPORAN > EXPORT DATASET JSONL", "EXPORT DATASET JSONL")
+
+	if r.commentRepo.CountComments() == 0 {
+		return fmt.Errorf("belum ada komentar untuk diekspor")
+	}
+
+	pathPrompt := promptui.Prompt{
+		Label:   "Nama file",
+		Default: "dataset.jsonl",
+	}
+
+	path, err := pathPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.dataset()
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONL(path, rows)
+	if err != nil {
+		return err
+	}
+
+	color.Green("%d komentar berhasil diekspor ke %s", len(rows), path)
+	helper.Pause()
+
+	return nil
+}
+
+// SplitDataset splits the labeled comments of the active project into train and test files.
+//
+// The function workflow:
+//  1. Prompts for the train ratio (defaults to 0.8) and the two output file names
+//  2. Groups the labeled comments by category and shuffles every group
+//  3. Puts the first ratio part of every group in the train set and the rest in the
+//     test set, so both sets keep the category distribution of the whole dataset
+//  4. Writes both sets as JSON Lines in the same format as ExportDataset and renders
+//     a table with the number of comments per category in each set
+//
+// Returns:
+//   - error: Any error encountered during input, data retrieval or writing the files
+func (r *reportService) SplitDataset() error {
+	helper.Header("MENU > ADMIN > LAPORAN > SPLIT TRAIN/TEST", "SPLIT TRAIN/TEST")
+
+	if r.commentRepo.CountComments() == 0 {
+		return fmt.Errorf("belum ada komentar untuk diekspor")
+	}
+
+	ratioPrompt := promptui.Prompt{
+		Label:   "Rasio train (0-1)",
+		Default: "0.8",
+		Validate: func(input string) error {
+			ratio, err := strconv.ParseFloat(input, 64)
+			if err != nil || ratio <= 0 || ratio >= 1 {
+				return fmt.Errorf("rasio harus angka di antara 0 dan 1")
+			}
+
+			return nil
+		},
+	}
+
+	input, err := ratioPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	ratio, _ := strconv.ParseFloat(input, 64)
+
+	trainPrompt := promptui.Prompt{
+		Label:   "File train",
+		Default: "train.jsonl",
+	}
+
+	trainPath, err := trainPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	testPrompt := promptui.Prompt{
+		Label:   "File test",
+		Default: "test.jsonl",
+	}
+
+	testPath, err := testPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.dataset()
+	if err != nil {
+		return err
+	}
+
+	categories := []string{"Positif", "Netral", "Negatif"}
+
+	var train, test []datasetRow
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Kategori", "Train", "Test"})
+	for _, kategori := range categories {
+		var group []datasetRow
+		for _, row := range rows {
+			if row.Label == kategori {
+				group = append(group, row)
+			}
+		}
+
+		rand.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+
+		n := int(float64(len(group))*ratio + 0.5)
+		train = append(train, group[:n]...)
+		test = append(test, group[n:]...)
+		t.AppendRow(table.Row{kategori, n, len(group) - n})
+	}
+	t.AppendFooter(table.Row{"Total", len(train), len(test)})
+
+	err = writeJSONL(trainPath, train)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONL(testPath, test)
+	if err != nil {
+		return err
+	}
+
+	t.Render()
+	color.Green("Dataset berhasil dibagi ke %s dan %s", trainPath, testPath)
+	helper.Pause()
+
+	return nil
+}
+
+// dataset collects the comments of the active project as dataset rows, resolving the
+// user ID of every comment to its username. Comments created from the admin menu get
+// the user "(admin)".
+//
+// Returns:
+//   - []datasetRow: One row per comment in storage order
+//   - error: Any error encountered during data retrieval
+func (r *reportService) dataset() ([]datasetRow, error) {
+	usernames := map[int]string{0: "(admin)"}
+
+	err := r.userService.ForEachUser(func(user model.User) bool {
+		usernames[user.Id] = user.Username
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []datasetRow
+	err = r.commentRepo.ForEachComment(func(comment model.Comment) bool {
+		username, ok := usernames[comment.UserId]
+		if !ok {
+			username = "(admin)"
+		}
+
+		rows = append(rows, datasetRow{
+			Text:      comment.Komentar,
+			Label:     comment.Kategori,
+			User:      username,
+			CreatedAt: comment.CreatedAt,
+		})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// writeJSONL writes dataset rows to a file as JSON Lines, one object per line.
+// The file is created if it does not exist and truncated if it does.
+//
+// Parameters:
+//   - path: The location of the file to write
+//   - rows: The rows to write
+//
+// Returns:
+//   - error: An error if the file cannot be created or written, nil on success
+func writeJSONL(path string, rows []datasetRow) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, row := range rows {
+		err = encoder.Encode(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderPNG creates a file at path and renders a go-chart chart into it as PNG.
+//
+// Parameters:
+//   - path: The location of the PNG file to write
+//   - render: The Render method of the chart to draw
+//
+// Returns:
+//   - error: An error if the file cannot be created or the chart cannot be rendered
+func renderPNG(path string, render func(chart.RendererProvider, io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return render(chart.PNG, file)
+}
+
+// periodSummary holds the aggregated statistics of the comments in one period.
+type periodSummary struct {
+	// Title is the human readable name of the period (e.g. "Oktober 2026").
+	Title string
+
+	// Total is the number of comments in the period.
+	Total int
+
+	// Kategori maps every sentiment category to its number of comments.
+	Kategori map[string]int
+
+	// TopUsers lists the most active users, most comments first.
+	TopUsers []rankedItem
+
+	// TopWords lists the most frequent words, most frequent first.
+	TopWords []rankedItem
+}
+
+// rankedItem is a name with a count, used for the top users and top words lists.
+type rankedItem struct {
+	Name  string
+	Count int
+}
+
+// stopwords are common Indonesian words that are ignored when counting top words.
+var stopwords = toSet([]string{
+	"yang", "dan", "di", "ke", "dari", "ini", "itu", "untuk", "dengan", "saya", "aku", "kamu",
+	"nya", "juga", "ada", "akan", "atau", "pada", "sudah", "lagi", "karena", "jadi", "sangat",
+	"banget", "bisa", "the", "and", "dia", "kami", "kita", "mereka", "tapi", "aja", "saja",
+})
+
+// bulan are the Indonesian month names indexed by time.Month.
+var bulan = []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli",
+	"Agustus", "September", "Oktober", "November", "Desember"}
+
+// LaporanBulanan aggregates comments by month and writes one report file per month.
+//
+// The function workflow:
+//  1. Groups all comments by the month of their CreatedAt timestamp
+//  2. Renders an overview table (Bulan, Jumlah, Positif, Netral, Negatif)
+//  3. Prompts for the output directory (defaults to "laporan")
+//  4. Writes laporan_YYYY-MM.txt for every month, containing the totals, the category
+//     split, the top 3 users and the top 5 words of that month
+//
+// Returns:
+//   - error: Any error encountered during data retrieval or while writing the files
+func (r *reportService) LaporanBulanan() error {
+	var comments [255]model.Comment
+
+	err := r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	// Collect the distinct months, oldest first
+	var months []string
+	seen := make(map[string]bool)
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar == "" {
+			continue
+		}
+
+		month := comments[i].CreatedAt.Format("2006-01")
+		if !seen[month] {
+			seen[month] = true
+			months = append(months, month)
+		}
+	}
+	sort.Strings(months)
+
+	helper.Header("MENU > ADMIN > LAPORAN > LAPORAN BULANAN", "LAPORAN BULANAN")
+
+	if len(months) == 0 {
+		return fmt.Errorf("belum ada komentar untuk dibuat laporan")
+	}
+
+	summaries := make([]periodSummary, len(months))
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Bulan", "Jumlah", "Positif", "Netral", "Negatif"})
+	for i, month := range months {
+		start, _ := time.ParseInLocation("2006-01", month, time.Local)
+		summaries[i], err = r.summarize(start, start.AddDate(0, 1, 0))
+		if err != nil {
+			return err
+		}
+		summaries[i].Title = fmt.Sprintf("%s %d", bulan[start.Month()], start.Year())
+
+		t.AppendRow(table.Row{
+			summaries[i].Title,
+			summaries[i].Total,
+			summaries[i].Kategori["Positif"],
+			summaries[i].Kategori["Netral"],
+			summaries[i].Kategori["Negatif"],
+		})
+	}
+	t.Render()
+
+	dirPrompt := promptui.Prompt{
+		Label:   "Folder tujuan",
+		Default: "laporan",
+	}
+
+	dir, err := dirPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for i, month := range months {
+		path := filepath.Join(dir, "laporan_"+month+".txt")
+
+		err = os.WriteFile(path, []byte(formatSummary("LAPORAN BULANAN", summaries[i])), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	color.Green("%d laporan bulanan berhasil ditulis ke %s", len(months), dir)
+	helper.Pause()
+
+	return nil
+}
+
+// summarize aggregates the comments created in the half-open interval [from, to).
+//
+// Parameters:
+//   - from: The start of the period (inclusive)
+//   - to: The end of the period (exclusive)
+//
+// Returns:
+//   - periodSummary: The totals, category split, top 3 users and top 5 words of the period
+//   - error: Any error encountered during data retrieval
+func (r *reportService) summarize(from, to time.Time) (periodSummary, error) {
+	var users [255]model.User
+	var comments [255]model.Comment
+
+	summary := periodSummary{Kategori: make(map[string]int)}
+
+	err := r.userService.GetAllUsers(&users)
+	if err != nil {
+		return summary, err
+	}
+
+	err = r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return summary, err
+	}
+
+	userCounts := make(map[string]int)
+	wordCounts := make(map[string]int)
+
+	for i := 0; i < global.CommentCount; i++ {
+		comment := comments[i]
+		if comment.CreatedAt.Before(from) || !comment.CreatedAt.Before(to) {
+			continue
+		}
+
+		summary.Total++
+		summary.Kategori[comment.Kategori]++
+
+		username := "(admin)"
+		for j := 0; j < global.UserCount; j++ {
+			if users[j].Id == comment.UserId {
+				username = users[j].Username
+				break
+			}
+		}
+		userCounts[username]++
+
+		countWords(comment.Komentar, wordCounts)
+	}
+
+	summary.TopUsers = topItems(userCounts, 3)
+	summary.TopWords = topItems(wordCounts, 5)
+
+	return summary, nil
+}
+
+// countWords adds the words of a text to word counts. Words are lowercased, and words of
+// at most two letters and stopwords are skipped.
+//
+// Parameters:
+//   - text: The text to count
+//   - counts: The counts per word, updated in place
+func countWords(text string, counts map[string]int) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c)
+	})
+	for _, word := range words {
+		if len(word) > 2 && !stopwords[word] {
+			counts[word]++
+		}
+	}
+}
+
+// Statistics is the aggregate of the comments served to dashboards.
+type Statistics struct {
+	// TotalUsers is the number of user accounts.
+	TotalUsers int `json:"total_users"`
+
+	// TotalComments is the number of comments in the active project.
+	TotalComments int `json:"total_comments"`
+
+	// PerCategory is the number of comments per category.
+	PerCategory map[string]int `json:"per_category"`
+
+	// PerDay is the number of comments created on each of the last days, oldest first.
+	PerDay []DailyCount `json:"per_day"`
+
+	// TopWords are the most used words, most used first.
+	TopWords []WordCount `json:"top_words"`
+
+	// Length is the average, median, shortest and longest comment length per category,
+	// followed by the lengths of every comment.
+	Length []model.LengthStats `json:"length"`
+}
+
+// DailyCount is the number of comments created on one day.
+type DailyCount struct {
+	// Date is the day in the format 2006-01-02.
+	Date string `json:"date"`
+
+	// Total is the number of comments created on the day.
+	Total int `json:"total"`
+
+	// PerCategory is the number of comments per category created on the day.
+	PerCategory map[string]int `json:"per_category"`
+}
+
+// WordCount is a word with the number of times it is used.
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Statistics aggregates the comments of the active project for dashboards.
+//
+// Every day of the period is listed, days without comments with a count of 0, so a
+// chart does not skip days. Words are counted like in the monthly report, see countWords.
+// The comment lengths are aggregated by the repository, see repository.CommentQuery.LengthStats.
+//
+// Parameters:
+//   - days: The number of days in the per-day series, ending today
+//   - words: The maximum number of top words
+//
+// Returns:
+//   - Statistics: The aggregated statistics
+//   - error: Any error encountered during data retrieval
+func (r *reportService) Statistics(days int, words int) (Statistics, error) {
+	stats := Statistics{
+		TotalUsers:  global.UserCount,
+		PerCategory: map[string]int{"Positif": 0, "Netral": 0, "Negatif": 0},
+		PerDay:      make([]DailyCount, days),
+		TopWords:    []WordCount{},
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	index := make(map[string]int)
+	for i := range stats.PerDay {
+		date := today.AddDate(0, 0, i-days+1).Format("2006-01-02")
+		stats.PerDay[i] = DailyCount{Date: date, PerCategory: map[string]int{"Positif": 0, "Netral": 0, "Negatif": 0}}
+		index[date] = i
+	}
+
+	wordCounts := make(map[string]int)
+	err := r.commentRepo.ForEachComment(func(c model.Comment) bool {
+		stats.TotalComments++
+		stats.PerCategory[c.Kategori]++
+		countWords(c.Komentar, wordCounts)
+
+		if i, ok := index[c.CreatedAt.In(now.Location()).Format("2006-01-02")]; ok {
+			stats.PerDay[i].Total++
+			stats.PerDay[i].PerCategory[c.Kategori]++
+		}
+		return true
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	for _, item := range topItems(wordCounts, words) {
+		stats.TopWords = append(stats.TopWords, WordCount{Word: item.Name, Count: item.Count})
+	}
+
+	stats.Length, err = r.commentRepo.Comments().LengthStats()
+	if err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// topItems returns the n entries with the highest counts, ties broken alphabetically.
+//
+// Parameters:
+//   - counts: The counts to rank
+//   - n: The maximum number of entries to return
+//
+// Returns:
+//   - []rankedItem: The ranked entries, highest count first
+func topItems(counts map[string]int, n int) []rankedItem {
+	items := make([]rankedItem, 0, len(counts))
+	for name, count := range counts {
+		items = append(items, rankedItem{Name: name, Count: count})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	if len(items) > n {
+		items = items[:n]
+	}
+
+	return items
+}
+
+// formatSummary renders a period summary as a plain-text report.
+//
+// Parameters:
+//   - heading: The report heading (e.g. "LAPORAN BULANAN")
+//   - summary: The aggregated statistics to render
+//
+// Returns:
+//   - string: The formatted report
+func formatSummary(heading string, summary periodSummary) string {
+	var b strings.Builder
+
+	line := strings.Repeat("=", 40)
+	fmt.Fprintln(&b, line)
+	fmt.Fprintf(&b, "%s - %s\n", heading, summary.Title)
+	fmt.Fprintln(&b, "Aplikasi Analisis Sentimen - Kelompok 2")
+	fmt.Fprintln(&b, line)
+	fmt.Fprintf(&b, "Jumlah Komentar : %d\n\n", summary.Total)
+
+	fmt.Fprintln(&b, "Kategori:")
+	for _, kategori := range []string{"Positif", "Netral", "Negatif"} {
+		count := summary.Kategori[kategori]
+		fmt.Fprintf(&b, "  %-8s %4d (%5.1f%%)\n", kategori, count, percentage(count, summary.Total))
+	}
+
+	fmt.Fprintln(&b, "\nUser Teraktif:")
+	for i, item := range summary.TopUsers {
+		fmt.Fprintf(&b, "  %d. %s (%d komentar)\n", i+1, item.Name, item.Count)
+	}
+
+	fmt.Fprintln(&b, "\nKata Terbanyak:")
+	for i, item := range summary.TopWords {
+		fmt.Fprintf(&b, "  %d. %s (%dx)\n", i+1, item.Name, item.Count)
+	}
+
+	fmt.Fprintf(&b, "\nDibuat pada %s\n", time.Now().Format("2006-01-02 15:04:05"))
+
+	return b.String()
+}
+
+// KirimLaporan e-mails a summary report to the configured recipients right away.
+//
+// The function workflow:
+// 1. Checks that SMTP is configured, otherwise returns an error
+// 2. Asks for the period: Mingguan (last 7 days) or Bulanan (current month so far)
+// 3. Builds the summary, formats it with formatSummary and sends it via the mail service
+//
+// Returns:
+//   - error: Configuration, data retrieval or delivery errors
+func (r *reportService) KirimLaporan() error {
+	helper.Header("MENU > ADMIN > LAPORAN > KIRIM LAPORAN", "KIRIM LAPORAN")
+
+	if !r.mail.IsConfigured() {
+		return fmt.Errorf("SMTP belum dikonfigurasi (SMTP_HOST, SMTP_FROM, REPORT_RECIPIENTS)")
+	}
+
+	color.Cyan("Penerima: %s", strings.Join(recipients(), ", "))
+	if schedule := helper.GetEnv("REPORT_SCHEDULE", ""); schedule != "" {
+		color.Cyan("Jadwal otomatis: %s", schedule)
+	}
+
+	periodPrompt := promptui.Select{
+		Label: "Pilih Periode",
+		Items: []string{"Mingguan", "Bulanan"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, period, err := periodPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -7)
+	if period == "Bulanan" {
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+
+	err = r.sendReport(period, from, now)
+	if err != nil {
+		return err
+	}
+
+	color.Green("Laporan %s berhasil dikirim!", strings.ToLower(period))
+	helper.Pause()
+
+	return nil
+}
+
+// StartSchedule starts the background delivery of the summary report by e-mail.
+//
+// Nothing happens unless SMTP is configured and REPORT_SCHEDULE is "weekly" or "monthly".
+// A goroutine checks every hour whether a report is due, based on the time of the last
+// delivery stored in REPORT_STATE_FILE:
+//   - weekly: the last 7 days are sent when 7 days have passed since the last delivery
+//   - monthly: the previous month is sent once a new month has started
+//
+// The first check only records the current time, so enabling the schedule does not
+// send a report immediately. Failed deliveries are retried on the next check.
+func (r *reportService) StartSchedule() {
+	schedule := helper.GetEnv("REPORT_SCHEDULE", "")
+	if (schedule != "weekly" && schedule != "monthly") || !r.mail.IsConfigured() {
+		return
+	}
+
+	go func() {
+		for {
+			r.sendScheduled(schedule, time.Now())
+			time.Sleep(time.Hour)
+		}
+	}()
+}
+
+// sendScheduled sends the scheduled report if it is due.
+//
+// Parameters:
+//   - schedule: The configured schedule ("weekly" or "monthly")
+//   - now: The current time
+func (r *reportService) sendScheduled(schedule string, now time.Time) {
+	statePath := helper.GetEnv("REPORT_STATE_FILE", "report_schedule.txt")
+
+	data, err := os.ReadFile(statePath)
+	last, parseErr := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil || parseErr != nil {
+		_ = os.WriteFile(statePath, []byte(now.Format(time.RFC3339)), 0644)
+		return
+	}
+
+	switch schedule {
+	case "weekly":
+		if now.Sub(last) < 7*24*time.Hour {
+			return
+		}
+		err = r.sendReport("Mingguan", now.AddDate(0, 0, -7), now)
+	case "monthly":
+		if now.Year() == last.Year() && now.Month() == last.Month() {
+			return
+		}
+		to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		err = r.sendReport("Bulanan", to.AddDate(0, -1, 0), to)
+	}
+
+	if err == nil {
+		_ = os.WriteFile(statePath, []byte(now.Format(time.RFC3339)), 0644)
+	}
+}
+
+// sendReport builds the summary of [from, to) and e-mails it.
+//
+// Parameters:
+//   - period: The period name used in the subject ("Mingguan" or "Bulanan")
+//   - from: The start of the period (inclusive)
+//   - to: The end of the period (exclusive)
+//
+// Returns:
+//   - error: Data retrieval or delivery errors
+func (r *reportService) sendReport(period string, from, to time.Time) error {
+	summary, err := r.summarize(from, to)
+	if err != nil {
+		return err
+	}
+	summary.Title = from.Format("02/01/2006") + " - " + to.Format("02/01/2006")
+
+	heading := "LAPORAN " + strings.ToUpper(period)
+	return r.mail.Send("[Analisis Sentimen] Laporan "+period+" "+summary.Title, formatSummary(heading, summary))
+}
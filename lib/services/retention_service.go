@@ -0,0 +1,145 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+
+	"tugas-besar/lib/global"
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// RetentionService defines the interface for the retention policy.
+// Comments older than RETENTION_DAYS are archived or deleted when the app starts.
+type RetentionService interface {
+	// Expire removes the comments older than the retention period and reports them.
+	Expire() error
+}
+
+// retentionService implements the RetentionService interface.
+type retentionService struct {
+	commentRepo repository.CommentRepository
+	tx          repository.TransactionRepository
+}
+
+// NewRetentionService creates and returns a new RetentionService implementation.
+//
+// Parameters:
+//   - commentRepo: The CommentRepository implementation used to read and delete comments
+//   - tx: The TransactionRepository implementation that makes the expiry all-or-nothing
+//
+// Returns:
+//   - RetentionService: A new instance of the retentionService implementation
+func NewRetentionService(commentRepo repository.CommentRepository, tx repository.TransactionRepository) RetentionService {
+	return &retentionService{
+		commentRepo: commentRepo,
+		tx:          tx,
+	}
+}
+
+// Expire applies the retention policy.
+//
+// The policy is configured with environment variables:
+//   - RETENTION_DAYS: The number of days a comment is kept, 0 disables the policy
+//   - RETENTION_ACTION: "archive" appends the expired comments to RETENTION_ARCHIVE_FILE
+//     (default "arsip_komentar.csv") before deleting them, "delete" only deletes them
+//
+// The function workflow:
+//  1. Collects the comments created before the retention period
+//  2. Deletes them and, when archiving, appends them to the archive file in a single
+//     transaction, so nothing is deleted if the archive cannot be written
+//  3. Displays a report of the expired comments and waits for the user to press Enter
+//
+// Nothing is displayed when the policy is disabled or no comment has expired.
+//
+// Returns:
+//   - error: An error if the configuration is invalid or the expiry fails
+func (r *retentionService) Expire() error {
+	days, err := strconv.Atoi(helper.GetEnv("RETENTION_DAYS", "0"))
+	if err != nil || days < 0 {
+		return fmt.Errorf("RETENTION_DAYS harus berupa angka positif")
+	}
+
+	if days == 0 {
+		return nil
+	}
+
+	action := helper.GetEnv("RETENTION_ACTION", "archive")
+	if action != "archive" && action != "delete" {
+		return fmt.Errorf("RETENTION_ACTION harus archive atau delete, bukan %q", action)
+	}
+
+	var comments [255]model.Comment
+	err = r.commentRepo.GetAllComments(&comments)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	var expired []model.Comment
+	for i := 0; i < global.CommentCount; i++ {
+		if comments[i].Komentar != "" && comments[i].CreatedAt.Before(cutoff) {
+			expired = append(expired, comments[i])
+		}
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+
+	archive := helper.GetEnv("RETENTION_ARCHIVE_FILE", "arsip_komentar.csv")
+	err = r.tx.Run(func() error {
+		for _, comment := range expired {
+			err := r.commentRepo.DeleteComment(comment.Id)
+			if err != nil {
+				return err
+			}
+		}
+
+		if action == "delete" {
+			return nil
+		}
+
+		rows := make([][]string, len(expired))
+		for i, comment := range expired {
+			rows[i] = []string{
+				strconv.Itoa(comment.Id),
+				comment.Uuid,
+				strconv.Itoa(comment.UserId),
+				strconv.Itoa(comment.ProjectId),
+				comment.Komentar,
+				comment.Kategori,
+				comment.Sumber,
+				comment.CreatedAt.Format(time.RFC3339),
+			}
+		}
+
+		return helper.AppendCSV(archive, []string{"id", "uuid", "user_id", "project_id", "komentar", "kategori", "sumber", "created_at"}, rows)
+	})
+	if err != nil {
+		return fmt.Errorf("retensi gagal, tidak ada komentar yang dihapus: %v", err)
+	}
+
+	helper.Header("RETENSI", "RETENSI")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori", "Dibuat"})
+	for i, comment := range expired {
+		t.AppendRow(table.Row{i + 1, comment.Id, comment.Komentar, comment.Kategori, comment.CreatedAt.Format("2006-01-02")})
+	}
+	t.Render()
+
+	if action == "archive" {
+		color.Green("%d komentar lebih dari %d hari diarsipkan ke %s", len(expired), days, archive)
+	} else {
+		color.Green("%d komentar lebih dari %d hari dihapus", len(expired), days)
+	}
+	helper.Pause()
+
+	return nil
+}
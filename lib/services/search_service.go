@@ -0,0 +1,221 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// searchLimit is the maximum number of users and of comments listed by a global search.
+const searchLimit = 10
+
+// SearchService defines the interface for the global search of the main menu.
+type SearchService interface {
+	// Cari searches the users and the comments at once, shows the results grouped per
+	// type and opens the detail of a chosen result.
+	Cari() error
+}
+
+// searchService implements the SearchService interface.
+type searchService struct {
+	userService UserService
+	commentRepo repository.CommentRepository
+}
+
+// NewSearchService creates and returns a new SearchService implementation.
+//
+// Parameters:
+//   - userService: The UserService implementation used to find users
+//   - commentRepo: The CommentRepository implementation used to find comments
+//
+// Returns:
+//   - SearchService: A new instance of the searchService implementation
+func NewSearchService(userService UserService, commentRepo repository.CommentRepository) SearchService {
+	return &searchService{
+		userService: userService,
+		commentRepo: commentRepo,
+	}
+}
+
+// Cari handles the global search.
+//
+// The function workflow:
+//  1. Clears the screen and prompts for a keyword
+//  2. Finds the users whose username contains the keyword and the comments of the active
+//     project ranked by RankSearch, at most searchLimit of each
+//  3. Renders the users and the comments in separate tables
+//  4. Lets the user open the detail of a result, which returns to the results afterwards,
+//     search again (helper.ErrContinue) or go back (helper.ErrBack)
+//
+// Returns:
+//   - error: Storage errors or user navigation commands (helper.ErrBack, helper.ErrContinue)
+func (s *searchService) Cari() error {
+	helper.Header("MENU > CARI", "CARI")
+
+	prompt := promptui.Prompt{
+		Label: "Kata kunci",
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return fmt.Errorf("kata kunci tidak boleh kosong")
+			}
+
+			return nil
+		},
+	}
+
+	keyword, err := prompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+	keyword = strings.TrimSpace(keyword)
+
+	var users []model.User
+	err = s.userService.ForEachUser(func(user model.User) bool {
+		if user.DeletedAt.IsZero() && strings.Contains(strings.ToLower(user.Username), strings.ToLower(keyword)) {
+			users = append(users, user)
+		}
+		return len(users) < searchLimit
+	})
+	if err != nil {
+		return err
+	}
+
+	var results [255]model.SearchResult
+	n, err := s.commentRepo.RankSearch(keyword, &results)
+	if err != nil {
+		return err
+	}
+	n = min(n, searchLimit)
+
+	for {
+		helper.Header("MENU > CARI", "CARI")
+		color.Cyan("Hasil untuk \"%s\": %d user, %d komentar", keyword, len(users), n)
+
+		var items []string
+
+		u := helper.NewTable()
+		u.SetTitle("User")
+		u.AppendHeader(table.Row{"#", "Username", "Terdaftar"})
+		for i, user := range users {
+			u.AppendRow(table.Row{i + 1, user.Username, user.CreatedAt.Format("2006-01-02")})
+			items = append(items, "User: "+user.Username)
+		}
+		u.Render()
+
+		c := helper.NewTable()
+		c.SetTitle("Komentar")
+		c.AppendHeader(table.Row{"#", "Id", "Komentar", "Kategori"})
+		for i := 0; i < n; i++ {
+			comment := results[i].Comment
+			c.AppendRow(table.Row{i + 1, comment.Id, helper.Truncate(comment.Komentar, 50), comment.Kategori})
+			items = append(items, fmt.Sprintf("Komentar #%d: %s", comment.Id, helper.Truncate(comment.Komentar, 40)))
+		}
+		c.Render()
+
+		selectPrompt := promptui.Select{
+			Label: "Buka Detail",
+			Items: append(items, "Cari Lagi", "Kembali"),
+			Templates: &promptui.SelectTemplates{
+				Label:    "{{ . | blue }}:",
+				Active:   "\u27A1 {{ . | cyan }}",
+				Inactive: "  {{ . | cyan }}",
+				Selected: "\u2705 {{ . | blue | cyan }}",
+			},
+		}
+
+		index, choice, err := selectPrompt.Run()
+		if err != nil || choice == "Kembali" {
+			return helper.ErrBack
+		}
+
+		switch {
+		case choice == "Cari Lagi":
+			return helper.ErrContinue
+		case index < len(users):
+			err = s.userDetail(users[index])
+		default:
+			err = s.commentDetail(results[index-len(users)].Comment)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// userDetail displays a user with the number of comments per category and their
+// comments in the active project, and waits for user input.
+//
+// Parameters:
+//   - user: The user to display
+//
+// Returns:
+//   - error: Any error encountered during data retrieval
+func (s *searchService) userDetail(user model.User) error {
+	var comments [255]model.Comment
+	n, err := s.commentRepo.Comments().WhereUser(user.Id).OrderBy("created_at", repository.Desc).Find(&comments)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		counts[comments[i].Kategori]++
+	}
+
+	helper.Header("MENU > CARI > DETAIL USER", "DETAIL USER")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Username", "Terdaftar", "Komentar", "Positif", "Netral", "Negatif"})
+	t.AppendRow(table.Row{user.Username, user.CreatedAt.Format("2006-01-02"), n, counts["Positif"], counts["Netral"], counts["Negatif"]})
+	t.Render()
+
+	c := helper.NewTable()
+	c.SetTitle("Komentar Terbaru")
+	c.AppendHeader(table.Row{"Id", "Komentar", "Kategori", "Dibuat"})
+	for i := 0; i < min(n, searchLimit); i++ {
+		c.AppendRow(table.Row{comments[i].Id, helper.Truncate(comments[i].Komentar, 50), comments[i].Kategori, comments[i].CreatedAt.Format("2006-01-02")})
+	}
+	c.Render()
+
+	helper.Pause()
+
+	return nil
+}
+
+// commentDetail displays a comment with its author, category and status, and waits for
+// user input. The internal admin note is not shown.
+//
+// Parameters:
+//   - comment: The comment to display
+//
+// Returns:
+//   - error: Always nil, kept for symmetry with userDetail
+func (s *searchService) commentDetail(comment model.Comment) error {
+	author := "admin"
+	s.userService.ForEachUser(func(user model.User) bool {
+		if user.Id == comment.UserId {
+			author = user.Username
+			return false
+		}
+		return true
+	})
+
+	helper.Header("MENU > CARI > DETAIL KOMENTAR", "DETAIL KOMENTAR")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Id", "User", "Kategori", "Status", "Dibuat"})
+	t.AppendRow(table.Row{comment.Id, author, comment.Kategori, repository.StatusOf(comment), comment.CreatedAt.Format("2006-01-02 15:04")})
+	t.Render()
+
+	fmt.Println(comment.Komentar)
+	helper.Pause()
+
+	return nil
+}
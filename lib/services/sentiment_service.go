@@ -0,0 +1,261 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// SentimentService defines the interface for automatic sentiment classification.
+// It predicts the sentiment category of a comment based on its text.
+type SentimentService interface {
+	// Analyze predicts the sentiment category (Positif, Netral, or Negatif) of a text.
+	// It also returns the raw lexicon score: positive words add to the score,
+	// negative words subtract from it.
+	Analyze(text string) (kategori string, score int)
+
+	// Confidence estimates how sure the analyzer is about its prediction for a text,
+	// from 0 (no lexicon words or evenly mixed signals) up towards 1.
+	Confidence(text string) float64
+}
+
+// sentimentService implements the SentimentService interface using a
+// small Indonesian sentiment lexicon.
+type sentimentService struct {
+	positive map[string]bool
+	negative map[string]bool
+	negation map[string]bool
+}
+
+// NewSentimentService creates and returns a new SentimentService implementation
+// backed by the built-in Indonesian lexicon. Entries of two words, such as "luar biasa",
+// only match as a phrase, see score.
+//
+// Returns:
+//   - SentimentService: A new instance of the sentimentService implementation
+func NewSentimentService() SentimentService {
+	return &sentimentService{
+		positive: toSet([]string{
+			"bagus", "baik", "mantap", "keren", "suka", "senang", "puas", "cepat", "murah",
+			"ramah", "hebat", "enak", "indah", "mudah", "membantu", "recommended", "rekomendasi",
+			"terbaik", "top", "oke", "ok", "sukses", "nyaman", "rapi", "bersih", "lancar",
+			"memuaskan", "berguna", "bermanfaat", "luar biasa", "cinta", "love", "good",
+			"great", "nice", "best", "terima kasih", "makasih", "sip", "jos", "asik", "seru",
+		}),
+		negative: toSet([]string{
+			"buruk", "jelek", "lambat", "lemot", "mahal", "kecewa", "kesal", "marah", "benci",
+			"rusak", "error", "gagal", "susah", "sulit", "ribet", "parah", "payah", "bohong",
+			"penipu", "tipu", "kotor", "kasar", "mengecewakan", "lelet", "sampah", "bug",
+			"hilang", "telat", "terlambat", "bad", "worst", "hate", "sedih", "capek", "bosan",
+			"nyesel", "menyesal", "rugi", "zonk", "hancur", "aneh", "males", "malas",
+		}),
+		negation: toSet([]string{
+			"tidak", "tak", "bukan", "belum", "kurang", "gak", "ga", "nggak", "enggak", "ngga", "jangan",
+		}),
+	}
+}
+
+// Analyze predicts the sentiment category of a text.
+//
+// The text is lowercased and split into words on every non-letter character.
+// Each word found in the positive lexicon adds one point, each word found in the
+// negative lexicon subtracts one point. Two-word phrases of the lexicon ("luar biasa",
+// "terima kasih") count once as a whole, so "biasa saja" does not score. A negation word ("tidak", "bukan", "kurang", ...)
+// flips the polarity of the word that follows it, so "tidak bagus" counts as negative.
+//
+// Parameters:
+//   - text: The comment text to classify
+//
+// Returns:
+//   - kategori: "Positif" if the score is above zero, "Negatif" if below zero, "Netral" otherwise
+//   - score: The lexicon score of the text
+func (s *sentimentService) Analyze(text string) (string, int) {
+	score, _ := s.score(text)
+
+	if score > 0 {
+		return "Positif", score
+	}
+
+	if score < 0 {
+		return "Negatif", score
+	}
+
+	return "Netral", score
+}
+
+// Confidence estimates how sure the analyzer is about its prediction for a text.
+//
+// The confidence is the absolute lexicon score divided by the number of lexicon words
+// plus one, so a text without any lexicon word scores 0, a text whose positive and
+// negative words cancel out scores 0, and a text with many words of the same polarity
+// approaches 1.
+//
+// Parameters:
+//   - text: The comment text to classify
+//
+// Returns:
+//   - float64: The confidence in the range 0 up to (but not including) 1
+func (s *sentimentService) Confidence(text string) float64 {
+	score, hits := s.score(text)
+	if score < 0 {
+		score = -score
+	}
+
+	return float64(score) / float64(hits+1)
+}
+
+// score computes the lexicon score of a text as described in Analyze.
+//
+// Parameters:
+//   - text: The comment text to score
+//
+// Returns:
+//   - int: The lexicon score of the text
+//   - int: The number of words found in the positive or negative lexicon
+func (s *sentimentService) score(text string) (int, int) {
+	words := tokenize(text)
+
+	score := 0
+	hits := 0
+	negate := false
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if i+1 < len(words) {
+			phrase := word + " " + words[i+1]
+			if s.positive[phrase] || s.negative[phrase] {
+				word = phrase
+				i++
+			}
+		}
+
+		if s.negation[word] {
+			negate = true
+			continue
+		}
+
+		value := 0
+		if s.positive[word] {
+			value = 1
+		} else if s.negative[word] {
+			value = -1
+		}
+
+		if value != 0 {
+			hits++
+		}
+
+		if negate {
+			value = -value
+			negate = false
+		}
+
+		score += value
+	}
+
+	return score, hits
+}
+
+// toSet converts a list of words into a set for constant-time lookups.
+//
+// Parameters:
+//   - words: The words to put in the set
+//
+// Returns:
+//   - map[string]bool: A map with every word as a key set to true
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+
+	for _, word := range words {
+		set[word] = true
+	}
+
+	return set
+}
+
+// tokenize lowercases a text and splits it into words on every non-letter character.
+//
+// Parameters:
+//   - text: The text to split
+//
+// Returns:
+//   - []string: The words of the text
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// naiveBayes is a multinomial Naive Bayes classifier trained on labeled comments.
+// Unlike the lexicon analyzer it learns from the stored data, which is what makes it
+// possible to evaluate it with cross-validation.
+type naiveBayes struct {
+	docs   map[string]int
+	words  map[string]map[string]int
+	totals map[string]int
+	vocab  map[string]bool
+	n      int
+}
+
+// newNaiveBayes creates an untrained Naive Bayes classifier.
+//
+// Returns:
+//   - *naiveBayes: A classifier without any training data
+func newNaiveBayes() *naiveBayes {
+	return &naiveBayes{
+		docs:   map[string]int{},
+		words:  map[string]map[string]int{},
+		totals: map[string]int{},
+		vocab:  map[string]bool{},
+	}
+}
+
+// Train adds one labeled text to the classifier.
+//
+// Parameters:
+//   - text: The comment text
+//   - label: The category of the comment
+func (nb *naiveBayes) Train(text, label string) {
+	if nb.words[label] == nil {
+		nb.words[label] = map[string]int{}
+	}
+
+	nb.docs[label]++
+	nb.n++
+	for _, word := range tokenize(text) {
+		nb.words[label][word]++
+		nb.totals[label]++
+		nb.vocab[word] = true
+	}
+}
+
+// Predict returns the most likely category of a text using Laplace smoothing.
+//
+// Parameters:
+//   - text: The comment text to classify
+//
+// Returns:
+//   - string: The predicted category, empty if the classifier has not been trained
+func (nb *naiveBayes) Predict(text string) string {
+	words := tokenize(text)
+
+	best := ""
+	bestScore := math.Inf(-1)
+	for _, label := range []string{"Positif", "Netral", "Negatif"} {
+		if nb.docs[label] == 0 {
+			continue
+		}
+
+		score := math.Log(float64(nb.docs[label]) / float64(nb.n))
+		for _, word := range words {
+			score += math.Log(float64(nb.words[label][word]+1) / float64(nb.totals[label]+len(nb.vocab)))
+		}
+
+		if score > bestScore {
+			best = label
+			bestScore = score
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/repository"
+)
+
+// SettingsService defines the interface for the runtime settings.
+// Settings are read from the environment, can be changed from the "Pengaturan" screen
+// and are persisted to the .env file so they survive a restart.
+type SettingsService interface {
+	// Pengaturan displays the settings screen and lets the admin change a setting.
+	Pengaturan() error
+
+	// Apply applies the settings that take effect immediately, such as the theme.
+	Apply()
+
+	// StartBackup starts the background job that copies the journal to the backup
+	// directory every BACKUP_INTERVAL minutes.
+	StartBackup()
+}
+
+// setting describes a single runtime setting.
+type setting struct {
+	// Key is the environment variable that stores the setting.
+	Key string
+
+	// Label is the name shown on the settings screen.
+	Label string
+
+	// Default is the value used when the variable is not set.
+	Default string
+
+	// Options lists the allowed values; an empty list means a non-negative number.
+	Options []string
+
+	// Description explains the setting on the settings screen.
+	Description string
+}
+
+// settings lists every runtime setting that can be changed in the app.
+var settings = []setting{
+	{Key: "THEME", Label: "Tema", Default: "warna", Options: []string{"warna", "mono"}, Description: "mono mematikan warna di terminal"},
+	{Key: "LANGUAGE", Label: "Bahasa", Default: "id", Options: []string{"id", "en"}, Description: "bahasa antarmuka"},
+	{Key: "TABLE_STYLE", Label: "Gaya Tabel", Default: "warna", Options: []string{"warna", "kompak", "polos"}, Description: "kompak = garis tipis tanpa jarak, polos = tanpa garis"},
+	{Key: "PAGE_SIZE", Label: "Ukuran Halaman", Default: "10", Description: "jumlah baris per halaman tabel"},
+	{Key: "USER_DELETE_GRACE_HOURS", Label: "Masa Pulih User", Default: "24", Description: "jam sebelum user yang dihapus hilang permanen"},
+	{Key: "PAUSE_TIMEOUT", Label: "Jeda Otomatis", Default: "0", Description: "detik sebelum layar lanjut sendiri, 0 = tunggu Enter"},
+	{Key: "BACKUP_INTERVAL", Label: "Interval Backup", Default: "0", Description: "menit antar backup journal, 0 = mati"},
+	{Key: "LOCK_MINUTES", Label: "Kunci Layar", Default: "0", Description: "menit tanpa aktivitas sebelum layar dikunci, 0 = mati"},
+}
+
+// settingsService implements the SettingsService interface.
+type settingsService struct {
+	path    string
+	journal repository.JournalRepository
+}
+
+// NewSettingsService creates and returns a new SettingsService implementation.
+//
+// Parameters:
+//   - path: The location of the .env file the settings are persisted to
+//   - journal: The JournalRepository implementation that is backed up periodically
+//
+// Returns:
+//   - SettingsService: A new instance of the settingsService implementation
+func NewSettingsService(path string, journal repository.JournalRepository) SettingsService {
+	return &settingsService{
+		path:    path,
+		journal: journal,
+	}
+}
+
+// Pengaturan displays the settings screen.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and a table of every setting with its
+//     current value and description
+//  2. Asks which setting to change
+//  3. Prompts for the new value: a selection for settings with fixed options,
+//     a number input otherwise
+//  4. Sets the variable for the running process, persists it to the .env file and
+//     applies it
+//
+// Returns:
+//   - error: helper.ErrContinue after a setting was changed, helper.ErrBack when the admin leaves,
+//     or an error if the .env file cannot be written
+func (s *settingsService) Pengaturan() error {
+	helper.Header("MENU > ADMIN > PENGATURAN", "PENGATURAN")
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Pengaturan", "Nilai", "Keterangan"})
+	items := make([]string, 0, len(settings)+1)
+	for _, item := range settings {
+		t.AppendRow(table.Row{item.Label, helper.GetEnv(item.Key, item.Default), item.Description})
+		items = append(items, item.Label)
+	}
+	t.Render()
+
+	items = append(items, "Exit")
+
+	prompt := promptui.Select{
+		Label: "Ubah Pengaturan",
+		Items: items,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	index, _, err := prompt.Run()
+	if err != nil || index == len(settings) {
+		return helper.ErrBack
+	}
+
+	item := settings[index]
+
+	var value string
+	if len(item.Options) > 0 {
+		valuePrompt := promptui.Select{
+			Label: item.Label,
+			Items: item.Options,
+			Templates: &promptui.SelectTemplates{
+				Label:    "{{ . | blue }}:",
+				Active:   "\u27A1 {{ . | cyan }}",
+				Inactive: "  {{ . | cyan }}",
+				Selected: "\u2705 {{ . | blue | cyan }}",
+			},
+		}
+
+		_, value, err = valuePrompt.Run()
+	} else {
+		valuePrompt := promptui.Prompt{
+			Label:   item.Label,
+			Default: helper.GetEnv(item.Key, item.Default),
+			Validate: func(input string) error {
+				number, err := strconv.Atoi(input)
+				if err != nil || number < 0 {
+					return fmt.Errorf("nilai harus berupa angka positif")
+				}
+
+				return nil
+			},
+		}
+
+		value, err = valuePrompt.Run()
+	}
+	if err != nil {
+		return helper.ErrContinue
+	}
+
+	err = s.save(item.Key, value)
+	if err != nil {
+		return err
+	}
+
+	s.Apply()
+
+	color.Green("%s diubah menjadi %s", item.Label, value)
+	helper.Pause()
+
+	return helper.ErrContinue
+}
+
+// save sets a variable for the running process and persists it to the .env file,
+// see helper.SaveEnv.
+//
+// Parameters:
+//   - key: The environment variable to set
+//   - value: The new value
+//
+// Returns:
+//   - error: An error if the .env file cannot be read or written
+func (s *settingsService) save(key, value string) error {
+	return helper.SaveEnv(s.path, key, value)
+}
+
+// Apply applies the settings that take effect immediately.
+// The "mono" theme disables colored output.
+func (s *settingsService) Apply() {
+	color.NoColor = helper.GetEnv("THEME", "warna") == "mono"
+}
+
+// StartBackup starts the background journal backup.
+//
+// A goroutine checks every minute whether BACKUP_INTERVAL minutes have passed since the
+// last backup and, if so, copies the journal into BACKUP_DIR (default "backup"). The
+// interval is read on every check, so changing it on the settings screen takes effect
+// without a restart; an interval of 0 disables the backups.
+func (s *settingsService) StartBackup() {
+	go func() {
+		last := time.Now()
+
+		for range time.Tick(time.Minute) {
+			interval, err := strconv.Atoi(helper.GetEnv("BACKUP_INTERVAL", "0"))
+			if err != nil || interval <= 0 || time.Since(last) < time.Duration(interval)*time.Minute {
+				continue
+			}
+
+			_, err = s.journal.Backup(helper.GetEnv("BACKUP_DIR", "backup"))
+			if err == nil {
+				last = time.Now()
+			}
+		}
+	}()
+}
+
+// PageSize returns the configured number of rows per table page.
+//
+// Returns:
+//   - int: The PAGE_SIZE setting, 10 when it is missing or invalid
+func PageSize() int {
+	size, err := strconv.Atoi(helper.GetEnv("PAGE_SIZE", "10"))
+	if err != nil || size < 1 {
+		return 10
+	}
+
+	return size
+}
+
+// pageCount returns the number of table pages needed for a number of rows.
+//
+// Parameters:
+//   - total: The number of rows
+//
+// Returns:
+//   - int: The number of pages, at least 1 so an empty table still has a page
+func pageCount(total int) int {
+	return max(1, (total+PageSize()-1)/PageSize())
+}
+
+// pageItems adds the "Halaman Berikutnya" and "Halaman Sebelumnya" menu keys in front of
+// the last key (Exit) when there is a page to move to.
+//
+// Parameters:
+//   - keys: The menu keys, ending with Exit
+//   - page: The current page, starting at 0
+//   - pages: The number of pages
+//
+// Returns:
+//   - []string: The menu keys including the page navigation
+func pageItems(keys []string, page int, pages int) []string {
+	items := append([]string{}, keys[:len(keys)-1]...)
+	if page < pages-1 {
+		items = append(items, "Halaman Berikutnya")
+	}
+	if page > 0 {
+		items = append(items, "Halaman Sebelumnya")
+	}
+
+	return append(items, keys[len(keys)-1])
+}
+
+// turnPage moves the page when a page navigation key was selected.
+//
+// Parameters:
+//   - key: The selected menu key
+//   - page: A pointer to the current page, starting at 0
+//
+// Returns:
+//   - bool: true if key was a page navigation key
+func turnPage(key string, page *int) bool {
+	switch key {
+	case "Halaman Berikutnya":
+		*page++
+	case "Halaman Sebelumnya":
+		*page = max(0, *page-1)
+	default:
+		return false
+	}
+
+	return true
+}
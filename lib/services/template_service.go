@@ -0,0 +1,201 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/manifoldco/promptui"
+
+	"tugas-besar/lib/helper"
+	"tugas-besar/lib/model"
+	"tugas-besar/lib/repository"
+)
+
+// TemplateService defines the interface for managing the comment templates.
+// Templates are reusable comment texts, e.g. survey prompts, offered in CreateCommentForm.
+type TemplateService interface {
+	// TemplateMenu lists the templates and lets the admin add or delete one.
+	TemplateMenu() error
+}
+
+// templateService implements the TemplateService interface.
+type templateService struct {
+	templateRepo repository.TemplateRepository
+}
+
+// NewTemplateService creates and returns a new TemplateService implementation.
+//
+// Parameters:
+//   - templateRepo: The TemplateRepository implementation used to store the templates
+//
+// Returns:
+//   - TemplateService: A new instance of the templateService implementation
+func NewTemplateService(templateRepo repository.TemplateRepository) TemplateService {
+	return &templateService{
+		templateRepo: templateRepo,
+	}
+}
+
+// TemplateMenu displays the comment templates and the template actions.
+//
+// The function workflow:
+//  1. Clears the screen, displays the header and a table of the templates
+//  2. Asks the admin what to do:
+//     - "Tambah": Prompts for the name, the text and the default category of a new template
+//     - "Hapus": Prompts for the ID of the template to delete and asks for confirmation
+//  3. Displays success message
+//
+// Returns:
+//   - error: helper.ErrContinue after an action so the menu is shown again, helper.ErrBack when the admin
+//     leaves, or a repository error
+func (s *templateService) TemplateMenu() error {
+	helper.Header("MENU > ADMIN > TEMPLATE", "TEMPLATE KOMENTAR")
+
+	var templates [255]model.Template
+	n, err := s.templateRepo.GetAllTemplates(&templates)
+	if err != nil {
+		return err
+	}
+
+	t := helper.NewTable()
+	t.AppendHeader(table.Row{"Id", "Nama", "Komentar", "Kategori"})
+	for i := 0; i < n; i++ {
+		t.AppendRow(table.Row{templates[i].Id, templates[i].Nama, templates[i].Komentar, templates[i].Kategori})
+	}
+	t.Render()
+
+	prompt := promptui.Select{
+		Label: "Pilih Menu",
+		Items: []string{"Tambah", "Hapus", "Exit"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, action, err := prompt.Run()
+	if err != nil || action == "Exit" {
+		return helper.ErrBack
+	}
+
+	switch action {
+	case "Tambah":
+		var template model.Template
+		err = s.templateForm(&template)
+		if err != nil {
+			return helper.ErrContinue
+		}
+
+		err = s.templateRepo.Create(&template)
+		if err != nil {
+			return err
+		}
+
+		color.Green("Template %s ditambahkan", template.Nama)
+
+	case "Hapus":
+		idPrompt := promptui.Prompt{
+			Label: "Id template",
+			Validate: func(input string) error {
+				_, err := strconv.Atoi(input)
+				if err != nil {
+					return fmt.Errorf("id harus berupa angka")
+				}
+
+				return nil
+			},
+		}
+
+		input, err := idPrompt.Run()
+		if err != nil {
+			return helper.ErrContinue
+		}
+
+		id, _ := strconv.Atoi(input)
+		name := ""
+		for i := 0; i < n; i++ {
+			if templates[i].Id == id {
+				name = templates[i].Nama
+			}
+		}
+
+		if name == "" {
+			return fmt.Errorf("template dengan id %d tidak ditemukan", id)
+		}
+
+		if !helper.ConfirmDelete(name) {
+			return helper.ErrContinue
+		}
+
+		err = s.templateRepo.DeleteTemplate(id)
+		if err != nil {
+			return err
+		}
+
+		color.Green("Template %s dihapus", name)
+	}
+
+	helper.Pause()
+
+	return helper.ErrContinue
+}
+
+// templateForm prompts for the name, the text and the default category of a template.
+//
+// Parameters:
+//   - template: Pointer to store the entered template
+//
+// Returns:
+//   - error: An error if any prompt is cancelled
+func (s *templateService) templateForm(template *model.Template) error {
+	notEmpty := func(input string) error {
+		if strings.TrimSpace(input) == "" {
+			return fmt.Errorf("tidak boleh kosong")
+		}
+
+		return nil
+	}
+
+	namaPrompt := promptui.Prompt{Label: "Nama", Validate: notEmpty}
+	komentarPrompt := promptui.Prompt{Label: "Komentar", Validate: notEmpty}
+	kategoriPrompt := promptui.Select{
+		Label: "Kategori",
+		Items: []string{"Tanpa Kategori", "Positif", "Netral", "Negatif"},
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	nama, err := namaPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	komentar, err := komentarPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	index, kategori, err := kategoriPrompt.Run()
+	if err != nil {
+		return err
+	}
+
+	if index == 0 {
+		kategori = ""
+	}
+
+	template.Nama = strings.TrimSpace(nama)
+	template.Komentar = komentar
+	template.Kategori = kategori
+
+	return nil
+}
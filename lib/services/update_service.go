@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"tugas-besar/lib/helper"
+)
+
+// UpdateService defines the interface for checking whether a newer release is available.
+type UpdateService interface {
+	// Start checks for a newer release in the background when UPDATE_CHECK is enabled
+	// and shows a notice in the main menu header when one is found.
+	Start()
+}
+
+// updateService implements the UpdateService interface using net/http.
+type updateService struct {
+	mainService MainService
+	client      *http.Client
+}
+
+// NewUpdateService creates and returns a new UpdateService implementation.
+//
+// Parameters:
+//   - mainService: The MainService implementation whose header shows the notice
+//
+// Returns:
+//   - UpdateService: A new instance of the updateService implementation
+func NewUpdateService(mainService MainService) UpdateService {
+	return &updateService{
+		mainService: mainService,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Start checks for a newer release in the background.
+//
+// The check is opt-in: it only runs when UPDATE_CHECK is "true". It requests UPDATE_URL
+// (the latest GitHub release of the project by default), which must answer a JSON object
+// with a "tag_name" (GitHub) or "version" field, and compares it with helper.Version.
+// Development builds (version "dev") are never reported as outdated. Network errors are
+// ignored so a missing connection never gets in the way of the application.
+func (u *updateService) Start() {
+	if helper.GetEnv("UPDATE_CHECK", "false") != "true" || helper.Version == "dev" {
+		return
+	}
+
+	go func() {
+		latest, err := u.latest()
+		if err != nil || compareVersions(latest, helper.Version) <= 0 {
+			return
+		}
+
+		u.mainService.SetNotice(fmt.Sprintf("Versi baru tersedia: %s (terpasang %s)", latest, helper.Version))
+	}()
+}
+
+// latest requests the newest released version.
+//
+// Returns:
+//   - string: The newest version, e.g. "v1.2.0"
+//   - error: An error if the request fails or the response has no version
+func (u *updateService) latest() (string, error) {
+	url := helper.GetEnv("UPDATE_URL", "https://api.github.com/repos/diamondver/tugas-akhir-alpro2/releases/latest")
+
+	resp, err := u.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("pemeriksaan update gagal: %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		Version string `json:"version"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&release)
+	if err != nil {
+		return "", err
+	}
+
+	if release.TagName != "" {
+		return release.TagName, nil
+	}
+
+	if release.Version != "" {
+		return release.Version, nil
+	}
+
+	return "", fmt.Errorf("respons update tidak berisi versi")
+}
+
+// compareVersions compares two dotted version numbers such as "v1.2.0" and "1.10".
+// A leading "v" and any suffix after "-" or "+" are ignored, missing parts count as 0.
+//
+// Parameters:
+//   - a: The first version
+//   - b: The second version
+//
+// Returns:
+//   - int: -1 if a is older than b, 1 if a is newer than b, 0 if they are equal
+func compareVersions(a, b string) int {
+	parse := func(version string) []int {
+		version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+		version, _, _ = strings.Cut(version, "-")
+		version, _, _ = strings.Cut(version, "+")
+
+		var parts []int
+		for _, part := range strings.Split(version, ".") {
+			n, _ := strconv.Atoi(part)
+			parts = append(parts, n)
+		}
+
+		return parts
+	}
+
+	pa, pb := parse(a), parse(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -1,6 +1,12 @@
 package services
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tugas-besar/lib/global"
 	"tugas-besar/lib/helper"
 	"tugas-besar/lib/model"
 	"tugas-besar/lib/repository"
@@ -27,12 +33,42 @@ type UserService interface {
 
 	// UserPage displays the user menu interface and captures the user's selection.
 	// It presents a menu with options for comment management (add/view/edit/delete)
-	// and stores the selected option in the provided parameter.
-	UserPage(chose *string) error
+	// and stores the selected option in the provided parameter. The header shows the
+	// previous login of the user.
+	UserPage(user model.User, chose *string) error
+
+	// ChangePassword asks the user for a new password and stores it. It is shown after
+	// login while the account still has a temporary password.
+	ChangePassword(user *model.User) error
+
+	// Unlock asks the password of the user again to unlock the idle lock.
+	// Returns an error if the password could not be asked, e.g. after Ctrl+C.
+	Unlock(user model.User) (bool, error)
+
+	// RecordLogin adds a successful or failed login attempt to the login history.
+	RecordLogin(username string, userId int, success bool) error
+
+	// LoginHistory retrieves the login attempts of a username, newest first, and returns
+	// the number of attempts. An empty username retrieves every attempt.
+	LoginHistory(username string, attempts *[255]model.LoginAttempt) (int, error)
+
+	// Impersonate marks the user menu as opened by the admin on behalf of username.
+	// In read-only mode only the menu items that do not change data are offered.
+	// An empty username ends the impersonation.
+	Impersonate(username string, readOnly bool)
 
 	// GetAllUsers retrieves all users stored in the system.
 	GetAllUsers(*[255]model.User) error
 
+	// CompleteUsername returns up to limit usernames that start with prefix.
+	CompleteUsername(prefix string, limit int) []string
+
+	// GetUsers retrieves one page of the users and returns the number of users copied.
+	GetUsers(offset int, limit int, users *[255]model.User) (int, error)
+
+	// ForEachUser calls fn for every user until fn returns false.
+	ForEachUser(fn func(user model.User) bool) error
+
 	// SearchUsers finds users whose usernames contain the search string.
 	SearchUsers(search string, users *[255]model.User) error
 
@@ -42,47 +78,108 @@ type UserService interface {
 
 	// DeleteUser removes a user from the system.
 	DeleteUser(id int) error
+
+	// MarkUserDeleted moves a user into the pending deletion state, see UserDeleteGrace.
+	MarkUserDeleted(index int) error
+
+	// RestoreUser restores a user that is pending deletion.
+	RestoreUser(index int) error
+
+	// PurgeDeletedUsers permanently removes the users whose grace period has passed and
+	// returns the number of users removed.
+	PurgeDeletedUsers() (int, error)
+
+	// SetUserRole changes the admin role of the user at the specified index.
+	SetUserRole(index int, role string) error
+
+	// AdminCount returns the number of users with the admin role that are not pending deletion.
+	AdminCount() int
 }
 
 // userService implements the UserService interface.
 // It acts as a service layer between the application and the repository.
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	loginRepo repository.LoginRepository
+
+	// impersonating is the username the admin opened the user menu as, empty if none
+	impersonating string
+
+	// readOnly hides the menu items that change data while impersonating
+	readOnly bool
+}
+
+// readOnlyUserMenu lists the user menu items that are offered in a read-only impersonation.
+var readOnlyUserMenu = map[string]bool{
+	"Lihat Komentar":     true,
+	"Komentar Terjadwal": true,
+	"Exit":               true,
 }
 
 // NewUserService creates and returns a new UserService implementation.
 //
 // Parameters:
 //   - userRepo: The user repository implementation to use for data operations
+//   - loginRepo: The login repository implementation holding the login history
 //
 // Returns:
 //   - UserService: A new instance of the userService implementation
-func NewUserService(userRepo repository.UserRepository) UserService {
+func NewUserService(userRepo repository.UserRepository, loginRepo repository.LoginRepository) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		loginRepo: loginRepo,
 	}
 }
 
 // UserPage displays the user menu interface and captures the user's selection.
 // It clears the screen, displays a formatted menu header, and presents
-// interactive options for comment management (add/view/edit/delete).
+// interactive options for comment management (add/rapid entry/view/reply/edit/delete/scheduled)
+// and second labeling.
 // The user's selection is stored in the provided parameter.
+// While the admin impersonates the user a red banner names the user, and in read-only
+// mode only the items in readOnlyUserMenu are offered. Below the header the previous
+// login of the user is shown, see lastLogin.
 //
 // Parameters:
+//   - user: The model.User representing the currently logged-in user
 //   - chose: A pointer to a string that will store the user's menu selection
 //
 // Returns:
 //   - error: An error if displaying the menu or capturing the selection fails, nil on success
-func (userService *userService) UserPage(chose *string) error {
-	helper.ClearScreen()
-	color.Yellow("* MENU > USER")
-	color.Yellow("========================================")
-	color.Yellow("=               MENU USER              =")
-	color.Yellow("========================================")
+func (userService *userService) UserPage(user model.User, chose *string) error {
+	helper.Header("MENU > USER", "MENU USER")
+
+	var attempts [255]model.LoginAttempt
+	n, err := userService.loginRepo.GetLoginsByUsername(user.Username, &attempts)
+	if err != nil {
+		return err
+	}
+	color.Cyan(lastLogin(attempts[:n], userService.impersonating == ""))
+
+	items := []string{"Tambah Komentar", "Input Cepat", "Lihat Komentar", "Balas Komentar", "Edit Komentar", "Delete Komentar", "Komentar Terjadwal", "Favorit", "Label Kedua", "Exit"}
+	if userService.impersonating != "" {
+		mode := "perubahan diizinkan"
+		if userService.readOnly {
+			mode = "hanya baca"
+
+			var allowed []string
+			for _, item := range items {
+				if readOnlyUserMenu[item] {
+					allowed = append(allowed, item)
+				}
+			}
+			items = allowed
+		}
+
+		color.Red("IMPERSONASI: admin sebagai %s (%s)", userService.impersonating, mode)
+	}
+
+	labels, keys := helper.MenuItems("user", items)
 
 	prompt := promptui.Select{
-		Label: "Pilih Menu",
-		Items: []string{"Tambah Komentar", "Lihat Komentar", "Edit Komentar", "Delete Komentar", "Exit"},
+		Label:     "Pilih Menu",
+		Items:     labels,
+		CursorPos: helper.MenuCursor("user", keys),
 		Templates: &promptui.SelectTemplates{
 			Label:    "{{ . | blue }}:",
 			Active:   "\u27A1 {{ . | cyan }}",
@@ -91,16 +188,189 @@ func (userService *userService) UserPage(chose *string) error {
 		},
 	}
 
-	_, result, err := prompt.Run()
+	index, _, err := prompt.Run()
+	if helper.LeaveMenu(keys, chose) {
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
 
-	*chose = result
+	*chose = keys[index]
+	helper.RememberMenu("user", keys[index])
 
 	return nil
 }
 
+// lastLogin describes the previous login of a user for the user menu header.
+//
+// Parameters:
+//   - attempts: The login attempts of the user, newest first
+//   - current: true when the newest successful attempt is the session being shown, so the
+//     one before it is described; false when the admin impersonates the user
+//
+// Returns:
+//   - string: The last-login line, including the failed attempts since that login
+func lastLogin(attempts []model.LoginAttempt, current bool) string {
+	failed := 0
+	for _, attempt := range attempts {
+		if !attempt.Success {
+			failed++
+			continue
+		}
+
+		if current {
+			current = false
+			failed = 0
+			continue
+		}
+
+		line := fmt.Sprintf("Login terakhir: %s", attempt.At.Format("2006-01-02 15:04"))
+		if failed > 0 {
+			line += fmt.Sprintf(" (%d percobaan gagal sejak itu)", failed)
+		}
+
+		return line
+	}
+
+	return "Login terakhir: belum pernah"
+}
+
+// ChangePassword lets a user replace a temporary password.
+//
+// The function workflow:
+//  1. Clears the screen and displays the header
+//  2. Prompts for the new password and its confirmation
+//     - If it is empty, equal to the temporary password or not confirmed: Returns helper.ErrContinue to ask again
+//  3. Stores the password, which clears MustChangePassword, and updates user
+//
+// Parameters:
+//   - user: Pointer to the logged-in user, updated with the new password
+//
+// Returns:
+//   - error: helper.ErrContinue to ask again, helper.ErrBack when the user cancels, or a storage error
+func (userService *userService) ChangePassword(user *model.User) error {
+	helper.Header("MENU > USER > GANTI PASSWORD", "GANTI PASSWORD")
+	color.Cyan("Akun ini memakai password sementara. Buat password baru untuk melanjutkan.")
+
+	passwordPrompt := promptui.Prompt{Label: "Password Baru", Mask: '*'}
+	confirmPasswordPrompt := promptui.Prompt{Label: "Confirm Password", Mask: '*'}
+
+	password, err := passwordPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	confirmPassword, err := confirmPasswordPrompt.Run()
+	if err != nil {
+		return helper.ErrBack
+	}
+
+	switch {
+	case strings.TrimSpace(password) == "":
+		color.Red("Password tidak boleh kosong")
+	case helper.CheckPasswordHash(password, user.Password):
+		color.Red("Password baru harus berbeda dari password sementara")
+	case password != confirmPassword:
+		color.Red("Password does not match")
+	default:
+		var users [255]model.User
+		err = userService.userRepo.GetAllUsers(&users)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < global.UserCount; i++ {
+			if users[i].Id != user.Id {
+				continue
+			}
+
+			err = userService.EditUser(i, model.User{Password: password, Version: users[i].Version})
+			if err != nil {
+				return err
+			}
+
+			userService.userRepo.FindUserByUsername(user.Username, user)
+			color.Green("Password berhasil diganti")
+			helper.Pause()
+
+			return nil
+		}
+
+		return fmt.Errorf("user %s tidak ditemukan", user.Username)
+	}
+
+	helper.Pause()
+
+	return helper.ErrContinue
+}
+
+// Unlock asks the password of the logged-in user again after the screen was locked
+// for inactivity, see helper.LockSession.
+//
+// Parameters:
+//   - user: The model.User representing the currently logged-in user
+//
+// Returns:
+//   - bool: true if the password of the user was entered
+//   - error: The error of the prompt, e.g. promptui.ErrInterrupt on Ctrl+C or io.EOF
+//     when the input ended, so it is not mistaken for a wrong password
+func (userService *userService) Unlock(user model.User) (bool, error) {
+	prompt := promptui.Prompt{
+		Label: "Password " + user.Username,
+		Mask:  '*',
+	}
+
+	password, err := prompt.Run()
+	if err != nil {
+		return false, err
+	}
+
+	return helper.CheckPasswordHash(password, user.Password), nil
+}
+
+// RecordLogin adds a login attempt, timestamped now, to the login history.
+//
+// Parameters:
+//   - username: The username that was entered
+//   - userId: The ID of the account, 0 when the username does not exist
+//   - success: Whether the password matched
+//
+// Returns:
+//   - error: An error if the attempt cannot be recorded, nil otherwise
+func (userService *userService) RecordLogin(username string, userId int, success bool) error {
+	return userService.loginRepo.Record(&model.LoginAttempt{
+		Username: username,
+		UserId:   userId,
+		Success:  success,
+		At:       time.Now(),
+	})
+}
+
+// LoginHistory retrieves the login attempts of a username, newest first.
+//
+// Parameters:
+//   - username: The username to filter on, empty for every attempt
+//   - attempts: A pointer to an array that will be filled with the attempts
+//
+// Returns:
+//   - int: The number of attempts
+//   - error: An error if the history cannot be read, nil otherwise
+func (userService *userService) LoginHistory(username string, attempts *[255]model.LoginAttempt) (int, error) {
+	return userService.loginRepo.GetLoginsByUsername(username, attempts)
+}
+
+// Impersonate marks the user menu as opened by the admin on behalf of a user.
+//
+// Parameters:
+//   - username: The impersonated username, empty to end the impersonation
+//   - readOnly: true to offer only the menu items that do not change data
+func (userService *userService) Impersonate(username string, readOnly bool) {
+	userService.impersonating = username
+	userService.readOnly = username != "" && readOnly
+}
+
 // CreateUser adds a new user to the system.
 // It delegates the creation operation to the underlying repository.
 //
@@ -110,6 +380,13 @@ func (userService *userService) UserPage(chose *string) error {
 // Returns:
 //   - error: An error if the creation fails, nil otherwise
 func (userService *userService) CreateUser(user *model.User) error {
+	hash, err := helper.HashPassword(user.Password)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hash
+
 	return userService.userRepo.Create(user)
 }
 
@@ -150,6 +427,46 @@ func (userService *userService) GetAllUsers(users *[255]model.User) error {
 	return userService.userRepo.GetAllUsers(users)
 }
 
+// CompleteUsername autocompletes a username.
+// It delegates the lookup to the underlying repository.
+//
+// Parameters:
+//   - prefix: The typed start of the username, case is ignored
+//   - limit: The maximum number of usernames to return
+//
+// Returns:
+//   - []string: The matching usernames in alphabetical order
+func (userService *userService) CompleteUsername(prefix string, limit int) []string {
+	return userService.userRepo.CompleteUsername(prefix, limit)
+}
+
+// GetUsers retrieves one page of the users, packed from index 0.
+// It delegates the retrieval operation to the underlying repository.
+//
+// Parameters:
+//   - offset: The number of users to skip
+//   - limit: The maximum number of users to copy
+//   - users: A pointer to an array that will be populated with the page
+//
+// Returns:
+//   - int: The number of users copied
+//   - error: An error if the page is invalid, nil otherwise
+func (userService *userService) GetUsers(offset int, limit int, users *[255]model.User) (int, error) {
+	return userService.userRepo.GetUsers(offset, limit, users)
+}
+
+// ForEachUser streams every user to fn until fn returns false.
+// It delegates the iteration to the underlying repository.
+//
+// Parameters:
+//   - fn: The function called with each user, returning false stops the iteration
+//
+// Returns:
+//   - error: An error if the iteration fails, nil otherwise
+func (userService *userService) ForEachUser(fn func(user model.User) bool) error {
+	return userService.userRepo.ForEachUser(fn)
+}
+
 // SearchUsers finds users whose usernames contain the search string.
 // It delegates the search operation to the underlying repository.
 //
@@ -174,6 +491,15 @@ func (userService *userService) SearchUsers(search string, users *[255]model.Use
 // Returns:
 //   - error: An error if the update fails or index is invalid, nil otherwise
 func (userService *userService) EditUser(index int, data model.User) error {
+	if data.Password != "" {
+		hash, err := helper.HashPassword(data.Password)
+		if err != nil {
+			return err
+		}
+
+		data.Password = hash
+	}
+
 	return userService.userRepo.EditUser(index, data)
 }
 
@@ -188,3 +514,181 @@ func (userService *userService) EditUser(index int, data model.User) error {
 func (userService *userService) DeleteUser(id int) error {
 	return userService.userRepo.DeleteUser(id)
 }
+
+// MarkUserDeleted moves a user into the pending deletion state.
+// It delegates the operation to the underlying repository.
+//
+// Parameters:
+//   - index: The index of the user to delete
+//
+// Returns:
+//   - error: An error if the index is invalid or the user is already deleted, nil otherwise
+func (userService *userService) MarkUserDeleted(index int) error {
+	return userService.userRepo.MarkUserDeleted(index)
+}
+
+// RestoreUser restores a user that is pending deletion.
+// It delegates the operation to the underlying repository.
+//
+// Parameters:
+//   - index: The index of the user to restore
+//
+// Returns:
+//   - error: An error if the index is invalid or the user is not deleted, nil otherwise
+func (userService *userService) RestoreUser(index int) error {
+	return userService.userRepo.RestoreUser(index)
+}
+
+// PurgeDeletedUsers permanently removes the users that were deleted longer than
+// UserDeleteGrace ago. The users are walked from the end so removing one does not shift
+// the users that are still to be checked.
+//
+// Returns:
+//   - int: The number of users removed
+//   - error: An error if a user cannot be removed, nil otherwise
+func (userService *userService) PurgeDeletedUsers() (int, error) {
+	grace := UserDeleteGrace()
+	purged := 0
+
+	for i := global.UserCount - 1; i >= 0; i-- {
+		deletedAt := global.Users[i].DeletedAt
+		if deletedAt.IsZero() || time.Since(deletedAt) < grace {
+			continue
+		}
+
+		err := userService.userRepo.DeleteUser(i)
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// SetUserRole changes the admin role of a user.
+// It delegates the operation to the underlying repository.
+//
+// Parameters:
+//   - index: The index of the user
+//   - role: RoleAdmin, RoleModerator or empty for a regular user
+//
+// Returns:
+//   - error: An error if the role is unknown or the index is invalid, nil otherwise
+func (userService *userService) SetUserRole(index int, role string) error {
+	if role != "" && !IsAdminRole(role) {
+		return fmt.Errorf("role %q tidak dikenal", role)
+	}
+
+	return userService.userRepo.SetUserRole(index, role)
+}
+
+// AdminCount counts the users that can log in with the admin role.
+//
+// Returns:
+//   - int: The number of users with RoleAdmin that are not pending deletion
+func (userService *userService) AdminCount() int {
+	count := 0
+	userService.userRepo.ForEachUser(func(user model.User) bool {
+		if user.Role == RoleAdmin && user.DeletedAt.IsZero() {
+			count++
+		}
+		return true
+	})
+
+	return count
+}
+
+// UserDeleteGrace returns how long a deleted user can be restored before it is removed
+// permanently.
+//
+// Returns:
+//   - time.Duration: The USER_DELETE_GRACE_HOURS setting, 24 hours when it is missing or invalid
+func UserDeleteGrace() time.Duration {
+	hours, err := strconv.Atoi(helper.GetEnv("USER_DELETE_GRACE_HOURS", "24"))
+	if err != nil || hours < 0 {
+		hours = 24
+	}
+
+	return time.Duration(hours) * time.Hour
+}
+
+// completeUsername autocompletes a typed username with the usernames that start with it.
+//
+// When the input is not a complete username but the start of one or more usernames,
+// the matches are offered in a selection list together with the input itself, so the
+// user can pick the full username after typing a few characters. An empty input, a
+// complete username or an input without matches is returned unchanged.
+//
+// Parameters:
+//   - complete: The lookup returning up to limit usernames that start with a prefix
+//   - input: The typed username
+//
+// Returns:
+//   - string: The chosen username
+//   - error: An error if the selection prompt is cancelled
+func completeUsername(complete func(prefix string, limit int) []string, input string) (string, error) {
+	if input == "" {
+		return input, nil
+	}
+
+	matches := complete(input, 10)
+	for _, match := range matches {
+		if match == input {
+			return input, nil
+		}
+	}
+
+	if len(matches) == 0 {
+		return input, nil
+	}
+
+	prompt := promptui.Select{
+		Label: "Lengkapi Username",
+		Items: append(matches, input),
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . | blue }}:",
+			Active:   "\u27A1 {{ . | cyan }}",
+			Inactive: "  {{ . | cyan }}",
+			Selected: "\u2705 {{ . | blue | cyan }}",
+		},
+	}
+
+	_, username, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return username, nil
+}
+
+// completeMentions autocompletes every @mention in a comment text.
+// Each word that starts with @ is completed with completeUsername and replaced by the
+// chosen username.
+//
+// Parameters:
+//   - complete: The lookup returning up to limit usernames that start with a prefix
+//   - text: The comment text
+//
+// Returns:
+//   - string: The text with the completed mentions
+//   - error: An error if a selection prompt is cancelled
+func completeMentions(complete func(prefix string, limit int) []string, text string) (string, error) {
+	words := strings.Split(text, " ")
+	for i, word := range words {
+		name := strings.TrimRight(strings.TrimPrefix(word, "@"), ".,!?;:")
+		if !strings.HasPrefix(word, "@") || name == "" {
+			continue
+		}
+
+		color.Cyan("Mention %s", word)
+		username, err := completeUsername(complete, name)
+		if err != nil {
+			return "", err
+		}
+
+		words[i] = "@" + username + strings.TrimPrefix(word, "@"+name)
+	}
+
+	return strings.Join(words, " "), nil
+}
@@ -1,12 +1,29 @@
 package main
 
-import "tugas-besar/lib"
+import (
+	"os"
+
+	"tugas-besar/lib"
+)
 
 // main is the entry point of the application.
-// It initializes the application by calling lib.Bootstrap(),
+// When a subcommand such as `classify` is given it is run non-interactively by
+// lib.Command and the program exits with its exit code. Otherwise it initializes
+// the application by calling lib.Bootstrap(),
 // which loads environment variables from the .env file,
 // sets up application configuration, and prepares the
 // necessary resources for the application to run.
+// With a leading --pprof flag (or PPROF_ADDR / PPROF_DIR) lib.Profile profiles the run,
+// the profiles are written once the menus or the subcommand have finished.
 func main() {
+	args, stop := lib.Profile(os.Args[1:])
+
+	if len(args) > 0 {
+		code := lib.Command(args)
+		stop()
+		os.Exit(code)
+	}
+
 	lib.Bootstrap()
+	stop()
 }